@@ -0,0 +1,369 @@
+// Package diskbtree is an ordered key-value index stored in a file.
+//
+// Index is B+Tree of fixed-size pages. Pages of committed tree are never
+// overwritten: changed node is copied to a free page (copy-on-write),
+// and Commit atomically switches to the new tree by writing meta page.
+// If process crashes before Commit is done, Open returns the last committed tree.
+package diskbtree
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+const (
+	defaultPageSize  = 4096
+	defaultCacheSize = 256
+
+	minPageSize = 512
+	maxPageSize = 1 << 16
+
+	// rangeBatch is number of items which Range reads under lock before calling `fn`
+	rangeBatch = 64
+)
+
+// Options of DiskBTree, zero values mean defaults
+//
+// # PageSize 	-> size of page in bytes (default 4096). Used only for new file,
+// existing file keeps its page size
+//
+// # CacheSize 	-> max number of pages in LRU cache (default 256)
+type Options struct {
+	PageSize  int
+	CacheSize int
+}
+
+// DiskBTree is the interface of ordered key-value index stored in a file.
+//
+// Put and Delete change the current transaction, Commit makes all changes
+// durable at once. Keys are compared as bytes.
+//
+//	db, err := diskbtree.Open("index.db", nil)
+//	defer db.Close()
+//
+//	_ = db.Put([]byte("user:1"), []byte("alice"))
+//	_ = db.Put([]byte("user:2"), []byte("bob"))
+//	err = db.Commit()
+//
+//	val, err := db.Get([]byte("user:1")) // alice
+//	err = db.Range([]byte("user:"), []byte("user;"), func(key, val []byte) bool {
+//		return true
+//	})
+type DiskBTree interface {
+	// Get returns copy of value by `key`
+	//
+	// if there is no key -> val = nil, err != nil
+	Get(key []byte) ([]byte, error)
+
+	// Put adds `key` with `val`. If key already exists -> replaces its val
+	//
+	// if key is empty or key with val don't fit in a quarter of page -> returns err
+	Put(key, val []byte) error
+
+	// Delete deletes `key`
+	//
+	// if there is no key -> returns err
+	Delete(key []byte) error
+
+	// Range calls `fn` for keys in [from, to) in ascending order until `fn` returns false.
+	// nil `from` means from the first key, nil `to` means to the last key.
+	// `fn` gets copies of key and val.
+	// `fn` is called without lock, so it may use the tree (Get, Put, Delete, Commit...),
+	// Range goes on from the next key after the current one
+	Range(from, to []byte, fn func(key, val []byte) bool) error
+
+	// Commit writes all changes to file and makes them durable
+	Commit() error
+
+	// Rollback forgets all changes after the last Commit
+	Rollback() error
+
+	// Size returns number of keys
+	Size() int
+
+	// Close commits changes and closes file
+	Close() error
+}
+
+// diskBTree - B+Tree in a file.
+//
+// # meta 	-> last committed meta
+//
+// # root 	-> root of the current tree (with uncommitted changes)
+type diskBTree struct {
+	p     *pager
+	meta  meta
+	root  pgid
+	count int
+
+	dirty  bool
+	closed bool
+
+	mu sync.Mutex
+}
+
+// Open opens index in file `path`, file is created if it doesn't exist.
+// `opts` may be nil
+func Open(path string, opts *Options) (*diskBTree, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	t, err := openFile(f, info.Size(), opts)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// openFile opens index in `f` of `size` bytes
+func openFile(f file, size int64, opts *Options) (*diskBTree, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	pageSize := opts.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize < minPageSize || pageSize > maxPageSize {
+		return nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	cacheSize := opts.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = defaultCacheSize
+	}
+
+	if size == 0 {
+		return create(f, pageSize, cacheSize)
+	}
+
+	m, err := readMeta(f, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	t := &diskBTree{
+		p:     newPager(f, int(m.pageSize), cacheSize, m.pageCount),
+		meta:  m,
+		root:  m.root,
+		count: int(m.count),
+	}
+	if err := t.p.reset(m.root, m.pageCount); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// create writes empty tree to new file
+func create(f file, pageSize, cacheSize int) (*diskBTree, error) {
+	t := &diskBTree{
+		p:    newPager(f, pageSize, cacheSize, metaPages),
+		meta: meta{pageSize: uint32(pageSize)},
+	}
+	t.root = t.p.newNode(true).id
+
+	// both meta pages are written, so page size can always be read from page 0
+	for i := 0; i < metaPages; i++ {
+		t.dirty = true
+		if err := t.commit(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Get returns copy of value by `key`
+func (t *diskBTree) Get(key []byte) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, fmt.Errorf(gocollections.ErrClosed)
+	}
+	n, i, found, err := t.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	val := bytes.Clone(n.vals[i])
+	if err := t.p.shrink(); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Put adds `key` with `val`. If key already exists -> replaces its val
+func (t *diskBTree) Put(key, val []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf(gocollections.ErrClosed)
+	}
+	if len(key) == 0 {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	if leafEntryHeader+len(key)+len(val) > t.maxEntrySize() {
+		return fmt.Errorf(gocollections.ErrTooLarge)
+	}
+
+	t.dirty = true
+	root, sep, right, added, err := t.put(t.root, bytes.Clone(key), bytes.Clone(val))
+	if err != nil {
+		return err
+	}
+	if right != 0 {
+		n := t.p.newNode(false)
+		n.keys = [][]byte{sep}
+		n.children = []pgid{root, right}
+		root = n.id
+	}
+	t.root = root
+	if added {
+		t.count++
+	}
+	return t.p.shrink()
+}
+
+// Delete deletes `key`
+func (t *diskBTree) Delete(key []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf(gocollections.ErrClosed)
+	}
+	_, _, found, err := t.lookup(key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	t.dirty = true
+	root, err := t.delete(t.root, key)
+	if err != nil {
+		return err
+	}
+
+	// root with one child is not needed
+	for {
+		n, err := t.p.node(root)
+		if err != nil {
+			return err
+		}
+		if n.leaf || len(n.keys) > 0 {
+			break
+		}
+		t.p.release(n.id)
+		root = n.children[0]
+	}
+	t.root = root
+	t.count--
+	return t.p.shrink()
+}
+
+// Range calls `fn` for keys in [from, to) in ascending order until `fn` returns false.
+// Items are read by batches under lock, `fn` is called after lock is released
+func (t *diskBTree) Range(from, to []byte, fn func(key, val []byte) bool) error {
+	for {
+		keys, vals, err := t.rangeBatch(from, to)
+		if err != nil {
+			return err
+		}
+		for i := range keys {
+			if !fn(keys[i], vals[i]) {
+				return nil
+			}
+		}
+		if len(keys) < rangeBatch {
+			return nil
+		}
+		// the smallest key after the last one, `fn` may keep the last key so it is copied
+		from = append(bytes.Clone(keys[len(keys)-1]), 0)
+	}
+}
+
+// rangeBatch returns copies of up to rangeBatch items of [from, to)
+func (t *diskBTree) rangeBatch(from, to []byte) ([][]byte, [][]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, nil, fmt.Errorf(gocollections.ErrClosed)
+	}
+	var keys, vals [][]byte
+	err := t.scan(from, to, func(key, val []byte) bool {
+		keys = append(keys, key)
+		vals = append(vals, val)
+		return len(keys) < rangeBatch
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, vals, t.p.shrink()
+}
+
+// Commit writes all changes to file and makes them durable
+func (t *diskBTree) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf(gocollections.ErrClosed)
+	}
+	return t.commit()
+}
+
+// Rollback forgets all changes after the last Commit
+func (t *diskBTree) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf(gocollections.ErrClosed)
+	}
+	if !t.dirty {
+		return nil
+	}
+	t.root = t.meta.root
+	t.count = int(t.meta.count)
+	t.dirty = false
+	return t.p.reset(t.meta.root, t.meta.pageCount)
+}
+
+// Size returns number of keys
+func (t *diskBTree) Size() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.count
+}
+
+// Close commits changes and closes file
+func (t *diskBTree) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf(gocollections.ErrClosed)
+	}
+	t.closed = true
+	err := t.commit()
+	if closeErr := t.p.f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,311 @@
+package diskbtree
+
+import (
+	"bytes"
+	"fmt"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// maxEntrySize is the max size of one entry: node split in halves by size
+// always gives nodes which fit in page
+func (t *diskBTree) maxEntrySize() int {
+	return (t.p.pageSize - pageHeaderSize - childSize) / 4
+}
+
+// readMeta reads both meta pages and returns valid one with greater txid.
+// Page size is taken from the first meta, if it is broken - from `pageSize`
+func readMeta(f file, pageSize int) (meta, error) {
+	buf := make([]byte, metaSize)
+	var metas []meta
+
+	if _, err := f.ReadAt(buf, 0); err == nil {
+		if m, err := decodeMeta(buf); err == nil {
+			metas = append(metas, m)
+			pageSize = int(m.pageSize)
+		}
+	}
+	if _, err := f.ReadAt(buf, int64(pageSize)); err == nil {
+		if m, err := decodeMeta(buf); err == nil && int(m.pageSize) == pageSize {
+			metas = append(metas, m)
+		}
+	}
+
+	if len(metas) == 0 {
+		return meta{}, fmt.Errorf(gocollections.ErrCorrupted)
+	}
+	best := metas[0]
+	for _, m := range metas[1:] {
+		if m.txid > best.txid {
+			best = m
+		}
+	}
+	return best, nil
+}
+
+// commit writes dirty pages, then meta. Sync after pages guarantees
+// that meta never points to pages which are not on disk
+func (t *diskBTree) commit() error {
+	if !t.dirty {
+		return nil
+	}
+	if err := t.p.flush(); err != nil {
+		return err
+	}
+	if err := t.p.f.Sync(); err != nil {
+		return err
+	}
+
+	m := meta{
+		pageSize:  uint32(t.p.pageSize),
+		root:      t.root,
+		pageCount: t.p.pageCount,
+		txid:      t.meta.txid + 1,
+		count:     uint64(t.count),
+	}
+	if err := t.p.writeMeta(m); err != nil {
+		return err
+	}
+	if err := t.p.f.Sync(); err != nil {
+		return err
+	}
+
+	t.meta = m
+	t.p.commit()
+	t.dirty = false
+	return nil
+}
+
+// lookup returns leaf where `key` is or would be and index in it
+func (t *diskBTree) lookup(key []byte) (*node, int, bool, error) {
+	n, err := t.p.node(t.root)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	for !n.leaf {
+		if n, err = t.p.node(n.children[n.childIndex(key)]); err != nil {
+			return nil, 0, false, err
+		}
+	}
+	i, found := n.search(key)
+	return n, i, found, nil
+}
+
+// put inserts key to subtree `id` and returns new id of subtree (it is copied on write).
+// If node overflows, it is split and separator with right node are returned
+func (t *diskBTree) put(id pgid, key, val []byte) (newID pgid, sep []byte, right pgid, added bool, err error) {
+	n, err := t.p.node(id)
+	if err != nil {
+		return 0, nil, 0, false, err
+	}
+	n = t.p.writable(n)
+
+	if n.leaf {
+		i, found := n.search(key)
+		if found {
+			n.vals[i] = val
+		} else {
+			n.keys = insertAt(n.keys, i, key)
+			n.vals = insertAt(n.vals, i, val)
+			added = true
+		}
+	} else {
+		i := n.childIndex(key)
+		var childSep []byte
+		var childRight pgid
+		n.children[i], childSep, childRight, added, err = t.put(n.children[i], key, val)
+		if err != nil {
+			return 0, nil, 0, false, err
+		}
+		if childRight != 0 {
+			n.keys = insertAt(n.keys, i, childSep)
+			n.children = insertAt(n.children, i+1, childRight)
+		}
+	}
+
+	if n.size() > t.p.pageSize {
+		sep, right = t.split(n)
+	}
+	return n.id, sep, right, added, nil
+}
+
+// split moves the second half of node (by size) to new node
+func (t *diskBTree) split(n *node) ([]byte, pgid) {
+	half := n.size() / 2
+	size := pageHeaderSize
+	m := 0
+	for ; m < len(n.keys)-1; m++ {
+		if n.leaf {
+			size += leafEntryHeader + len(n.keys[m]) + len(n.vals[m])
+		} else {
+			size += branchEntryHeader + len(n.keys[m])
+		}
+		if size > half {
+			break
+		}
+	}
+	m = max(m, 1)
+
+	right := t.p.newNode(n.leaf)
+	if n.leaf {
+		// leaf: right gets keys[m:], its first key is copied up
+		right.keys = append([][]byte(nil), n.keys[m:]...)
+		right.vals = append([][]byte(nil), n.vals[m:]...)
+		n.keys, n.vals = n.keys[:m:m], n.vals[:m:m]
+		return right.keys[0], right.id
+	}
+	// branch: keys[m] goes up, right gets keys after it
+	sep := n.keys[m]
+	right.keys = append([][]byte(nil), n.keys[m+1:]...)
+	right.children = append([]pgid(nil), n.children[m+1:]...)
+	n.keys, n.children = n.keys[:m:m], n.children[:m+1:m+1]
+	return sep, right.id
+}
+
+// delete deletes existing key from subtree `id` and returns new id of subtree.
+// Child which became smaller than a quarter of page is merged with sibling if they fit in page
+func (t *diskBTree) delete(id pgid, key []byte) (pgid, error) {
+	n, err := t.p.node(id)
+	if err != nil {
+		return 0, err
+	}
+	n = t.p.writable(n)
+
+	if n.leaf {
+		if i, found := n.search(key); found {
+			n.keys = removeAt(n.keys, i)
+			n.vals = removeAt(n.vals, i)
+		}
+		return n.id, nil
+	}
+
+	i := n.childIndex(key)
+	if n.children[i], err = t.delete(n.children[i], key); err != nil {
+		return 0, err
+	}
+	if err := t.rebalance(n, i); err != nil {
+		return 0, err
+	}
+	return n.id, nil
+}
+
+// rebalance merges small child `i` of branch `n` with its sibling
+func (t *diskBTree) rebalance(n *node, i int) error {
+	child, err := t.p.node(n.children[i])
+	if err != nil {
+		return err
+	}
+	if child.size() >= t.p.pageSize/4 || len(n.children) < 2 {
+		return nil
+	}
+	if i == len(n.children)-1 {
+		i--
+	}
+
+	left, err := t.p.node(n.children[i])
+	if err != nil {
+		return err
+	}
+	right, err := t.p.node(n.children[i+1])
+	if err != nil {
+		return err
+	}
+	merged := left.size() + right.size() - pageHeaderSize
+	if !left.leaf {
+		merged += branchEntryHeader + len(n.keys[i]) - childSize
+	}
+	if merged > t.p.pageSize {
+		return nil
+	}
+
+	left = t.p.writable(left)
+	if left.leaf {
+		left.keys = append(left.keys, right.keys...)
+		left.vals = append(left.vals, right.vals...)
+	} else {
+		left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	t.p.release(right.id)
+
+	n.children[i] = left.id
+	n.keys = removeAt(n.keys, i)
+	n.children = removeAt(n.children, i+1)
+	return nil
+}
+
+// scan goes through leaves with stack of branches and positions in them
+func (t *diskBTree) scan(from, to []byte, fn func(key, val []byte) bool) error {
+	type frame struct {
+		n *node
+		i int
+	}
+	var stack []frame
+
+	n, err := t.p.node(t.root)
+	if err != nil {
+		return err
+	}
+	for !n.leaf {
+		i := 0
+		if from != nil {
+			i = n.childIndex(from)
+		}
+		stack = append(stack, frame{n, i})
+		if n, err = t.p.node(n.children[i]); err != nil {
+			return err
+		}
+	}
+	i := 0
+	if from != nil {
+		i, _ = n.search(from)
+	}
+
+	for {
+		for ; i < len(n.keys); i++ {
+			if to != nil && bytes.Compare(n.keys[i], to) >= 0 {
+				return nil
+			}
+			if !fn(bytes.Clone(n.keys[i]), bytes.Clone(n.vals[i])) {
+				return nil
+			}
+		}
+
+		// go up to branch which has next child, then down to its leftmost leaf
+		for len(stack) > 0 && stack[len(stack)-1].i == len(stack[len(stack)-1].n.children)-1 {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			return nil
+		}
+		// scan doesn't change nodes, so they can be evicted while it holds them
+		if err := t.p.shrink(); err != nil {
+			return err
+		}
+		top := &stack[len(stack)-1]
+		top.i++
+		if n, err = t.p.node(top.n.children[top.i]); err != nil {
+			return err
+		}
+		for !n.leaf {
+			stack = append(stack, frame{n, 0})
+			if n, err = t.p.node(n.children[0]); err != nil {
+				return err
+			}
+		}
+		i = 0
+	}
+}
+
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	return s[:len(s)-1]
+}
@@ -0,0 +1,449 @@
+package diskbtree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = &Options{PageSize: 512, CacheSize: 8}
+
+func openTest(t *testing.T, path string) *diskBTree {
+	t.Helper()
+	db, err := Open(path, testOptions)
+	require.NoError(t, err)
+	return db
+}
+
+func key(i int) []byte {
+	return []byte(fmt.Sprintf("key-%06d", i))
+}
+
+// checkDiskBTree checks order of keys, separators, equal depth of leaves,
+// size of pages and that tree contains exactly `expected`
+func checkDiskBTree(t *testing.T, db *diskBTree, expected map[string]string) {
+	t.Helper()
+
+	used := make(map[pgid]bool)
+	leafDepth := -1
+	var check func(id pgid, lo, hi []byte, depth int)
+	check = func(id pgid, lo, hi []byte, depth int) {
+		require.False(t, used[id], "page %d is used twice", id)
+		used[id] = true
+		n, err := db.p.node(id)
+		require.NoError(t, err)
+		require.LessOrEqual(t, n.size(), db.p.pageSize)
+
+		for i, k := range n.keys {
+			if i > 0 {
+				require.Negative(t, bytes.Compare(n.keys[i-1], k))
+			}
+			if lo != nil {
+				require.GreaterOrEqual(t, bytes.Compare(k, lo), 0)
+			}
+			if hi != nil {
+				require.Negative(t, bytes.Compare(k, hi))
+			}
+		}
+		if n.leaf {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			require.Equal(t, leafDepth, depth, "leaves on different depth")
+			return
+		}
+		require.Len(t, n.children, len(n.keys)+1)
+		for i, child := range n.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = n.keys[i-1]
+			}
+			if i < len(n.keys) {
+				childHi = n.keys[i]
+			}
+			check(child, childLo, childHi, depth+1)
+		}
+	}
+	check(db.root, nil, nil, 0)
+	require.NoError(t, db.p.shrink())
+
+	var keys []string
+	err := db.Range(nil, nil, func(k, v []byte) bool {
+		keys = append(keys, string(k))
+		assert.Equal(t, expected[string(k)], string(v))
+		return true
+	})
+	require.NoError(t, err)
+
+	var expectedKeys []string
+	for k := range expected {
+		expectedKeys = append(expectedKeys, k)
+	}
+	slices.Sort(expectedKeys)
+	require.Equal(t, expectedKeys, keys)
+	require.Equal(t, len(expected), db.Size())
+}
+
+func TestDiskBTree_Basic(t *testing.T) {
+	db := openTest(t, filepath.Join(t.TempDir(), "basic.db"))
+	defer db.Close()
+
+	_, err := db.Get([]byte("a"))
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+
+	require.NoError(t, db.Put([]byte("b"), []byte("2")))
+	require.NoError(t, db.Put([]byte("a"), []byte("1")))
+	require.NoError(t, db.Put([]byte("c"), []byte("3")))
+	require.NoError(t, db.Put([]byte("b"), []byte("22")))
+	assert.Equal(t, 3, db.Size())
+
+	val, err := db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("22"), val)
+
+	// returned value is a copy
+	val[0] = 'x'
+	val, _ = db.Get([]byte("b"))
+	assert.Equal(t, []byte("22"), val)
+
+	require.NoError(t, db.Delete([]byte("b")))
+	assert.EqualError(t, db.Delete([]byte("b")), gocollections.ErrNotFound)
+	_, err = db.Get([]byte("b"))
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+
+	assert.EqualError(t, db.Put(nil, []byte("1")), gocollections.ErrInvalidData)
+	assert.EqualError(t, db.Put([]byte("big"), make([]byte, 512)), gocollections.ErrTooLarge)
+
+	checkDiskBTree(t, db, map[string]string{"a": "1", "c": "3"})
+}
+
+func TestDiskBTree_Random(t *testing.T) {
+	db := openTest(t, filepath.Join(t.TempDir(), "random.db"))
+	defer db.Close()
+
+	rnd := rand.New(rand.NewSource(1))
+	expected := make(map[string]string)
+	for i := 0; i < 5000; i++ {
+		k := key(rnd.Intn(1500))
+		if rnd.Intn(3) == 0 {
+			err := db.Delete(k)
+			if _, ok := expected[string(k)]; ok {
+				require.NoError(t, err)
+				delete(expected, string(k))
+			} else {
+				require.EqualError(t, err, gocollections.ErrNotFound)
+			}
+		} else {
+			val := bytes.Repeat([]byte{byte('a' + rnd.Intn(26))}, rnd.Intn(100))
+			require.NoError(t, db.Put(k, val))
+			expected[string(k)] = string(val)
+		}
+		if i%1000 == 0 {
+			require.NoError(t, db.Commit())
+			checkDiskBTree(t, db, expected)
+		}
+	}
+	checkDiskBTree(t, db, expected)
+
+	for k := range expected {
+		require.NoError(t, db.Delete([]byte(k)))
+	}
+	checkDiskBTree(t, db, map[string]string{})
+	n, err := db.p.node(db.root)
+	require.NoError(t, err)
+	assert.True(t, n.leaf)
+}
+
+func TestDiskBTree_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "persistence.db")
+	db := openTest(t, path)
+
+	expected := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, db.Put(key(i), []byte(fmt.Sprint(i))))
+		expected[string(key(i))] = fmt.Sprint(i)
+	}
+	require.NoError(t, db.Close())
+	assert.EqualError(t, db.Close(), gocollections.ErrClosed)
+	_, err := db.Get(key(1))
+	assert.EqualError(t, err, gocollections.ErrClosed)
+
+	// page size is taken from file
+	db, err = Open(path, &Options{PageSize: 4096})
+	require.NoError(t, err)
+	assert.Equal(t, 512, db.p.pageSize)
+	checkDiskBTree(t, db, expected)
+
+	for i := 0; i < 1000; i += 2 {
+		require.NoError(t, db.Delete(key(i)))
+		delete(expected, string(key(i)))
+	}
+	require.NoError(t, db.Close())
+
+	db = openTest(t, path)
+	defer db.Close()
+	checkDiskBTree(t, db, expected)
+}
+
+func TestDiskBTree_Rollback(t *testing.T) {
+	db := openTest(t, filepath.Join(t.TempDir(), "rollback.db"))
+	defer db.Close()
+
+	expected := make(map[string]string)
+	for i := 0; i < 500; i++ {
+		require.NoError(t, db.Put(key(i), []byte("v")))
+		expected[string(key(i))] = "v"
+	}
+	require.NoError(t, db.Commit())
+
+	for i := 0; i < 500; i++ {
+		if i%3 == 0 {
+			require.NoError(t, db.Delete(key(i)))
+		} else {
+			require.NoError(t, db.Put(key(i+1000), []byte("new")))
+		}
+	}
+	require.NoError(t, db.Rollback())
+	checkDiskBTree(t, db, expected)
+
+	// pages of rolled back transaction are reused
+	require.NoError(t, db.Put(key(5000), []byte("v")))
+	expected[string(key(5000))] = "v"
+	require.NoError(t, db.Commit())
+	checkDiskBTree(t, db, expected)
+}
+
+func TestDiskBTree_Range(t *testing.T) {
+	db := openTest(t, filepath.Join(t.TempDir(), "range.db"))
+	defer db.Close()
+
+	for i := 0; i < 1000; i += 2 {
+		require.NoError(t, db.Put(key(i), key(i)))
+	}
+
+	collect := func(from, to []byte, limit int) []int {
+		var res []int
+		err := db.Range(from, to, func(k, v []byte) bool {
+			assert.Equal(t, k, v)
+			var i int
+			fmt.Sscanf(string(k), "key-%d", &i)
+			res = append(res, i)
+			return len(res) < limit
+		})
+		require.NoError(t, err)
+		return res
+	}
+	brute := func(from, to, limit int) []int {
+		var res []int
+		for i := 0; i < 1000 && len(res) < limit; i += 2 {
+			if i >= from && i < to {
+				res = append(res, i)
+			}
+		}
+		return res
+	}
+
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		from, to := rnd.Intn(1100), rnd.Intn(1100)
+		limit := 1 + rnd.Intn(600)
+		assert.Equal(t, brute(from, to, limit), collect(key(from), key(to), limit))
+	}
+	assert.Equal(t, brute(0, 1000, 1000), collect(nil, nil, 1000))
+	assert.Equal(t, brute(0, 101, 1000), collect(nil, key(101), 1000))
+	assert.Equal(t, brute(901, 1000, 1000), collect(key(901), nil, 1000))
+	assert.Empty(t, collect([]byte("z"), nil, 1000))
+}
+
+func TestDiskBTree_RangeCallback(t *testing.T) {
+	db := openTest(t, filepath.Join(t.TempDir(), "callback.db"))
+	defer db.Close()
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, db.Put(key(i), key(i)))
+	}
+
+	// `fn` is called without lock, so it can use the tree
+	visited := 0
+	err := db.Range(nil, nil, func(k, v []byte) bool {
+		val, err := db.Get(k)
+		require.NoError(t, err)
+		assert.Equal(t, v, val)
+		require.NoError(t, db.Delete(k))
+		if visited%100 == 0 {
+			require.NoError(t, db.Commit())
+		}
+		visited++
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1000, visited)
+	assert.Equal(t, 0, db.Size())
+}
+
+func TestDiskBTree_PageReuse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reuse.db")
+	db := openTest(t, path)
+	defer db.Close()
+
+	fill := func() {
+		for i := 0; i < 2000; i++ {
+			require.NoError(t, db.Put(key(i), make([]byte, 50)))
+		}
+		require.NoError(t, db.Commit())
+		for i := 0; i < 2000; i++ {
+			require.NoError(t, db.Delete(key(i)))
+		}
+		require.NoError(t, db.Commit())
+	}
+
+	fill()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	size := info.Size()
+
+	for i := 0; i < 5; i++ {
+		fill()
+	}
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, size, info.Size(), "freed pages must be reused")
+	assert.Equal(t, 0, db.Size())
+}
+
+func TestDiskBTree_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupted.db")
+	require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte{0xff}, 4096), 0o644))
+
+	_, err := Open(path, nil)
+	assert.EqualError(t, err, gocollections.ErrCorrupted)
+
+	_, err = Open(filepath.Join(t.TempDir(), "bad.db"), &Options{PageSize: 100})
+	assert.EqualError(t, err, gocollections.ErrInvalidData)
+}
+
+// crashFile simulates crash: write number `limit` is written partially,
+// then all operations fail
+type crashFile struct {
+	*os.File
+	limit   int
+	writes  int
+	crashed bool
+}
+
+var errCrash = errors.New("crash")
+
+func (f *crashFile) WriteAt(p []byte, off int64) (int, error) {
+	if f.crashed {
+		return 0, errCrash
+	}
+	f.writes++
+	if f.writes == f.limit {
+		f.crashed = true
+		n, _ := f.File.WriteAt(p[:len(p)/2], off)
+		return n, errCrash
+	}
+	return f.File.WriteAt(p, off)
+}
+
+func (f *crashFile) Sync() error {
+	if f.crashed {
+		return errCrash
+	}
+	return f.File.Sync()
+}
+
+func copyFile(t *testing.T, dst, src string) {
+	t.Helper()
+	in, err := os.Open(src)
+	require.NoError(t, err)
+	defer in.Close()
+	out, err := os.Create(dst)
+	require.NoError(t, err)
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	require.NoError(t, err)
+}
+
+func TestDiskBTree_CrashRecovery(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.db")
+
+	// committed state A
+	db := openTest(t, base)
+	committed := make(map[string]string)
+	for i := 0; i < 800; i++ {
+		require.NoError(t, db.Put(key(i), []byte(fmt.Sprint("a", i))))
+		committed[string(key(i))] = fmt.Sprint("a", i)
+	}
+	require.NoError(t, db.Close())
+
+	// transaction which changes A to B
+	updated := make(map[string]string)
+	for k, v := range committed {
+		updated[k] = v
+	}
+	change := func(db *diskBTree) error {
+		for i := 0; i < 800; i++ {
+			var err error
+			switch i % 4 {
+			case 0:
+				err = db.Delete(key(i))
+				delete(updated, string(key(i)))
+			case 1:
+				err = db.Put(key(i), []byte(fmt.Sprint("b", i)))
+				updated[string(key(i))] = fmt.Sprint("b", i)
+			case 2:
+				err = db.Put(key(i+1000), []byte("new"))
+				updated[string(key(i+1000))] = "new"
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return db.Commit()
+	}
+
+	crashes := 0
+	for limit := 1; ; limit++ {
+		path := filepath.Join(dir, fmt.Sprintf("crash-%d.db", limit))
+		copyFile(t, path, base)
+
+		f, err := os.OpenFile(path, os.O_RDWR, 0o644)
+		require.NoError(t, err)
+		info, err := f.Stat()
+		require.NoError(t, err)
+		cf := &crashFile{File: f, limit: limit}
+
+		db, err := openFile(cf, info.Size(), testOptions)
+		require.NoError(t, err)
+		err = change(db)
+		require.NoError(t, f.Close())
+
+		// process is dead, open file again
+		db = openTest(t, path)
+		if err != nil {
+			crashes++
+			checkDiskBTree(t, db, committed)
+			require.NoError(t, db.Close())
+			continue
+		}
+
+		// writes are not exhausted -> transaction is committed
+		checkDiskBTree(t, db, updated)
+		require.NoError(t, db.Close())
+		break
+	}
+	// crashes happened on eviction, on flush and on meta
+	assert.Greater(t, crashes, 10)
+}
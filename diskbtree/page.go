@@ -0,0 +1,228 @@
+package diskbtree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"sort"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// pgid is the number of page in file, page starts at pgid * pageSize
+type pgid uint64
+
+const (
+	// pages 0 and 1 are meta pages, tree pages start from 2
+	metaPages = 2
+
+	leafPage   = 1
+	branchPage = 2
+
+	// page header: type (1) + reserved (1) + count of keys (2)
+	pageHeaderSize = 4
+
+	// leaf entry: key len (2) + val len (4) + key + val
+	leafEntryHeader = 6
+
+	// branch entry: key len (2) + key + child (8), plus child0 before entries
+	branchEntryHeader = 10
+	childSize         = 8
+
+	magic         = 0x44425431 // "DBT1"
+	formatVersion = 1
+	metaSize      = 48
+)
+
+// node is the decoded page of B+Tree.
+//
+// # keys 	-> keys of leaf or separators of branch
+//
+// # vals 	-> values of leaf
+//
+// # children 	-> len(keys)+1 children of branch: all keys of children[i] are < keys[i],
+// all keys of children[i+1] are >= keys[i]
+//
+// # dirty 	-> node was changed and is not written to file yet
+type node struct {
+	id       pgid
+	leaf     bool
+	keys     [][]byte
+	vals     [][]byte
+	children []pgid
+	dirty    bool
+}
+
+// size returns number of bytes of encoded node
+func (n *node) size() int {
+	size := pageHeaderSize
+	if !n.leaf {
+		size += childSize
+	}
+	for i, k := range n.keys {
+		if n.leaf {
+			size += leafEntryHeader + len(k) + len(n.vals[i])
+		} else {
+			size += branchEntryHeader + len(k)
+		}
+	}
+	return size
+}
+
+// search returns index of the first key >= `key` and true if it is equal
+func (n *node) search(key []byte) (int, bool) {
+	i := sort.Search(len(n.keys), func(i int) bool {
+		return bytes.Compare(n.keys[i], key) >= 0
+	})
+	return i, i < len(n.keys) && bytes.Equal(n.keys[i], key)
+}
+
+// childIndex returns index of child of branch where `key` may be
+func (n *node) childIndex(key []byte) int {
+	return sort.Search(len(n.keys), func(i int) bool {
+		return bytes.Compare(n.keys[i], key) > 0
+	})
+}
+
+// clone returns copy of node with new page id.
+// Keys and values are shared: they are never changed in place
+func (n *node) clone(id pgid) *node {
+	c := &node{id: id, leaf: n.leaf, dirty: true}
+	c.keys = append(make([][]byte, 0, len(n.keys)+1), n.keys...)
+	if n.leaf {
+		c.vals = append(make([][]byte, 0, len(n.vals)+1), n.vals...)
+	} else {
+		c.children = append(make([]pgid, 0, len(n.children)+1), n.children...)
+	}
+	return c
+}
+
+// encode writes node to `buf` of page size
+func (n *node) encode(buf []byte) {
+	clear(buf)
+	buf[0] = branchPage
+	if n.leaf {
+		buf[0] = leafPage
+	}
+	binary.LittleEndian.PutUint16(buf[2:], uint16(len(n.keys)))
+
+	pos := pageHeaderSize
+	if !n.leaf {
+		binary.LittleEndian.PutUint64(buf[pos:], uint64(n.children[0]))
+		pos += childSize
+	}
+	for i, k := range n.keys {
+		binary.LittleEndian.PutUint16(buf[pos:], uint16(len(k)))
+		pos += 2
+		if n.leaf {
+			binary.LittleEndian.PutUint32(buf[pos:], uint32(len(n.vals[i])))
+			pos += 4
+			pos += copy(buf[pos:], k)
+			pos += copy(buf[pos:], n.vals[i])
+		} else {
+			pos += copy(buf[pos:], k)
+			binary.LittleEndian.PutUint64(buf[pos:], uint64(n.children[i+1]))
+			pos += childSize
+		}
+	}
+}
+
+// decodeNode reads node from page `buf`. Keys and values point into `buf`
+func decodeNode(id pgid, buf []byte) (*node, error) {
+	n := &node{id: id}
+	switch buf[0] {
+	case leafPage:
+		n.leaf = true
+	case branchPage:
+	default:
+		return nil, fmt.Errorf(gocollections.ErrCorrupted)
+	}
+
+	count := int(binary.LittleEndian.Uint16(buf[2:]))
+	n.keys = make([][]byte, count)
+	pos := pageHeaderSize
+	if n.leaf {
+		n.vals = make([][]byte, count)
+	} else {
+		n.children = make([]pgid, count+1)
+		if pos+childSize > len(buf) {
+			return nil, fmt.Errorf(gocollections.ErrCorrupted)
+		}
+		n.children[0] = pgid(binary.LittleEndian.Uint64(buf[pos:]))
+		pos += childSize
+	}
+
+	for i := 0; i < count; i++ {
+		if pos+leafEntryHeader > len(buf) {
+			return nil, fmt.Errorf(gocollections.ErrCorrupted)
+		}
+		klen := int(binary.LittleEndian.Uint16(buf[pos:]))
+		pos += 2
+		if n.leaf {
+			vlen := int(binary.LittleEndian.Uint32(buf[pos:]))
+			pos += 4
+			if pos+klen+vlen > len(buf) {
+				return nil, fmt.Errorf(gocollections.ErrCorrupted)
+			}
+			n.keys[i] = buf[pos : pos+klen : pos+klen]
+			pos += klen
+			n.vals[i] = buf[pos : pos+vlen : pos+vlen]
+			pos += vlen
+		} else {
+			if pos+klen+childSize > len(buf) {
+				return nil, fmt.Errorf(gocollections.ErrCorrupted)
+			}
+			n.keys[i] = buf[pos : pos+klen : pos+klen]
+			pos += klen
+			n.children[i+1] = pgid(binary.LittleEndian.Uint64(buf[pos:]))
+			pos += childSize
+		}
+	}
+	return n, nil
+}
+
+// meta is the root of committed tree. There are 2 meta pages,
+// commit writes to the older one, so the newer one stays valid if commit fails.
+//
+// # txid 	-> number of commit, meta with greater txid is newer
+//
+// # pageCount 	-> number of pages in file used by committed tree
+//
+// # count 	-> number of keys
+type meta struct {
+	pageSize  uint32
+	root      pgid
+	pageCount pgid
+	txid      uint64
+	count     uint64
+}
+
+func (m *meta) encode(buf []byte) {
+	clear(buf[:metaSize])
+	binary.LittleEndian.PutUint32(buf[0:], magic)
+	binary.LittleEndian.PutUint32(buf[4:], formatVersion)
+	binary.LittleEndian.PutUint32(buf[8:], m.pageSize)
+	binary.LittleEndian.PutUint64(buf[12:], uint64(m.root))
+	binary.LittleEndian.PutUint64(buf[20:], uint64(m.pageCount))
+	binary.LittleEndian.PutUint64(buf[28:], m.txid)
+	binary.LittleEndian.PutUint64(buf[36:], m.count)
+	binary.LittleEndian.PutUint32(buf[44:], crc32.ChecksumIEEE(buf[:44]))
+}
+
+// decodeMeta returns err if meta is not written or written partially
+func decodeMeta(buf []byte) (meta, error) {
+	var m meta
+	if len(buf) < metaSize ||
+		binary.LittleEndian.Uint32(buf[0:]) != magic ||
+		binary.LittleEndian.Uint32(buf[4:]) != formatVersion ||
+		binary.LittleEndian.Uint32(buf[44:]) != crc32.ChecksumIEEE(buf[:44]) {
+		return m, fmt.Errorf(gocollections.ErrCorrupted)
+	}
+	m.pageSize = binary.LittleEndian.Uint32(buf[8:])
+	m.root = pgid(binary.LittleEndian.Uint64(buf[12:]))
+	m.pageCount = pgid(binary.LittleEndian.Uint64(buf[20:]))
+	m.txid = binary.LittleEndian.Uint64(buf[28:])
+	m.count = binary.LittleEndian.Uint64(buf[36:])
+	return m, nil
+}
@@ -0,0 +1,71 @@
+package diskbtree
+
+import (
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_EncodeDecode(t *testing.T) {
+	buf := make([]byte, 512)
+
+	leaf := &node{
+		id:   3,
+		leaf: true,
+		keys: [][]byte{[]byte("a"), []byte("bb"), []byte("ccc")},
+		vals: [][]byte{[]byte("1"), {}, []byte("333")},
+	}
+	leaf.encode(buf)
+	n, err := decodeNode(3, buf)
+	require.NoError(t, err)
+	assert.True(t, n.leaf)
+	assert.Equal(t, leaf.keys, n.keys)
+	assert.Equal(t, leaf.vals, n.vals)
+	assert.Equal(t, leaf.size(), pageHeaderSize+3*leafEntryHeader+6+4)
+
+	branch := &node{
+		id:       4,
+		keys:     [][]byte{[]byte("k"), []byte("m")},
+		children: []pgid{10, 11, 12},
+	}
+	branch.encode(buf)
+	n, err = decodeNode(4, buf)
+	require.NoError(t, err)
+	assert.False(t, n.leaf)
+	assert.Equal(t, branch.keys, n.keys)
+	assert.Equal(t, branch.children, n.children)
+
+	assert.Equal(t, 0, n.childIndex([]byte("a")))
+	assert.Equal(t, 1, n.childIndex([]byte("k")))
+	assert.Equal(t, 2, n.childIndex([]byte("z")))
+
+	buf[0] = 0
+	_, err = decodeNode(4, buf)
+	assert.EqualError(t, err, gocollections.ErrCorrupted)
+
+	// count of keys is more than page has
+	branch.encode(buf)
+	buf[2] = 0xff
+	_, err = decodeNode(4, buf)
+	assert.EqualError(t, err, gocollections.ErrCorrupted)
+}
+
+func TestMeta_EncodeDecode(t *testing.T) {
+	buf := make([]byte, metaSize)
+	m := meta{pageSize: 4096, root: 7, pageCount: 20, txid: 5, count: 100}
+	m.encode(buf)
+
+	decoded, err := decodeMeta(buf)
+	require.NoError(t, err)
+	assert.Equal(t, m, decoded)
+
+	// torn write breaks checksum
+	buf[30] ^= 1
+	_, err = decodeMeta(buf)
+	assert.EqualError(t, err, gocollections.ErrCorrupted)
+
+	_, err = decodeMeta(make([]byte, metaSize))
+	assert.EqualError(t, err, gocollections.ErrCorrupted)
+}
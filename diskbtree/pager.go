@@ -0,0 +1,228 @@
+package diskbtree
+
+import (
+	"container/list"
+	"fmt"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// file is the part of *os.File used by pager
+type file interface {
+	ReadAt(p []byte, off int64) (int, error)
+	WriteAt(p []byte, off int64) (int, error)
+	Sync() error
+	Close() error
+}
+
+// pager reads and writes pages and keeps decoded nodes in LRU cache.
+//
+// Pages of committed tree are never overwritten (copy-on-write):
+// node of committed tree is copied to new page before change,
+// old page becomes free only after commit.
+//
+// # free 	-> pages which can be used now
+//
+// # pending 	-> pages of committed tree freed by current transaction,
+// they become free after commit
+//
+// # fresh 	-> pages allocated by current transaction,
+// they are not in committed tree and can be changed in place
+type pager struct {
+	f        file
+	pageSize int
+
+	cache    map[pgid]*list.Element
+	lru      *list.List
+	capacity int
+
+	free      []pgid
+	pending   []pgid
+	fresh     map[pgid]bool
+	pageCount pgid
+
+	buf []byte
+}
+
+func newPager(f file, pageSize, capacity int, pageCount pgid) *pager {
+	return &pager{
+		f:         f,
+		pageSize:  pageSize,
+		cache:     make(map[pgid]*list.Element),
+		lru:       list.New(),
+		capacity:  max(capacity, 1),
+		fresh:     make(map[pgid]bool),
+		pageCount: pageCount,
+		buf:       make([]byte, pageSize),
+	}
+}
+
+// node returns node from cache or reads it from file
+func (p *pager) node(id pgid) (*node, error) {
+	if e, ok := p.cache[id]; ok {
+		p.lru.MoveToFront(e)
+		return e.Value.(*node), nil
+	}
+	if id < metaPages || id >= p.pageCount {
+		return nil, fmt.Errorf(gocollections.ErrCorrupted)
+	}
+
+	// decoded keys point into buf, so every page has its own buf
+	buf := make([]byte, p.pageSize)
+	if _, err := p.f.ReadAt(buf, int64(id)*int64(p.pageSize)); err != nil {
+		return nil, err
+	}
+	n, err := decodeNode(id, buf)
+	if err != nil {
+		return nil, err
+	}
+	p.cache[id] = p.lru.PushFront(n)
+	return n, nil
+}
+
+// writable returns node which can be changed: fresh node itself
+// or its copy on the new page (old page becomes pending)
+func (p *pager) writable(n *node) *node {
+	if p.fresh[n.id] {
+		n.dirty = true
+		return n
+	}
+	c := n.clone(p.alloc())
+	p.release(n.id)
+	p.cache[c.id] = p.lru.PushFront(c)
+	return c
+}
+
+// newNode allocates page for new node
+func (p *pager) newNode(leaf bool) *node {
+	n := &node{id: p.alloc(), leaf: leaf, dirty: true}
+	p.cache[n.id] = p.lru.PushFront(n)
+	return n
+}
+
+// alloc returns free page or new page at the end of file
+func (p *pager) alloc() pgid {
+	var id pgid
+	if len(p.free) > 0 {
+		id = p.free[len(p.free)-1]
+		p.free = p.free[:len(p.free)-1]
+	} else {
+		id = p.pageCount
+		p.pageCount++
+	}
+	p.fresh[id] = true
+	return id
+}
+
+// release frees page of node which is not in tree anymore
+func (p *pager) release(id pgid) {
+	if e, ok := p.cache[id]; ok {
+		p.lru.Remove(e)
+		delete(p.cache, id)
+	}
+	if p.fresh[id] {
+		delete(p.fresh, id)
+		p.free = append(p.free, id)
+		return
+	}
+	p.pending = append(p.pending, id)
+}
+
+// write writes node to its page
+func (p *pager) write(n *node) error {
+	n.encode(p.buf)
+	if _, err := p.f.WriteAt(p.buf, int64(n.id)*int64(p.pageSize)); err != nil {
+		return err
+	}
+	n.dirty = false
+	return nil
+}
+
+// shrink evicts least recently used nodes while cache is over capacity.
+// Dirty node is written before eviction: it is fresh, so it is not in committed tree
+func (p *pager) shrink() error {
+	for p.lru.Len() > p.capacity {
+		e := p.lru.Back()
+		n := e.Value.(*node)
+		if n.dirty {
+			if err := p.write(n); err != nil {
+				return err
+			}
+		}
+		p.lru.Remove(e)
+		delete(p.cache, n.id)
+	}
+	return nil
+}
+
+// flush writes all dirty nodes
+func (p *pager) flush() error {
+	for e := p.lru.Front(); e != nil; e = e.Next() {
+		if n := e.Value.(*node); n.dirty {
+			if err := p.write(n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeMeta writes meta to its slot: txid % 2.
+// Only meta bytes are written, the rest of meta page is unused
+func (p *pager) writeMeta(m meta) error {
+	m.encode(p.buf)
+	_, err := p.f.WriteAt(p.buf[:metaSize], int64(m.txid%metaPages)*int64(p.pageSize))
+	return err
+}
+
+// commit is called when meta is written: pending pages are not used by any tree now
+func (p *pager) commit() {
+	p.free = append(p.free, p.pending...)
+	p.pending = nil
+	clear(p.fresh)
+}
+
+// reset forgets all changes of current transaction and
+// finds free pages of committed tree with root `root`
+func (p *pager) reset(root pgid, pageCount pgid) error {
+	for id := range p.fresh {
+		if e, ok := p.cache[id]; ok {
+			p.lru.Remove(e)
+			delete(p.cache, id)
+		}
+	}
+	clear(p.fresh)
+	p.pending = nil
+	p.pageCount = pageCount
+
+	used := make(map[pgid]bool)
+	if err := p.walk(root, used); err != nil {
+		return err
+	}
+	p.free = p.free[:0]
+	for id := pgid(metaPages); id < pageCount; id++ {
+		if !used[id] {
+			p.free = append(p.free, id)
+		}
+	}
+	return nil
+}
+
+// walk marks all pages of subtree as used
+func (p *pager) walk(id pgid, used map[pgid]bool) error {
+	if used[id] {
+		return fmt.Errorf(gocollections.ErrCorrupted)
+	}
+	used[id] = true
+	n, err := p.node(id)
+	if err != nil {
+		return err
+	}
+	for _, child := range n.children {
+		if err := p.walk(child, used); err != nil {
+			return err
+		}
+	}
+	// walk reads all pages, don't keep them all in cache
+	return p.shrink()
+}
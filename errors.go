@@ -1,9 +1,14 @@
 package gocollections
 
 const (
-	ErrEmpty       = "empty"
-	ErrOutOfBounds = "out of bounds"
-	ErrNotFound    = "not found"
-	ErrFull        = "data structure is full"
-	ErrPriority    = "invalid priority"
+	ErrEmpty        = "empty"
+	ErrOutOfBounds  = "out of bounds"
+	ErrNotFound     = "not found"
+	ErrFull         = "data structure is full"
+	ErrPriority     = "invalid priority"
+	ErrIncompatible = "incompatible data structures"
+	ErrInvalidData  = "invalid data"
+	ErrTooLarge     = "data is too large"
+	ErrCorrupted    = "data is corrupted"
+	ErrClosed       = "data structure is closed"
 )
@@ -0,0 +1,214 @@
+package filters
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// bloomFilter - standard Bloom Filter.
+//
+// It's a bit array of size m and k hash functions.
+// Add sets k bits, Contains checks that all k bits are set.
+//
+// # bits 	-> bit array, packed into uint64 words
+//
+// # m 		-> number of bits
+//
+// # k 		-> number of hash functions
+type bloomFilter[T any] struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+
+	hash Hasher[T]
+
+	mu sync.RWMutex
+}
+
+// NewBloomFilter creates Bloom Filter for `expectedItems` items
+// with false-positive rate `fpRate` (for example 0.01 -> 1%).
+//
+// If hash == nil -> DefaultHasher is used
+func NewBloomFilter[T any](expectedItems uint, fpRate float64, hash Hasher[T]) *bloomFilter[T] {
+	m, k := optimalParams(expectedItems, fpRate)
+	return NewBloomFilterWithSize(m, k, hash)
+}
+
+// NewBloomFilterWithSize creates Bloom Filter with `m` bits and `k` hash functions
+func NewBloomFilterWithSize[T any](m, k uint64, hash Hasher[T]) *bloomFilter[T] {
+	if hash == nil {
+		hash = DefaultHasher[T]()
+	}
+	m, k = max(m, 1), max(k, 1)
+	return &bloomFilter[T]{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		hash: hash,
+	}
+}
+
+// Add adds `item` to filter. Always returns nil
+func (bf *bloomFilter[T]) Add(item T) error {
+	h1, h2 := SplitHash(bf.hash(item))
+
+	bf.mu.Lock()
+	defer bf.mu.Unlock()
+
+	for i := uint64(0); i < bf.k; i++ {
+		loc := location(h1, h2, i, bf.m)
+		bf.bits[loc/64] |= 1 << (loc % 64)
+	}
+	return nil
+}
+
+// Contains returns true if `item` may be in filter,
+// false if `item` is definitely not in filter
+func (bf *bloomFilter[T]) Contains(item T) bool {
+	h1, h2 := SplitHash(bf.hash(item))
+
+	bf.mu.RLock()
+	defer bf.mu.RUnlock()
+
+	for i := uint64(0); i < bf.k; i++ {
+		loc := location(h1, h2, i, bf.m)
+		if bf.bits[loc/64]&(1<<(loc%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FillRatio returns part of bits which are set, from 0 to 1
+func (bf *bloomFilter[T]) FillRatio() float64 {
+	bf.mu.RLock()
+	defer bf.mu.RUnlock()
+
+	return float64(bf.setBits()) / float64(bf.m)
+}
+
+// EstimatedCount returns estimated number of added items
+func (bf *bloomFilter[T]) EstimatedCount() uint64 {
+	bf.mu.RLock()
+	defer bf.mu.RUnlock()
+
+	return estimateCount(bf.m, bf.k, bf.setBits())
+}
+
+// EstimatedFalsePositiveRate returns current probability of false positive:
+//
+//	fillRatio ^ k
+func (bf *bloomFilter[T]) EstimatedFalsePositiveRate() float64 {
+	bf.mu.RLock()
+	defer bf.mu.RUnlock()
+
+	return math.Pow(float64(bf.setBits())/float64(bf.m), float64(bf.k))
+}
+
+// Params returns number of bits `m` and number of hash functions `k`
+func (bf *bloomFilter[T]) Params() (uint64, uint64) {
+	bf.mu.RLock()
+	defer bf.mu.RUnlock()
+
+	return bf.m, bf.k
+}
+
+// Reset removes all items
+func (bf *bloomFilter[T]) Reset() {
+	bf.mu.Lock()
+	defer bf.mu.Unlock()
+
+	clear(bf.bits)
+}
+
+// Union adds all items of `other` to filter.
+//
+// Filters must have the same m, k and hash function, otherwise returns err
+func (bf *bloomFilter[T]) Union(other *bloomFilter[T]) error {
+	return bf.merge(other, func(a, b uint64) uint64 { return a | b })
+}
+
+// Intersect leaves in filter only items which are in `other` too.
+//
+// Result may have more false positives than the filter built from intersection of items.
+// Filters must have the same m, k and hash function, otherwise returns err
+func (bf *bloomFilter[T]) Intersect(other *bloomFilter[T]) error {
+	return bf.merge(other, func(a, b uint64) uint64 { return a & b })
+}
+
+// MarshalBinary encodes filter to bytes.
+// Hash function is not encoded, so decode with the same one
+func (bf *bloomFilter[T]) MarshalBinary() ([]byte, error) {
+	bf.mu.RLock()
+	defer bf.mu.RUnlock()
+
+	buf := make([]byte, 0, headerSize+len(bf.bits)*8)
+	buf = writeHeader(buf, kindBloom, bf.m, bf.k)
+	for _, w := range bf.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes filter encoded by MarshalBinary.
+//
+// Hash function of filter stays the same
+func (bf *bloomFilter[T]) UnmarshalBinary(data []byte) error {
+	m, k, payload, err := readHeader(data, kindBloom)
+	if err != nil {
+		return err
+	}
+	// words are counted from payload: (m + 63) / 64 overflows for huge m
+	words := uint64(len(payload)) / 8
+	if words == 0 || uint64(len(payload)) != words*8 || m > words*64 || m <= (words-1)*64 {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+
+	newBits := make([]uint64, words)
+	for i := range newBits {
+		newBits[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+
+	bf.mu.Lock()
+	defer bf.mu.Unlock()
+
+	bf.bits, bf.m, bf.k = newBits, m, k
+	return nil
+}
+
+func (bf *bloomFilter[T]) merge(other *bloomFilter[T], op func(a, b uint64) uint64) error {
+	if other == bf {
+		return nil
+	}
+
+	// copy other's bits first, so we never hold locks of both filters
+	other.mu.RLock()
+	m, k := other.m, other.k
+	otherBits := make([]uint64, len(other.bits))
+	copy(otherBits, other.bits)
+	other.mu.RUnlock()
+
+	bf.mu.Lock()
+	defer bf.mu.Unlock()
+
+	if bf.m != m || bf.k != k {
+		return fmt.Errorf(gocollections.ErrIncompatible)
+	}
+	for i := range bf.bits {
+		bf.bits[i] = op(bf.bits[i], otherBits[i])
+	}
+	return nil
+}
+
+func (bf *bloomFilter[T]) setBits() uint64 {
+	var n int
+	for _, w := range bf.bits {
+		n += bits.OnesCount64(w)
+	}
+	return uint64(n)
+}
@@ -0,0 +1,77 @@
+package filters
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+const (
+	// default false-positive rate if passed one is not in (0, 1)
+	defaultFPRate = 0.01
+
+	formatVersion byte = 1
+
+	kindBloom    byte = 'B'
+	kindCounting byte = 'C'
+
+	// version + kind + m + k
+	headerSize = 2 + 8 + 8
+)
+
+// optimalParams returns number of cells `m` and number of hash functions `k`
+// for `n` expected items and false-positive rate `p`:
+//
+//	m = -n * ln(p) / ln(2)^2
+//	k = m / n * ln(2)
+func optimalParams(n uint, p float64) (uint64, uint64) {
+	if n == 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = defaultFPRate
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(m, 1), max(k, 1)
+}
+
+// location returns index of i-th cell (Kirsch-Mitzenmacher double hashing):
+//
+//	g_i(x) = h1(x) + i * h2(x) mod m
+func location(h1, h2, i, m uint64) uint64 {
+	return (h1 + i*h2) % m
+}
+
+// estimateCount estimates number of items by number of used cells `x`:
+//
+//	n = -m / k * ln(1 - x / m)
+func estimateCount(m, k, x uint64) uint64 {
+	if x >= m {
+		return math.MaxUint64
+	}
+	return uint64(math.Round(-float64(m) / float64(k) * math.Log(1-float64(x)/float64(m))))
+}
+
+// writeHeader appends header of serialized filter to buf
+func writeHeader(buf []byte, kind byte, m, k uint64) []byte {
+	buf = append(buf, formatVersion, kind)
+	buf = binary.LittleEndian.AppendUint64(buf, m)
+	buf = binary.LittleEndian.AppendUint64(buf, k)
+	return buf
+}
+
+// readHeader checks header of serialized filter and returns m, k and the rest of data
+func readHeader(data []byte, kind byte) (uint64, uint64, []byte, error) {
+	if len(data) < headerSize || data[0] != formatVersion || data[1] != kind {
+		return 0, 0, nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	m := binary.LittleEndian.Uint64(data[2:])
+	k := binary.LittleEndian.Uint64(data[10:])
+	if m == 0 || k == 0 {
+		return 0, 0, nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	return m, k, data[headerSize:], nil
+}
@@ -0,0 +1,170 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter_AddContains(t *testing.T) {
+	var f Filter[string] = NewBloomFilter[string](1000, 0.01, StringHasher)
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, f.Add(fmt.Sprintf("item-%d", i)))
+	}
+	// no false negatives
+	for i := 0; i < 1000; i++ {
+		assert.True(t, f.Contains(fmt.Sprintf("item-%d", i)))
+	}
+
+	f.Reset()
+	assert.False(t, f.Contains("item-0"))
+	assert.Equal(t, 0.0, f.FillRatio())
+}
+
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	n := 10000
+	bf := NewBloomFilter[string](uint(n), 0.01, StringHasher)
+	for i := 0; i < n; i++ {
+		_ = bf.Add(fmt.Sprintf("in-%d", i))
+	}
+
+	fp := 0
+	for i := 0; i < n; i++ {
+		if bf.Contains(fmt.Sprintf("out-%d", i)) {
+			fp++
+		}
+	}
+	rate := float64(fp) / float64(n)
+	assert.Less(t, rate, 0.02)
+	assert.InDelta(t, 0.01, bf.EstimatedFalsePositiveRate(), 0.005)
+
+	// filter sized for n items is about half full
+	assert.InDelta(t, 0.5, bf.FillRatio(), 0.05)
+	assert.InDelta(t, float64(n), float64(bf.EstimatedCount()), float64(n)*0.05)
+}
+
+func TestBloomFilter_Params(t *testing.T) {
+	bf := NewBloomFilter[string](1000, 0.01, nil)
+	m, k := bf.Params()
+	// m = 1000 * 9.585 bits, k = 7
+	assert.Equal(t, uint64(9586), m)
+	assert.Equal(t, uint64(7), k)
+
+	bf = NewBloomFilterWithSize[string](0, 0, nil)
+	m, k = bf.Params()
+	assert.Equal(t, uint64(1), m)
+	assert.Equal(t, uint64(1), k)
+}
+
+func TestBloomFilter_DefaultHasher(t *testing.T) {
+	bytesFilter := NewBloomFilter[[]byte](100, 0.01, nil)
+	_ = bytesFilter.Add([]byte("hello"))
+	assert.True(t, bytesFilter.Contains([]byte("hello")))
+	assert.False(t, bytesFilter.Contains([]byte("world")))
+
+	intFilter := NewBloomFilter[int](100, 0.01, nil)
+	_ = intFilter.Add(42)
+	assert.True(t, intFilter.Contains(42))
+	assert.False(t, intFilter.Contains(43))
+
+	assert.Equal(t, StringHasher("abc"), BytesHasher([]byte("abc")))
+}
+
+func TestBloomFilter_UnionIntersect(t *testing.T) {
+	a := NewBloomFilter[string](1000, 0.01, StringHasher)
+	b := NewBloomFilter[string](1000, 0.01, StringHasher)
+	for i := 0; i < 100; i++ {
+		_ = a.Add(fmt.Sprintf("a-%d", i))
+		_ = b.Add(fmt.Sprintf("b-%d", i))
+	}
+	_ = a.Add("both")
+	_ = b.Add("both")
+
+	union := NewBloomFilter[string](1000, 0.01, StringHasher)
+	require.NoError(t, union.Union(a))
+	require.NoError(t, union.Union(b))
+	for i := 0; i < 100; i++ {
+		assert.True(t, union.Contains(fmt.Sprintf("a-%d", i)))
+		assert.True(t, union.Contains(fmt.Sprintf("b-%d", i)))
+	}
+
+	require.NoError(t, a.Intersect(b))
+	assert.True(t, a.Contains("both"))
+	misses := 0
+	for i := 0; i < 100; i++ {
+		if !a.Contains(fmt.Sprintf("a-%d", i)) {
+			misses++
+		}
+	}
+	assert.Greater(t, misses, 90)
+
+	other := NewBloomFilter[string](10, 0.01, StringHasher)
+	assert.Equal(t, errors.New(gocollections.ErrIncompatible), a.Union(other))
+	assert.Error(t, a.Intersect(other))
+	require.NoError(t, a.Union(a))
+}
+
+func TestBloomFilter_Binary(t *testing.T) {
+	bf := NewBloomFilter[string](500, 0.001, StringHasher)
+	for i := 0; i < 500; i++ {
+		_ = bf.Add(fmt.Sprintf("item-%d", i))
+	}
+
+	data, err := bf.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := NewBloomFilter[string](1, 0.5, StringHasher)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, bf.bits, decoded.bits)
+	m1, k1 := bf.Params()
+	m2, k2 := decoded.Params()
+	assert.Equal(t, m1, m2)
+	assert.Equal(t, k1, k2)
+	for i := 0; i < 500; i++ {
+		assert.True(t, decoded.Contains(fmt.Sprintf("item-%d", i)))
+	}
+
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), decoded.UnmarshalBinary(data[:10]))
+	assert.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+	// header with huge m and no payload
+	header := writeHeader(nil, kindBloom, math.MaxUint64, 3)
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), decoded.UnmarshalBinary(header))
+	// m doesn't match number of words
+	header = writeHeader(nil, kindBloom, 65, 3)
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), decoded.UnmarshalBinary(append(header, make([]byte, 8)...)))
+	// counting filter can't decode bloom filter
+	assert.Error(t, NewCountingBloomFilter[string](1, 0.5, nil).UnmarshalBinary(data))
+}
+
+func BenchmarkBloomFilter_Add(b *testing.B) {
+	bf := NewBloomFilter[string](uint(b.N)+1, 0.01, StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = bf.Add(items[i%len(items)])
+	}
+}
+
+func BenchmarkBloomFilter_Contains(b *testing.B) {
+	bf := NewBloomFilter[string](1024, 0.01, StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+		_ = bf.Add(items[i])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = bf.Contains(items[i%len(items)])
+	}
+}
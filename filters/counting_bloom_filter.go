@@ -0,0 +1,227 @@
+package filters
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// countingBloomFilter - Bloom Filter with counters instead of bits,
+// so items can be removed.
+//
+// Add increments k counters, Remove decrements them,
+// Contains checks that all k counters are > 0.
+//
+// Counters are 8-bit. If counter reaches 255 -> it's stuck forever
+// (never incremented or decremented), otherwise Remove could cause false negatives
+type countingBloomFilter[T any] struct {
+	counters []uint8
+	m        uint64
+	k        uint64
+
+	hash Hasher[T]
+
+	mu sync.RWMutex
+}
+
+// NewCountingBloomFilter creates Counting Bloom Filter for `expectedItems` items
+// with false-positive rate `fpRate` (for example 0.01 -> 1%).
+//
+// If hash == nil -> DefaultHasher is used
+func NewCountingBloomFilter[T any](expectedItems uint, fpRate float64, hash Hasher[T]) *countingBloomFilter[T] {
+	m, k := optimalParams(expectedItems, fpRate)
+	return NewCountingBloomFilterWithSize(m, k, hash)
+}
+
+// NewCountingBloomFilterWithSize creates Counting Bloom Filter with `m` counters and `k` hash functions
+func NewCountingBloomFilterWithSize[T any](m, k uint64, hash Hasher[T]) *countingBloomFilter[T] {
+	if hash == nil {
+		hash = DefaultHasher[T]()
+	}
+	m, k = max(m, 1), max(k, 1)
+	return &countingBloomFilter[T]{
+		counters: make([]uint8, m),
+		m:        m,
+		k:        k,
+		hash:     hash,
+	}
+}
+
+// Add adds `item` to filter. Always returns nil
+func (cf *countingBloomFilter[T]) Add(item T) error {
+	h1, h2 := SplitHash(cf.hash(item))
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	for i := uint64(0); i < cf.k; i++ {
+		loc := location(h1, h2, i, cf.m)
+		if cf.counters[loc] < math.MaxUint8 {
+			cf.counters[loc]++
+		}
+	}
+	return nil
+}
+
+// Remove removes `item` from filter
+//
+// if `item` is definitely not in filter -> returns err
+func (cf *countingBloomFilter[T]) Remove(item T) error {
+	h1, h2 := SplitHash(cf.hash(item))
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	for i := uint64(0); i < cf.k; i++ {
+		if cf.counters[location(h1, h2, i, cf.m)] == 0 {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+	}
+	for i := uint64(0); i < cf.k; i++ {
+		loc := location(h1, h2, i, cf.m)
+		if cf.counters[loc] < math.MaxUint8 {
+			cf.counters[loc]--
+		}
+	}
+	return nil
+}
+
+// Contains returns true if `item` may be in filter,
+// false if `item` is definitely not in filter
+func (cf *countingBloomFilter[T]) Contains(item T) bool {
+	h1, h2 := SplitHash(cf.hash(item))
+
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	for i := uint64(0); i < cf.k; i++ {
+		if cf.counters[location(h1, h2, i, cf.m)] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FillRatio returns part of counters which are > 0, from 0 to 1
+func (cf *countingBloomFilter[T]) FillRatio() float64 {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	return float64(cf.usedCounters()) / float64(cf.m)
+}
+
+// EstimatedCount returns estimated number of items
+func (cf *countingBloomFilter[T]) EstimatedCount() uint64 {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	return estimateCount(cf.m, cf.k, cf.usedCounters())
+}
+
+// Params returns number of counters `m` and number of hash functions `k`
+func (cf *countingBloomFilter[T]) Params() (uint64, uint64) {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	return cf.m, cf.k
+}
+
+// Reset removes all items
+func (cf *countingBloomFilter[T]) Reset() {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	clear(cf.counters)
+}
+
+// Union adds all items of `other` to filter (sums counters).
+//
+// Filters must have the same m, k and hash function, otherwise returns err
+func (cf *countingBloomFilter[T]) Union(other *countingBloomFilter[T]) error {
+	return cf.merge(other, func(a, b uint8) uint8 {
+		if a > math.MaxUint8-b {
+			return math.MaxUint8
+		}
+		return a + b
+	})
+}
+
+// Intersect leaves in filter only items which are in `other` too (min of counters).
+//
+// Filters must have the same m, k and hash function, otherwise returns err
+func (cf *countingBloomFilter[T]) Intersect(other *countingBloomFilter[T]) error {
+	return cf.merge(other, func(a, b uint8) uint8 { return min(a, b) })
+}
+
+// MarshalBinary encodes filter to bytes.
+// Hash function is not encoded, so decode with the same one
+func (cf *countingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	buf := make([]byte, 0, headerSize+len(cf.counters))
+	buf = writeHeader(buf, kindCounting, cf.m, cf.k)
+	buf = append(buf, cf.counters...)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes filter encoded by MarshalBinary.
+//
+// Hash function of filter stays the same
+func (cf *countingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	m, k, payload, err := readHeader(data, kindCounting)
+	if err != nil {
+		return err
+	}
+	if uint64(len(payload)) != m {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+
+	newCounters := make([]uint8, m)
+	copy(newCounters, payload)
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	cf.counters, cf.m, cf.k = newCounters, m, k
+	return nil
+}
+
+func (cf *countingBloomFilter[T]) merge(other *countingBloomFilter[T], op func(a, b uint8) uint8) error {
+	// copy other's counters first, so we never hold locks of both filters
+	// (and self-union sums counters of the snapshot)
+	otherCounters, m, k := other.snapshot()
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	if cf.m != m || cf.k != k {
+		return fmt.Errorf(gocollections.ErrIncompatible)
+	}
+	for i := range cf.counters {
+		cf.counters[i] = op(cf.counters[i], otherCounters[i])
+	}
+	return nil
+}
+
+// snapshot returns copy of counters, m and k
+func (cf *countingBloomFilter[T]) snapshot() ([]uint8, uint64, uint64) {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	res := make([]uint8, len(cf.counters))
+	copy(res, cf.counters)
+	return res, cf.m, cf.k
+}
+
+func (cf *countingBloomFilter[T]) usedCounters() uint64 {
+	var n uint64
+	for _, c := range cf.counters {
+		if c > 0 {
+			n++
+		}
+	}
+	return n
+}
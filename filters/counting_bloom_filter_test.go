@@ -0,0 +1,126 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountingBloomFilter_AddRemove(t *testing.T) {
+	var f RemovableFilter[string] = NewCountingBloomFilter[string](1000, 0.01, StringHasher)
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, f.Add(fmt.Sprintf("item-%d", i)))
+	}
+	for i := 0; i < 1000; i += 2 {
+		require.NoError(t, f.Remove(fmt.Sprintf("item-%d", i)))
+	}
+
+	// removed items are gone (except rare false positives), the rest are still here
+	present := 0
+	for i := 0; i < 1000; i++ {
+		if i%2 == 1 {
+			assert.True(t, f.Contains(fmt.Sprintf("item-%d", i)))
+		} else if f.Contains(fmt.Sprintf("item-%d", i)) {
+			present++
+		}
+	}
+	assert.Less(t, present, 20)
+
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), f.Remove("never-added"))
+}
+
+func TestCountingBloomFilter_Duplicates(t *testing.T) {
+	cf := NewCountingBloomFilter[string](100, 0.01, nil)
+
+	_ = cf.Add("x")
+	_ = cf.Add("x")
+	require.NoError(t, cf.Remove("x"))
+	assert.True(t, cf.Contains("x"))
+	require.NoError(t, cf.Remove("x"))
+	assert.False(t, cf.Contains("x"))
+	assert.Equal(t, 0.0, cf.FillRatio())
+}
+
+func TestCountingBloomFilter_Saturation(t *testing.T) {
+	cf := NewCountingBloomFilterWithSize[string](16, 1, StringHasher)
+
+	for i := 0; i < 300; i++ {
+		_ = cf.Add("hot")
+	}
+	// stuck counter never goes down, so there is no false negative
+	for i := 0; i < 300; i++ {
+		_ = cf.Remove("hot")
+	}
+	assert.True(t, cf.Contains("hot"))
+}
+
+func TestCountingBloomFilter_UnionIntersect(t *testing.T) {
+	a := NewCountingBloomFilter[string](100, 0.01, StringHasher)
+	b := NewCountingBloomFilter[string](100, 0.01, StringHasher)
+	_ = a.Add("a")
+	_ = a.Add("both")
+	_ = b.Add("b")
+	_ = b.Add("both")
+
+	require.NoError(t, a.Union(b))
+	assert.True(t, a.Contains("a"))
+	assert.True(t, a.Contains("b"))
+	// "both" was added twice -> one Remove is not enough
+	require.NoError(t, a.Remove("both"))
+	assert.True(t, a.Contains("both"))
+
+	c := NewCountingBloomFilter[string](100, 0.01, StringHasher)
+	_ = c.Add("b")
+	require.NoError(t, a.Intersect(c))
+	assert.True(t, a.Contains("b"))
+	assert.False(t, a.Contains("a"))
+
+	// self-union doubles counters
+	require.NoError(t, c.Union(c))
+	require.NoError(t, c.Remove("b"))
+	assert.True(t, c.Contains("b"))
+
+	other := NewCountingBloomFilter[string](1000, 0.01, StringHasher)
+	assert.Equal(t, errors.New(gocollections.ErrIncompatible), a.Union(other))
+}
+
+func TestCountingBloomFilter_Binary(t *testing.T) {
+	cf := NewCountingBloomFilter[string](200, 0.01, StringHasher)
+	for i := 0; i < 200; i++ {
+		_ = cf.Add(fmt.Sprintf("item-%d", i))
+	}
+
+	data, err := cf.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := NewCountingBloomFilter[string](1, 0.5, StringHasher)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, cf.counters, decoded.counters)
+	for i := 0; i < 200; i++ {
+		assert.True(t, decoded.Contains(fmt.Sprintf("item-%d", i)))
+	}
+	assert.InDelta(t, 200, float64(decoded.EstimatedCount()), 20)
+
+	assert.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+	assert.Error(t, decoded.UnmarshalBinary(nil))
+}
+
+func BenchmarkCountingBloomFilter_AddRemove(b *testing.B) {
+	cf := NewCountingBloomFilter[string](1024, 0.01, StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		item := items[i%len(items)]
+		_ = cf.Add(item)
+		_ = cf.Remove(item)
+	}
+}
@@ -0,0 +1,261 @@
+package filters
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+const (
+	defaultFingerprintBits = 16
+	defaultBucketSize      = 4
+	defaultMaxKicks        = 500
+)
+
+// CuckooStats is the load statistics of Cuckoo Filter
+type CuckooStats struct {
+	// Count is number of items
+	Count uint64
+
+	// Capacity is number of slots: Buckets * BucketSize
+	Capacity uint64
+
+	Buckets         uint64
+	BucketSize      uint64
+	FingerprintBits uint64
+
+	// LoadFactor is Count / Capacity
+	LoadFactor float64
+
+	// MaxLoadFactor is expected load factor when Add starts returning ErrFull
+	MaxLoadFactor float64
+
+	// FullBuckets is number of buckets without empty slots
+	FullBuckets uint64
+
+	// EmptyBuckets is number of buckets without fingerprints
+	EmptyBuckets uint64
+}
+
+// cuckooFilter - Cuckoo Filter.
+//
+// Every item is stored as a small fingerprint in one of two buckets:
+//
+//	i1 = hash(item)
+//	i2 = i1 xor hash(fingerprint)
+//
+// so the second bucket can be computed from any bucket and fingerprint.
+// If both buckets are full -> random fingerprint is kicked out to its other bucket,
+// and so on, up to maxKicks times. If it doesn't help -> all kicks are rolled back
+// and Add returns ErrFull, so failed Add never loses other items.
+//
+// # table 		-> fingerprints packed into uint64 words, fpBits bits per slot. 0 is empty slot
+//
+// # numBuckets 	-> number of buckets, always power of 2
+type cuckooFilter[T any] struct {
+	table      []uint64
+	numBuckets uint64
+	bucketSize uint64
+	fpBits     uint64
+	maxKicks   int
+
+	count uint64
+
+	hash Hasher[T]
+	rnd  *rand.Rand
+
+	mu sync.RWMutex
+}
+
+// NewCuckooFilter creates Cuckoo Filter for `capacity` items
+// with 16-bit fingerprints and 4 slots per bucket.
+//
+// If hash == nil -> DefaultHasher is used
+func NewCuckooFilter[T any](capacity uint, hash Hasher[T]) *cuckooFilter[T] {
+	return NewCuckooFilterWithConfig(capacity, defaultFingerprintBits, defaultBucketSize, defaultMaxKicks, hash)
+}
+
+// NewCuckooFilterWithConfig creates Cuckoo Filter with custom config:
+//
+// # capacity 	-> number of items filter must hold
+//
+// # fpBits 		-> bits per fingerprint, from 1 to 32. More bits -> less false positives:
+//
+//	fpRate <= 2 * bucketSize / 2^fpBits
+//
+// # bucketSize 	-> slots per bucket. Bigger buckets -> higher load factor, but more false positives
+//
+// # maxKicks 	-> how many times Add kicks fingerprints out before returning ErrFull
+func NewCuckooFilterWithConfig[T any](capacity uint, fpBits, bucketSize uint, maxKicks int, hash Hasher[T]) *cuckooFilter[T] {
+	if hash == nil {
+		hash = DefaultHasher[T]()
+	}
+	if fpBits < 1 || fpBits > 32 {
+		fpBits = defaultFingerprintBits
+	}
+	if bucketSize < 1 {
+		bucketSize = defaultBucketSize
+	}
+	if maxKicks < 1 {
+		maxKicks = defaultMaxKicks
+	}
+
+	buckets := math.Ceil(float64(max(capacity, 1)) / (float64(bucketSize) * maxLoadFactor(bucketSize)))
+	numBuckets := nextPowerOfTwo(uint64(buckets))
+	slots := numBuckets * uint64(bucketSize)
+
+	return &cuckooFilter[T]{
+		table:      make([]uint64, (slots*uint64(fpBits)+63)/64),
+		numBuckets: numBuckets,
+		bucketSize: uint64(bucketSize),
+		fpBits:     uint64(fpBits),
+		maxKicks:   maxKicks,
+		hash:       hash,
+		rnd:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Add adds `item` to filter.
+//
+// If there is no place for item -> returns ErrFull, the item is not added
+func (cf *cuckooFilter[T]) Add(item T) error {
+	fp, i1 := cf.fingerprintAndIndex(cf.hash(item))
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	i2 := cf.altIndex(i1, fp)
+	if cf.insertInto(i1, fp) || cf.insertInto(i2, fp) {
+		cf.count++
+		return nil
+	}
+
+	// both buckets are full -> kick random fingerprints out
+	i := i1
+	if cf.rnd.Intn(2) == 1 {
+		i = i2
+	}
+	kicked := make([]uint64, 0, cf.maxKicks)
+	for n := 0; n < cf.maxKicks; n++ {
+		slot := i*cf.bucketSize + uint64(cf.rnd.Intn(int(cf.bucketSize)))
+		kicked = append(kicked, slot)
+		fp = cf.swap(slot, fp)
+
+		i = cf.altIndex(i, fp)
+		if cf.insertInto(i, fp) {
+			cf.count++
+			return nil
+		}
+	}
+
+	// no luck -> put every kicked fingerprint back
+	for n := len(kicked) - 1; n >= 0; n-- {
+		fp = cf.swap(kicked[n], fp)
+	}
+	return fmt.Errorf(gocollections.ErrFull)
+}
+
+// Contains returns true if `item` may be in filter,
+// false if `item` is definitely not in filter
+func (cf *cuckooFilter[T]) Contains(item T) bool {
+	fp, i1 := cf.fingerprintAndIndex(cf.hash(item))
+
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	return cf.findIn(i1, fp) != -1 || cf.findIn(cf.altIndex(i1, fp), fp) != -1
+}
+
+// Remove removes `item` from filter
+//
+// if `item` is definitely not in filter -> returns err
+func (cf *cuckooFilter[T]) Remove(item T) error {
+	fp, i1 := cf.fingerprintAndIndex(cf.hash(item))
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	for _, i := range [2]uint64{i1, cf.altIndex(i1, fp)} {
+		if pos := cf.findIn(i, fp); pos != -1 {
+			cf.set(i*cf.bucketSize+uint64(pos), 0)
+			cf.count--
+			return nil
+		}
+	}
+	return fmt.Errorf(gocollections.ErrNotFound)
+}
+
+// FillRatio returns load factor of filter, from 0 to 1
+func (cf *cuckooFilter[T]) FillRatio() float64 {
+	return cf.LoadFactor()
+}
+
+// LoadFactor returns number of items / number of slots
+func (cf *cuckooFilter[T]) LoadFactor() float64 {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	return float64(cf.count) / float64(cf.numBuckets*cf.bucketSize)
+}
+
+// Count returns number of items in filter
+func (cf *cuckooFilter[T]) Count() uint64 {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	return cf.count
+}
+
+// Capacity returns number of slots: buckets * bucketSize.
+//
+// Usually Add starts failing before all slots are used (see LoadFactor)
+func (cf *cuckooFilter[T]) Capacity() uint64 {
+	return cf.numBuckets * cf.bucketSize
+}
+
+// Stats returns load statistics of filter
+func (cf *cuckooFilter[T]) Stats() CuckooStats {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+
+	stats := CuckooStats{
+		Count:           cf.count,
+		Capacity:        cf.numBuckets * cf.bucketSize,
+		Buckets:         cf.numBuckets,
+		BucketSize:      cf.bucketSize,
+		FingerprintBits: cf.fpBits,
+		MaxLoadFactor:   maxLoadFactor(uint(cf.bucketSize)),
+	}
+	stats.LoadFactor = float64(stats.Count) / float64(stats.Capacity)
+
+	for i := uint64(0); i < cf.numBuckets; i++ {
+		used := cf.bucketSize - cf.emptySlots(i)
+		if used == cf.bucketSize {
+			stats.FullBuckets++
+		} else if used == 0 {
+			stats.EmptyBuckets++
+		}
+	}
+	return stats
+}
+
+// EstimatedFalsePositiveRate returns upper bound of false-positive rate:
+//
+//	1 - (1 - 1/2^fpBits)^(2*bucketSize*loadFactor)
+func (cf *cuckooFilter[T]) EstimatedFalsePositiveRate() float64 {
+	lf := cf.LoadFactor()
+	return 1 - math.Pow(1-1/math.Exp2(float64(cf.fpBits)), 2*float64(cf.bucketSize)*lf)
+}
+
+// Reset removes all items
+func (cf *cuckooFilter[T]) Reset() {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	clear(cf.table)
+	cf.count = 0
+}
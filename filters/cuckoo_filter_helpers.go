@@ -0,0 +1,112 @@
+package filters
+
+// fingerprintAndIndex splits hash: low bits -> first bucket, high bits -> fingerprint.
+//
+// Fingerprint 0 means empty slot, so it's replaced with 1
+func (cf *cuckooFilter[T]) fingerprintAndIndex(h uint64) (uint64, uint64) {
+	fp := (h >> 32) & cf.fpMask()
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h & (cf.numBuckets - 1)
+}
+
+// altIndex returns the other bucket of fingerprint.
+//
+// altIndex(altIndex(i, fp), fp) == i, because numBuckets is power of 2
+func (cf *cuckooFilter[T]) altIndex(i, fp uint64) uint64 {
+	return (i ^ mix64(fp)) & (cf.numBuckets - 1)
+}
+
+// insertInto puts fingerprint to the first empty slot of bucket `i`.
+//
+// returns false if bucket is full
+func (cf *cuckooFilter[T]) insertInto(i, fp uint64) bool {
+	for slot := i * cf.bucketSize; slot < (i+1)*cf.bucketSize; slot++ {
+		if cf.get(slot) == 0 {
+			cf.set(slot, fp)
+			return true
+		}
+	}
+	return false
+}
+
+// findIn returns position of fingerprint in bucket `i` or -1
+func (cf *cuckooFilter[T]) findIn(i, fp uint64) int {
+	for pos := uint64(0); pos < cf.bucketSize; pos++ {
+		if cf.get(i*cf.bucketSize+pos) == fp {
+			return int(pos)
+		}
+	}
+	return -1
+}
+
+// emptySlots returns number of empty slots in bucket `i`
+func (cf *cuckooFilter[T]) emptySlots(i uint64) uint64 {
+	var n uint64
+	for slot := i * cf.bucketSize; slot < (i+1)*cf.bucketSize; slot++ {
+		if cf.get(slot) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// swap puts fingerprint to slot and returns the old one
+func (cf *cuckooFilter[T]) swap(slot, fp uint64) uint64 {
+	old := cf.get(slot)
+	cf.set(slot, fp)
+	return old
+}
+
+// get returns fingerprint of slot. Slot can lie on the border of two words
+func (cf *cuckooFilter[T]) get(slot uint64) uint64 {
+	pos := slot * cf.fpBits
+	word, off := pos/64, pos%64
+
+	v := cf.table[word] >> off
+	if off+cf.fpBits > 64 {
+		v |= cf.table[word+1] << (64 - off)
+	}
+	return v & cf.fpMask()
+}
+
+// set writes fingerprint to slot. Slot can lie on the border of two words
+func (cf *cuckooFilter[T]) set(slot, fp uint64) {
+	pos := slot * cf.fpBits
+	word, off := pos/64, pos%64
+	mask := cf.fpMask()
+
+	cf.table[word] = cf.table[word]&^(mask<<off) | fp<<off
+	if off+cf.fpBits > 64 {
+		shift := 64 - off
+		cf.table[word+1] = cf.table[word+1]&^(mask>>shift) | fp>>shift
+	}
+}
+
+func (cf *cuckooFilter[T]) fpMask() uint64 {
+	return 1<<cf.fpBits - 1
+}
+
+// maxLoadFactor returns load factor when Add starts failing
+// (from the Cuckoo Filter paper)
+func maxLoadFactor(bucketSize uint) float64 {
+	switch {
+	case bucketSize == 1:
+		return 0.5
+	case bucketSize == 2:
+		return 0.84
+	case bucketSize <= 4:
+		return 0.95
+	default:
+		return 0.98
+	}
+}
+
+func nextPowerOfTwo(n uint64) uint64 {
+	p := uint64(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,143 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCuckooFilter_AddContainsRemove(t *testing.T) {
+	var f RemovableFilter[string] = NewCuckooFilter[string](1000, StringHasher)
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, f.Add(fmt.Sprintf("item-%d", i)))
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(t, f.Contains(fmt.Sprintf("item-%d", i)))
+	}
+
+	for i := 0; i < 1000; i += 2 {
+		require.NoError(t, f.Remove(fmt.Sprintf("item-%d", i)))
+	}
+	for i := 1; i < 1000; i += 2 {
+		assert.True(t, f.Contains(fmt.Sprintf("item-%d", i)))
+	}
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), f.Remove("never-added"))
+
+	f.Reset()
+	assert.False(t, f.Contains("item-1"))
+	assert.Equal(t, 0.0, f.FillRatio())
+}
+
+func TestCuckooFilter_FalsePositiveRate(t *testing.T) {
+	n := 10000
+	cf := NewCuckooFilterWithConfig[string](uint(n), 12, 4, 500, StringHasher)
+	for i := 0; i < n; i++ {
+		require.NoError(t, cf.Add(fmt.Sprintf("in-%d", i)))
+	}
+
+	fp := 0
+	for i := 0; i < n; i++ {
+		if cf.Contains(fmt.Sprintf("out-%d", i)) {
+			fp++
+		}
+	}
+	// 2 * 4 / 2^12 ~ 0.2%
+	assert.Less(t, float64(fp)/float64(n), 0.005)
+	assert.Less(t, cf.EstimatedFalsePositiveRate(), 0.002)
+}
+
+func TestCuckooFilter_Full(t *testing.T) {
+	cf := NewCuckooFilterWithConfig[int](64, 8, 4, 100, nil)
+	capacity := cf.Capacity()
+
+	added := make([]int, 0)
+	var err error
+	for i := 0; err == nil; i++ {
+		if err = cf.Add(i); err == nil {
+			added = append(added, i)
+		}
+	}
+	assert.Equal(t, errors.New(gocollections.ErrFull), err)
+	assert.LessOrEqual(t, uint64(len(added)), capacity)
+	assert.Equal(t, uint64(len(added)), cf.Count())
+	assert.Greater(t, cf.LoadFactor(), 0.7)
+
+	// failed Add rolls back all kicks, nothing is lost
+	for _, item := range added {
+		assert.True(t, cf.Contains(item))
+	}
+
+	// after Remove there is a place again
+	require.NoError(t, cf.Remove(added[0]))
+	require.NoError(t, cf.Add(added[0]))
+}
+
+func TestCuckooFilter_Stats(t *testing.T) {
+	cf := NewCuckooFilterWithConfig[string](100, 16, 2, 500, StringHasher)
+
+	stats := cf.Stats()
+	assert.Equal(t, uint64(0), stats.Count)
+	assert.Equal(t, stats.Buckets, stats.EmptyBuckets)
+	assert.Equal(t, uint64(2), stats.BucketSize)
+	assert.Equal(t, uint64(16), stats.FingerprintBits)
+	assert.Equal(t, 0.84, stats.MaxLoadFactor)
+	// buckets are power of 2
+	assert.Equal(t, uint64(0), stats.Buckets&(stats.Buckets-1))
+	assert.GreaterOrEqual(t, float64(stats.Capacity)*stats.MaxLoadFactor, 100.0)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, cf.Add(fmt.Sprintf("item-%d", i)))
+	}
+	stats = cf.Stats()
+	assert.Equal(t, uint64(100), stats.Count)
+	assert.Equal(t, float64(100)/float64(stats.Capacity), stats.LoadFactor)
+	assert.Greater(t, stats.FullBuckets, uint64(0))
+	assert.Less(t, stats.EmptyBuckets, stats.Buckets)
+}
+
+// fingerprints of odd sizes lie on the border of uint64 words
+func TestCuckooFilter_PackedFingerprints(t *testing.T) {
+	for _, bits := range []uint{1, 3, 7, 13, 17, 31, 32} {
+		cf := NewCuckooFilterWithConfig[int](200, bits, 4, 500, nil)
+		for slot := uint64(0); slot < cf.Capacity(); slot++ {
+			cf.set(slot, (slot*2654435761)&cf.fpMask())
+		}
+		for slot := uint64(0); slot < cf.Capacity(); slot++ {
+			require.Equal(t, (slot*2654435761)&cf.fpMask(), cf.get(slot), "bits=%d slot=%d", bits, slot)
+		}
+	}
+}
+
+func BenchmarkCuckooFilter_AddRemove(b *testing.B) {
+	cf := NewCuckooFilter[string](1024, StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		item := items[i%len(items)]
+		_ = cf.Add(item)
+		_ = cf.Remove(item)
+	}
+}
+
+func BenchmarkCuckooFilter_Contains(b *testing.B) {
+	cf := NewCuckooFilter[string](1024, StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+		_ = cf.Add(items[i])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = cf.Contains(items[i%len(items)])
+	}
+}
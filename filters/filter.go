@@ -0,0 +1,113 @@
+package filters
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Hasher returns 64-bit hash of item.
+//
+// Filters derive all their indices from this one hash,
+// so it must be good at mixing bits. For strings and bytes use
+// StringHasher and BytesHasher
+type Hasher[T any] func(item T) uint64
+
+// Filter is the interface of probabilistic set (membership filter).
+//
+// Contains never returns false for added item,
+// but can return true for item that was never added (false positive).
+//
+//	var f filters.Filter[string]
+//
+//	f = filters.NewBloomFilter[string](1000, 0.01, filters.StringHasher)
+//	_ = f.Add("apple")
+//	f.Contains("apple")  // true
+//	f.Contains("banana") // false (with probability 99%)
+type Filter[T any] interface {
+	// Add adds `item` to filter.
+	//
+	// Returns err if filter can't store the item
+	Add(item T) error
+
+	// Contains returns true if `item` may be in filter,
+	// false if `item` is definitely not in filter
+	Contains(item T) bool
+
+	// FillRatio returns part of filter's cells which are in use, from 0 to 1
+	FillRatio() float64
+
+	// Reset removes all items
+	Reset()
+}
+
+// RemovableFilter is Filter that supports deletion
+type RemovableFilter[T any] interface {
+	Filter[T]
+
+	// Remove removes `item` from filter
+	//
+	// if `item` is definitely not in filter -> returns err
+	//
+	// Removing item that was never added can remove other items
+	Remove(item T) error
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// StringHasher is default Hasher for strings: FNV-1a with final mixing of bits
+func StringHasher(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return mix64(h)
+}
+
+// BytesHasher is default Hasher for byte slices: FNV-1a with final mixing of bits
+func BytesHasher(b []byte) uint64 {
+	h := uint64(fnvOffset64)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+	return mix64(h)
+}
+
+// DefaultHasher returns Hasher which is used if nil Hasher was passed to constructor
+// of filter (and of sketches in package sketches).
+//
+// Strings and byte slices are hashed directly,
+// any other type is formatted with fmt first - that's slow, so pass your own Hasher
+func DefaultHasher[T any]() Hasher[T] {
+	return func(item T) uint64 {
+		switch v := any(item).(type) {
+		case string:
+			return StringHasher(v)
+		case []byte:
+			return BytesHasher(v)
+		default:
+			return StringHasher(fmt.Sprintf("%v", v))
+		}
+	}
+}
+
+// SplitHash turns one hash into two for double hashing:
+// i-th index of item is h1 + i*h2 mod size
+func SplitHash(h uint64) (uint64, uint64) {
+	// h2 must be odd, otherwise with even size some indexes are never used
+	return h, bits.RotateLeft64(h, 32) | 1
+}
+
+// mix64 is the finalizer of MurmurHash3, it spreads bits of h over all 64 bits
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
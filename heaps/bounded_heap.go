@@ -0,0 +1,50 @@
+package heaps
+
+// boundedHeap is the heap that keeps at most `capacity` items.
+//
+// If heap is full, new item replaces the top item (MAX in Max-Heap)
+// only if new item is less than top. So Max-Heap keeps `capacity` MIN items
+// and Min-Heap keeps `capacity` MAX items: k nearest points, top k scores etc.
+type boundedHeap[T comparable] struct {
+	*maxMinHeap[T]
+
+	capacity int
+}
+
+// NewBoundedHeap creates heap which keeps at most `capacity` items
+func NewBoundedHeap[T comparable](capacity int, compare Comparator[T]) *boundedHeap[T] {
+	capacity = max(capacity, 0)
+	return &boundedHeap[T]{
+		maxMinHeap: &maxMinHeap[T]{compare: compare, elements: make([]T, 0, capacity)},
+		capacity:   capacity,
+	}
+}
+
+// Insert adds element to heap.
+//
+// If heap is full -> element replaces top element if it is less than top,
+// otherwise element is dropped
+func (h *boundedHeap[T]) Insert(item T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.elements) < h.capacity {
+		h.elements = append(h.elements, item)
+		h.heapifyUp(len(h.elements) - 1)
+		return
+	}
+	if h.capacity > 0 && h.compare(item, h.elements[0]) < 0 {
+		h.elements[0] = item
+		h.heapifyDown(0)
+	}
+}
+
+// IsFull returns true if heap has `capacity` items
+func (h *boundedHeap[T]) IsFull() bool {
+	return h.Size() == h.capacity
+}
+
+// Capacity returns max number of items
+func (h *boundedHeap[T]) Capacity() int {
+	return h.capacity
+}
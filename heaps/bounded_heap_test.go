@@ -0,0 +1,55 @@
+package heaps
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundedHeap_KeepsMin(t *testing.T) {
+	var h Heap[int] = NewBoundedHeap(3, intComparator_MaxHeap)
+
+	for _, v := range []int{5, 1, 9, 3, 7, 2, 8} {
+		h.Insert(v)
+	}
+	assert.Equal(t, 3, h.Size())
+
+	var got []int
+	for !h.IsEmpty() {
+		v, err := h.Extract()
+		require.NoError(t, err)
+		got = append(got, *v)
+	}
+	assert.Equal(t, []int{3, 2, 1}, got)
+}
+
+func TestBoundedHeap_KeepsMax(t *testing.T) {
+	h := NewBoundedHeap(4, intComparator_MinHeap)
+	rnd := rand.New(rand.NewSource(1))
+
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = rnd.Intn(10000)
+		h.Insert(items[i])
+	}
+	assert.True(t, h.IsFull())
+	assert.Equal(t, 4, h.Capacity())
+
+	sort.Sort(sort.Reverse(sort.IntSlice(items)))
+	top, err := h.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, items[3], *top)
+}
+
+func TestBoundedHeap_ZeroCapacity(t *testing.T) {
+	h := NewBoundedHeap(0, intComparator_MaxHeap)
+	h.Insert(1)
+	assert.True(t, h.IsEmpty())
+	assert.True(t, h.IsFull())
+
+	_, err := h.Peek()
+	assert.Error(t, err)
+}
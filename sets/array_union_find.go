@@ -0,0 +1,125 @@
+package sets
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// arrayUnionFind - Disjoint Set (Union-Find) for dense int ids [0, n).
+//
+// There is no map here, ids are indices of slices,
+// so that's much faster than unionFind[int].
+type arrayUnionFind struct {
+	f forest
+
+	mu sync.Mutex
+}
+
+// NewArrayUnionFind creates Union-Find with n singletons: {0}, {1}, ..., {n-1}
+func NewArrayUnionFind(n int) *arrayUnionFind {
+	if n < 0 {
+		n = 0
+	}
+	return &arrayUnionFind{f: newForest(n)}
+}
+
+// Add makes sure `item` is in Union-Find.
+//
+// Ids are dense, so if item >= Size() -> all ids [Size(), item]
+// are added as singletons. Negative ids are ignored
+func (uf *arrayUnionFind) Add(item int) {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if item < 0 {
+		return
+	}
+	uf.f.grow(item + 1)
+}
+
+// Find returns the representative of the set that contains `item`
+func (uf *arrayUnionFind) Find(item int) (*int, error) {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if !uf.inBounds(item) {
+		return nil, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	root := uf.f.find(item)
+	return &root, nil
+}
+
+// Union merges sets that contain `a` and `b`
+func (uf *arrayUnionFind) Union(a, b int) error {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if !uf.inBounds(a) || !uf.inBounds(b) {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	uf.f.union(a, b)
+	return nil
+}
+
+// Connected returns true if `a` and `b` are in the same set
+func (uf *arrayUnionFind) Connected(a, b int) bool {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if !uf.inBounds(a) || !uf.inBounds(b) {
+		return false
+	}
+	return uf.f.find(a) == uf.f.find(b)
+}
+
+// SetSize returns number of elements in the set that contains `item`
+func (uf *arrayUnionFind) SetSize(item int) int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if !uf.inBounds(item) {
+		return 0
+	}
+	return uf.f.size[uf.f.find(item)]
+}
+
+// Members returns all elements of the set that contains `item`
+func (uf *arrayUnionFind) Members(item int) []int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if !uf.inBounds(item) {
+		return nil
+	}
+	return uf.f.members(item)
+}
+
+// Sets returns members of every set
+func (uf *arrayUnionFind) Sets() [][]int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	return uf.f.sets()
+}
+
+// Count returns number of disjoint sets
+func (uf *arrayUnionFind) Count() int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	return uf.f.count
+}
+
+// Size returns number of elements
+func (uf *arrayUnionFind) Size() int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	return len(uf.f.parent)
+}
+
+func (uf *arrayUnionFind) inBounds(item int) bool {
+	return item >= 0 && item < len(uf.f.parent)
+}
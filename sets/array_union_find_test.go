@@ -0,0 +1,101 @@
+package sets
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayUnionFind_Union(t *testing.T) {
+	var uf UnionFind[int] = NewArrayUnionFind(6)
+
+	assert.Equal(t, 6, uf.Size())
+	assert.Equal(t, 6, uf.Count())
+
+	require.NoError(t, uf.Union(0, 1))
+	require.NoError(t, uf.Union(2, 3))
+	require.NoError(t, uf.Union(1, 3))
+	assert.Equal(t, errors.New(gocollections.ErrOutOfBounds), uf.Union(0, 6))
+	assert.Error(t, uf.Union(-1, 0))
+
+	assert.True(t, uf.Connected(0, 2))
+	assert.False(t, uf.Connected(0, 4))
+	assert.False(t, uf.Connected(0, 100))
+	assert.Equal(t, 3, uf.Count())
+	assert.Equal(t, 4, uf.SetSize(3))
+	assert.Equal(t, 0, uf.SetSize(100))
+
+	r0, err := uf.Find(0)
+	require.NoError(t, err)
+	r3, err := uf.Find(3)
+	require.NoError(t, err)
+	assert.Equal(t, *r0, *r3)
+
+	_, err = uf.Find(6)
+	assert.Error(t, err)
+}
+
+func TestArrayUnionFind_Add(t *testing.T) {
+	uf := NewArrayUnionFind(0)
+
+	uf.Add(3)
+	assert.Equal(t, 4, uf.Size())
+	assert.Equal(t, 4, uf.Count())
+
+	uf.Add(1)
+	uf.Add(-5)
+	assert.Equal(t, 4, uf.Size())
+
+	require.NoError(t, uf.Union(0, 3))
+	uf.Add(5)
+	assert.Equal(t, 6, uf.Size())
+	assert.Equal(t, 5, uf.Count())
+	assert.True(t, uf.Connected(3, 0))
+}
+
+func TestArrayUnionFind_MembersAndSets(t *testing.T) {
+	uf := NewArrayUnionFind(7)
+	require.NoError(t, uf.Union(0, 6))
+	require.NoError(t, uf.Union(6, 3))
+	require.NoError(t, uf.Union(1, 2))
+
+	members := uf.Members(3)
+	sort.Ints(members)
+	assert.Equal(t, []int{0, 3, 6}, members)
+	assert.Equal(t, []int{5}, uf.Members(5))
+	assert.Nil(t, uf.Members(7))
+
+	sets := uf.Sets()
+	for _, s := range sets {
+		sort.Ints(s)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i][0] < sets[j][0] })
+	assert.Equal(t, [][]int{{0, 3, 6}, {1, 2}, {4}, {5}}, sets)
+}
+
+func BenchmarkArrayUnionFind_Union(b *testing.B) {
+	n := 1 << 16
+	for i := 0; i < b.N; i++ {
+		uf := NewArrayUnionFind(n)
+		for j := 1; j < n; j++ {
+			_ = uf.Union(j, (j*7919)%n)
+		}
+	}
+}
+
+func BenchmarkArrayUnionFind_Find(b *testing.B) {
+	n := 1 << 16
+	uf := NewArrayUnionFind(n)
+	for j := 1; j < n; j++ {
+		_ = uf.Union(j, (j*7919)%n)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = uf.Find(i % n)
+	}
+}
@@ -0,0 +1,54 @@
+package sets
+
+// UnionFind is the interface of Disjoint Set (Union-Find).
+//
+// Every element belongs to exactly one set. Each set has
+// a representative (root) - one of its members.
+//
+//	var uf sets.UnionFind[string]
+//
+//	uf = sets.NewUnionFind[string]()
+//	uf.Add("a")
+//	uf.Add("b")
+//	uf.Add("c")
+//	_ = uf.Union("a", "b")
+//
+//	uf.Connected("a", "b") // true
+//	uf.Connected("a", "c") // false
+//	uf.Count()             // 2
+type UnionFind[T comparable] interface {
+	// Add adds `item` as a new singleton set.
+	//
+	// If item already exists -> does nothing
+	Add(item T)
+
+	// Find returns the representative of the set that contains `item`
+	//
+	// if there is no item -> val = nil, err != nil
+	Find(item T) (*T, error)
+
+	// Union merges sets that contain `a` and `b`
+	//
+	// if there is no `a` or `b` -> returns err
+	Union(a, b T) error
+
+	// Connected returns true if `a` and `b` are in the same set
+	Connected(a, b T) bool
+
+	// SetSize returns number of elements in the set that contains `item`
+	//
+	// if there is no item -> returns 0
+	SetSize(item T) int
+
+	// Members returns all elements of the set that contains `item`
+	Members(item T) []T
+
+	// Sets returns members of every set
+	Sets() [][]T
+
+	// Count returns number of disjoint sets
+	Count() int
+
+	// Size returns number of elements
+	Size() int
+}
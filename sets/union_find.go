@@ -0,0 +1,143 @@
+package sets
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// unionFind - Disjoint Set (Union-Find) for any comparable type.
+//
+// Every item gets its own int id, and all the work is done by forest
+// with path compression and union by size.
+//
+// If your items are dense ints [0, n) -> use NewArrayUnionFind, it's faster
+type unionFind[T comparable] struct {
+	ids   map[T]int
+	items []T
+
+	f forest
+
+	mu sync.Mutex
+}
+
+func NewUnionFind[T comparable]() *unionFind[T] {
+	return &unionFind[T]{ids: make(map[T]int)}
+}
+
+// Add adds `item` as a new singleton set.
+//
+// If item already exists -> does nothing
+func (uf *unionFind[T]) Add(item T) {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	if _, exists := uf.ids[item]; exists {
+		return
+	}
+	uf.ids[item] = len(uf.items)
+	uf.items = append(uf.items, item)
+	uf.f.grow(len(uf.items))
+}
+
+// Find returns the representative of the set that contains `item`
+func (uf *unionFind[T]) Find(item T) (*T, error) {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	id, exists := uf.ids[item]
+	if !exists {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	root := uf.items[uf.f.find(id)]
+	return &root, nil
+}
+
+// Union merges sets that contain `a` and `b`
+func (uf *unionFind[T]) Union(a, b T) error {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	idA, existsA := uf.ids[a]
+	idB, existsB := uf.ids[b]
+	if !existsA || !existsB {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	uf.f.union(idA, idB)
+	return nil
+}
+
+// Connected returns true if `a` and `b` are in the same set
+func (uf *unionFind[T]) Connected(a, b T) bool {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	idA, existsA := uf.ids[a]
+	idB, existsB := uf.ids[b]
+	if !existsA || !existsB {
+		return false
+	}
+	return uf.f.find(idA) == uf.f.find(idB)
+}
+
+// SetSize returns number of elements in the set that contains `item`
+func (uf *unionFind[T]) SetSize(item T) int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	id, exists := uf.ids[item]
+	if !exists {
+		return 0
+	}
+	return uf.f.size[uf.f.find(id)]
+}
+
+// Members returns all elements of the set that contains `item`
+func (uf *unionFind[T]) Members(item T) []T {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	id, exists := uf.ids[item]
+	if !exists {
+		return nil
+	}
+	return uf.toItems(uf.f.members(id))
+}
+
+// Sets returns members of every set
+func (uf *unionFind[T]) Sets() [][]T {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	groups := uf.f.sets()
+	res := make([][]T, 0, len(groups))
+	for _, ids := range groups {
+		res = append(res, uf.toItems(ids))
+	}
+	return res
+}
+
+// Count returns number of disjoint sets
+func (uf *unionFind[T]) Count() int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	return uf.f.count
+}
+
+// Size returns number of elements
+func (uf *unionFind[T]) Size() int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	return len(uf.items)
+}
+
+func (uf *unionFind[T]) toItems(ids []int) []T {
+	items := make([]T, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, uf.items[id])
+	}
+	return items
+}
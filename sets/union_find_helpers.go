@@ -0,0 +1,93 @@
+package sets
+
+// forest is the core of Union-Find over dense int ids [0, len(parent)).
+//
+// # parent 	-> parent[i] is parent of i, root has parent[i] == i
+//
+// # size 	-> size[root] is the number of elements in the set (valid only for roots)
+//
+// # next 	-> circular list of set members, so we can list members
+// of one set in O(set size) without scanning all elements
+//
+// # count 	-> number of disjoint sets
+type forest struct {
+	parent []int
+	size   []int
+	next   []int
+
+	count int
+}
+
+func newForest(n int) forest {
+	f := forest{
+		parent: make([]int, 0, n),
+		size:   make([]int, 0, n),
+		next:   make([]int, 0, n),
+	}
+	f.grow(n)
+	return f
+}
+
+// grow adds singletons until there are n elements
+func (f *forest) grow(n int) {
+	for i := len(f.parent); i < n; i++ {
+		f.parent = append(f.parent, i)
+		f.size = append(f.size, 1)
+		f.next = append(f.next, i)
+		f.count++
+	}
+}
+
+// find returns root of x with path compression.
+//
+// First pass finds the root, second pass links every node on the path to the root
+func (f *forest) find(x int) int {
+	root := x
+	for f.parent[root] != root {
+		root = f.parent[root]
+	}
+	for f.parent[x] != root {
+		x, f.parent[x] = f.parent[x], root
+	}
+	return root
+}
+
+// union merges sets of x and y by size: smaller tree goes under bigger one.
+//
+// returns false if x and y were already in the same set
+func (f *forest) union(x, y int) bool {
+	rx, ry := f.find(x), f.find(y)
+	if rx == ry {
+		return false
+	}
+	if f.size[rx] < f.size[ry] {
+		rx, ry = ry, rx
+	}
+	f.parent[ry] = rx
+	f.size[rx] += f.size[ry]
+
+	// splice two circular lists into one
+	f.next[rx], f.next[ry] = f.next[ry], f.next[rx]
+	f.count--
+	return true
+}
+
+// members returns ids of all elements in the set of x
+func (f *forest) members(x int) []int {
+	ids := []int{x}
+	for i := f.next[x]; i != x; i = f.next[i] {
+		ids = append(ids, i)
+	}
+	return ids
+}
+
+// sets returns ids of members of every set
+func (f *forest) sets() [][]int {
+	res := make([][]int, 0, f.count)
+	for i := range f.parent {
+		if f.parent[i] == i {
+			res = append(res, f.members(i))
+		}
+	}
+	return res
+}
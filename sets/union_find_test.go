@@ -0,0 +1,130 @@
+package sets
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnionFind_AddFind(t *testing.T) {
+	var uf UnionFind[string] = NewUnionFind[string]()
+
+	uf.Add("a")
+	uf.Add("b")
+	uf.Add("a")
+
+	assert.Equal(t, 2, uf.Size())
+	assert.Equal(t, 2, uf.Count())
+
+	root, err := uf.Find("a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", *root)
+
+	root, err = uf.Find("x")
+	assert.Nil(t, root)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+}
+
+func TestUnionFind_Union(t *testing.T) {
+	uf := NewUnionFind[string]()
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		uf.Add(s)
+	}
+
+	require.NoError(t, uf.Union("a", "b"))
+	require.NoError(t, uf.Union("c", "d"))
+	require.NoError(t, uf.Union("b", "d"))
+	// already connected -> nothing changes
+	require.NoError(t, uf.Union("a", "c"))
+	assert.Error(t, uf.Union("a", "x"))
+
+	assert.True(t, uf.Connected("a", "d"))
+	assert.True(t, uf.Connected("c", "b"))
+	assert.False(t, uf.Connected("a", "e"))
+	assert.False(t, uf.Connected("a", "x"))
+
+	assert.Equal(t, 2, uf.Count())
+	assert.Equal(t, 4, uf.SetSize("c"))
+	assert.Equal(t, 1, uf.SetSize("e"))
+	assert.Equal(t, 0, uf.SetSize("x"))
+
+	ra, err := uf.Find("a")
+	require.NoError(t, err)
+	rd, err := uf.Find("d")
+	require.NoError(t, err)
+	assert.Equal(t, *ra, *rd)
+}
+
+func TestUnionFind_MembersAndSets(t *testing.T) {
+	uf := NewUnionFind[int]()
+	for i := 0; i < 10; i++ {
+		uf.Add(i * 10)
+	}
+	// evens and odds
+	for i := 2; i < 10; i++ {
+		require.NoError(t, uf.Union(i*10, (i-2)*10))
+	}
+
+	members := uf.Members(30)
+	sort.Ints(members)
+	assert.Equal(t, []int{10, 30, 50, 70, 90}, members)
+	assert.Nil(t, uf.Members(-1))
+
+	sets := uf.Sets()
+	assert.Len(t, sets, 2)
+	for _, s := range sets {
+		sort.Ints(s)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i][0] < sets[j][0] })
+	assert.Equal(t, [][]int{{0, 20, 40, 60, 80}, {10, 30, 50, 70, 90}}, sets)
+}
+
+func TestUnionFind_Chain(t *testing.T) {
+	n := 10000
+	uf := NewUnionFind[string]()
+	for i := 0; i < n; i++ {
+		uf.Add(fmt.Sprintf("node-%d", i))
+	}
+	for i := 1; i < n; i++ {
+		require.NoError(t, uf.Union(fmt.Sprintf("node-%d", i-1), fmt.Sprintf("node-%d", i)))
+	}
+
+	assert.Equal(t, 1, uf.Count())
+	assert.Equal(t, n, uf.SetSize("node-0"))
+	assert.True(t, uf.Connected("node-0", fmt.Sprintf("node-%d", n-1)))
+	assert.Len(t, uf.Members("node-42"), n)
+}
+
+func BenchmarkUnionFind_Union(b *testing.B) {
+	n := 1 << 16
+	for i := 0; i < b.N; i++ {
+		uf := NewUnionFind[int]()
+		for j := 0; j < n; j++ {
+			uf.Add(j)
+		}
+		for j := 1; j < n; j++ {
+			_ = uf.Union(j, (j*7919)%n)
+		}
+	}
+}
+
+func BenchmarkUnionFind_Find(b *testing.B) {
+	n := 1 << 16
+	uf := NewUnionFind[int]()
+	for j := 0; j < n; j++ {
+		uf.Add(j)
+	}
+	for j := 1; j < n; j++ {
+		_ = uf.Union(j, (j*7919)%n)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = uf.Find(i % n)
+	}
+}
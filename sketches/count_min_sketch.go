@@ -0,0 +1,211 @@
+package sketches
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/0x0FACED/go-collections/filters"
+)
+
+// version + kind + width + depth + total
+const cmsHeaderSize = 2 + 8 + 8 + 8
+
+// countMinSketch - Count-Min Sketch, estimates frequencies of items in stream.
+//
+// It's a table depth x width of counters, every row has its own hash function.
+// Add increments one counter in each row, Estimate returns min of them.
+//
+// Estimate never returns less than real count, and with probability 1 - delta:
+//
+//	Estimate(x) <= count(x) + epsilon * Total()
+type countMinSketch[T any] struct {
+	counters []uint64
+	width    uint64
+	depth    uint64
+	total    uint64
+
+	hash filters.Hasher[T]
+
+	mu sync.RWMutex
+}
+
+// NewCountMinSketch creates Count-Min Sketch with error `epsilon`
+// and probability of exceeding the error `delta`:
+//
+//	width = e / epsilon
+//	depth = ln(1 / delta)
+//
+// If hash == nil -> filters.DefaultHasher is used
+func NewCountMinSketch[T any](epsilon, delta float64, hash filters.Hasher[T]) *countMinSketch[T] {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := uint64(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinSketchWithSize(width, depth, hash)
+}
+
+// NewCountMinSketchWithSize creates Count-Min Sketch with `depth` rows of `width` counters
+func NewCountMinSketchWithSize[T any](width, depth uint64, hash filters.Hasher[T]) *countMinSketch[T] {
+	if hash == nil {
+		hash = filters.DefaultHasher[T]()
+	}
+	width, depth = max(width, 1), max(depth, 1)
+	return &countMinSketch[T]{
+		counters: make([]uint64, width*depth),
+		width:    width,
+		depth:    depth,
+		hash:     hash,
+	}
+}
+
+// Add adds `n` occurrences of `item`
+func (cms *countMinSketch[T]) Add(item T, n uint64) {
+	h1, h2 := filters.SplitHash(cms.hash(item))
+
+	cms.mu.Lock()
+	defer cms.mu.Unlock()
+
+	cms.add(h1, h2, n)
+}
+
+// Estimate returns estimated number of occurrences of `item`
+func (cms *countMinSketch[T]) Estimate(item T) uint64 {
+	h1, h2 := filters.SplitHash(cms.hash(item))
+
+	cms.mu.RLock()
+	defer cms.mu.RUnlock()
+
+	return cms.estimate(h1, h2)
+}
+
+// Total returns sum of all added `n`
+func (cms *countMinSketch[T]) Total() uint64 {
+	cms.mu.RLock()
+	defer cms.mu.RUnlock()
+
+	return cms.total
+}
+
+// Size returns width and depth of sketch
+func (cms *countMinSketch[T]) Size() (uint64, uint64) {
+	cms.mu.RLock()
+	defer cms.mu.RUnlock()
+
+	return cms.width, cms.depth
+}
+
+// Merge adds all items of `other` to sketch.
+//
+// Both must have the same width, depth and hash function, otherwise returns err
+func (cms *countMinSketch[T]) Merge(other *countMinSketch[T]) error {
+	otherCounters, width, depth, total := other.snapshot()
+
+	cms.mu.Lock()
+	defer cms.mu.Unlock()
+
+	if cms.width != width || cms.depth != depth {
+		return fmt.Errorf(gocollections.ErrIncompatible)
+	}
+	for i, c := range otherCounters {
+		cms.counters[i] = saturatingAdd(cms.counters[i], c)
+	}
+	cms.total = saturatingAdd(cms.total, total)
+	return nil
+}
+
+// Reset removes all items
+func (cms *countMinSketch[T]) Reset() {
+	cms.mu.Lock()
+	defer cms.mu.Unlock()
+
+	clear(cms.counters)
+	cms.total = 0
+}
+
+// MarshalBinary encodes sketch to bytes.
+// Hash function is not encoded, so decode with the same one
+func (cms *countMinSketch[T]) MarshalBinary() ([]byte, error) {
+	cms.mu.RLock()
+	defer cms.mu.RUnlock()
+
+	buf := make([]byte, 0, cmsHeaderSize+len(cms.counters)*8)
+	buf = append(buf, formatVersion, kindCountMinSketch)
+	buf = binary.LittleEndian.AppendUint64(buf, cms.width)
+	buf = binary.LittleEndian.AppendUint64(buf, cms.depth)
+	buf = binary.LittleEndian.AppendUint64(buf, cms.total)
+	for _, c := range cms.counters {
+		buf = binary.LittleEndian.AppendUint64(buf, c)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes sketch encoded by MarshalBinary.
+//
+// Hash function stays the same
+func (cms *countMinSketch[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < cmsHeaderSize || data[0] != formatVersion || data[1] != kindCountMinSketch {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	width := binary.LittleEndian.Uint64(data[2:])
+	depth := binary.LittleEndian.Uint64(data[10:])
+	total := binary.LittleEndian.Uint64(data[18:])
+	payload := data[cmsHeaderSize:]
+	if width == 0 || depth == 0 || uint64(len(payload))/8/width != depth || uint64(len(payload)) != width*depth*8 {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+
+	counters := make([]uint64, width*depth)
+	for i := range counters {
+		counters[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+
+	cms.mu.Lock()
+	defer cms.mu.Unlock()
+
+	cms.counters, cms.width, cms.depth, cms.total = counters, width, depth, total
+	return nil
+}
+
+// add increments counters without lock and returns new estimate
+func (cms *countMinSketch[T]) add(h1, h2, n uint64) uint64 {
+	est := uint64(math.MaxUint64)
+	for row := uint64(0); row < cms.depth; row++ {
+		i := row*cms.width + (h1+row*h2)%cms.width
+		cms.counters[i] = saturatingAdd(cms.counters[i], n)
+		est = min(est, cms.counters[i])
+	}
+	cms.total = saturatingAdd(cms.total, n)
+	return est
+}
+
+func (cms *countMinSketch[T]) estimate(h1, h2 uint64) uint64 {
+	est := uint64(math.MaxUint64)
+	for row := uint64(0); row < cms.depth; row++ {
+		est = min(est, cms.counters[row*cms.width+(h1+row*h2)%cms.width])
+	}
+	return est
+}
+
+// snapshot returns copy of counters, width, depth and total
+func (cms *countMinSketch[T]) snapshot() ([]uint64, uint64, uint64, uint64) {
+	cms.mu.RLock()
+	defer cms.mu.RUnlock()
+
+	res := make([]uint64, len(cms.counters))
+	copy(res, cms.counters)
+	return res, cms.width, cms.depth, cms.total
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
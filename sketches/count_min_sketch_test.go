@@ -0,0 +1,108 @@
+package sketches
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/0x0FACED/go-collections/filters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountMinSketch_Estimate(t *testing.T) {
+	cms := NewCountMinSketch[string](0.001, 0.01, filters.StringHasher)
+	width, depth := cms.Size()
+	assert.Equal(t, uint64(2719), width)
+	assert.Equal(t, uint64(5), depth)
+
+	rnd := rand.New(rand.NewSource(1))
+	counts := make(map[string]uint64)
+	for i := 0; i < 100000; i++ {
+		item := fmt.Sprintf("item-%d", rnd.Intn(5000))
+		cms.Add(item, 1)
+		counts[item]++
+	}
+	assert.Equal(t, uint64(100000), cms.Total())
+
+	bound := uint64(0.001 * 100000)
+	bad := 0
+	for item, count := range counts {
+		est := cms.Estimate(item)
+		// never underestimates
+		assert.GreaterOrEqual(t, est, count)
+		if est > count+bound {
+			bad++
+		}
+	}
+	assert.LessOrEqual(t, float64(bad)/float64(len(counts)), 0.01)
+	assert.Equal(t, uint64(0), NewCountMinSketch[string](0.01, 0.01, nil).Estimate("nothing"))
+}
+
+func TestCountMinSketch_AddN(t *testing.T) {
+	cms := NewCountMinSketchWithSize[string](100, 4, nil)
+	cms.Add("a", 10)
+	cms.Add("a", 5)
+	cms.Add("b", 1)
+
+	assert.GreaterOrEqual(t, cms.Estimate("a"), uint64(15))
+	assert.GreaterOrEqual(t, cms.Estimate("b"), uint64(1))
+	assert.Equal(t, uint64(16), cms.Total())
+
+	cms.Reset()
+	assert.Equal(t, uint64(0), cms.Estimate("a"))
+	assert.Equal(t, uint64(0), cms.Total())
+}
+
+func TestCountMinSketch_Merge(t *testing.T) {
+	a := NewCountMinSketchWithSize[string](1000, 5, filters.StringHasher)
+	b := NewCountMinSketchWithSize[string](1000, 5, filters.StringHasher)
+	a.Add("x", 3)
+	b.Add("x", 4)
+	b.Add("y", 1)
+
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, uint64(7), a.Estimate("x"))
+	assert.Equal(t, uint64(1), a.Estimate("y"))
+	assert.Equal(t, uint64(8), a.Total())
+
+	c := NewCountMinSketchWithSize[string](1000, 4, filters.StringHasher)
+	assert.Equal(t, errors.New(gocollections.ErrIncompatible), a.Merge(c))
+}
+
+func TestCountMinSketch_Binary(t *testing.T) {
+	cms := NewCountMinSketchWithSize[string](64, 3, filters.StringHasher)
+	for i := 0; i < 100; i++ {
+		cms.Add(fmt.Sprintf("item-%d", i%10), uint64(i))
+	}
+
+	data, err := cms.MarshalBinary()
+	require.NoError(t, err)
+
+	decoded := NewCountMinSketchWithSize[string](1, 1, filters.StringHasher)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, cms.counters, decoded.counters)
+	assert.Equal(t, cms.Total(), decoded.Total())
+	for i := 0; i < 10; i++ {
+		item := fmt.Sprintf("item-%d", i)
+		assert.Equal(t, cms.Estimate(item), decoded.Estimate(item))
+	}
+
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), decoded.UnmarshalBinary(data[:len(data)-8]))
+	assert.Error(t, decoded.UnmarshalBinary(data[:5]))
+}
+
+func BenchmarkCountMinSketch_Add(b *testing.B) {
+	cms := NewCountMinSketch[string](0.001, 0.01, filters.StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cms.Add(items[i%len(items)], 1)
+	}
+}
@@ -0,0 +1,191 @@
+package sketches
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/0x0FACED/go-collections/filters"
+)
+
+const (
+	minPrecision = 4
+	maxPrecision = 18
+
+	defaultPrecision = 14
+)
+
+// hyperLogLog - HyperLogLog, estimates number of distinct items
+// using m = 2^precision small registers.
+//
+// First `precision` bits of hash select register, register keeps
+// max position of the first 1-bit in the rest of hash.
+// Standard error is about 1.04 / sqrt(m):
+//
+//	precision 10 -> 1 KB,  error ~3.25%
+//	precision 14 -> 16 KB, error ~0.81%
+//	precision 18 -> 256 KB, error ~0.2%
+type hyperLogLog[T any] struct {
+	registers []uint8
+	p         uint8
+
+	hash filters.Hasher[T]
+
+	mu sync.RWMutex
+}
+
+// NewHyperLogLog creates HyperLogLog with `precision` from 4 to 18.
+// If precision is out of range -> 14 is used.
+//
+// If hash == nil -> filters.DefaultHasher is used
+func NewHyperLogLog[T any](precision uint8, hash filters.Hasher[T]) *hyperLogLog[T] {
+	if precision < minPrecision || precision > maxPrecision {
+		precision = defaultPrecision
+	}
+	if hash == nil {
+		hash = filters.DefaultHasher[T]()
+	}
+	return &hyperLogLog[T]{
+		registers: make([]uint8, 1<<precision),
+		p:         precision,
+		hash:      hash,
+	}
+}
+
+// Add adds `item` to HyperLogLog
+func (hll *hyperLogLog[T]) Add(item T) {
+	h := hll.hash(item)
+
+	hll.mu.Lock()
+	defer hll.mu.Unlock()
+
+	idx := h >> (64 - hll.p)
+	// sentinel bit, so rank is never more than 64 - p + 1
+	w := h<<hll.p | 1<<(hll.p-1)
+	rank := uint8(bits.LeadingZeros64(w)) + 1
+	if rank > hll.registers[idx] {
+		hll.registers[idx] = rank
+	}
+}
+
+// Estimate returns estimated number of distinct added items
+func (hll *hyperLogLog[T]) Estimate() uint64 {
+	hll.mu.RLock()
+	defer hll.mu.RUnlock()
+
+	m := float64(len(hll.registers))
+	var sum float64
+	var zeros int
+	for _, r := range hll.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(len(hll.registers)) * m * m / sum
+	// small range correction: linear counting is better there
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// Merge adds all items of `other` to HyperLogLog.
+//
+// Both must have the same precision and hash function, otherwise returns err
+func (hll *hyperLogLog[T]) Merge(other *hyperLogLog[T]) error {
+	otherRegisters, p := other.snapshot()
+
+	hll.mu.Lock()
+	defer hll.mu.Unlock()
+
+	if hll.p != p {
+		return fmt.Errorf(gocollections.ErrIncompatible)
+	}
+	for i, r := range otherRegisters {
+		hll.registers[i] = max(hll.registers[i], r)
+	}
+	return nil
+}
+
+// Precision returns precision of HyperLogLog
+func (hll *hyperLogLog[T]) Precision() uint8 {
+	hll.mu.RLock()
+	defer hll.mu.RUnlock()
+
+	return hll.p
+}
+
+// Reset removes all items
+func (hll *hyperLogLog[T]) Reset() {
+	hll.mu.Lock()
+	defer hll.mu.Unlock()
+
+	clear(hll.registers)
+}
+
+// MarshalBinary encodes HyperLogLog to bytes.
+// Hash function is not encoded, so decode with the same one
+func (hll *hyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	hll.mu.RLock()
+	defer hll.mu.RUnlock()
+
+	buf := make([]byte, 0, 3+len(hll.registers))
+	buf = append(buf, formatVersion, kindHyperLogLog, hll.p)
+	buf = append(buf, hll.registers...)
+	return buf, nil
+}
+
+// UnmarshalBinary decodes HyperLogLog encoded by MarshalBinary.
+//
+// Hash function stays the same
+func (hll *hyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] != formatVersion || data[1] != kindHyperLogLog {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	p := data[2]
+	if p < minPrecision || p > maxPrecision || len(data)-3 != 1<<p {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+
+	registers := make([]uint8, 1<<p)
+	copy(registers, data[3:])
+	for _, r := range registers {
+		if r > 64-p+1 {
+			return fmt.Errorf(gocollections.ErrInvalidData)
+		}
+	}
+
+	hll.mu.Lock()
+	defer hll.mu.Unlock()
+
+	hll.registers, hll.p = registers, p
+	return nil
+}
+
+// snapshot returns copy of registers and precision
+func (hll *hyperLogLog[T]) snapshot() ([]uint8, uint8) {
+	hll.mu.RLock()
+	defer hll.mu.RUnlock()
+
+	res := make([]uint8, len(hll.registers))
+	copy(res, hll.registers)
+	return res, hll.p
+}
+
+// alpha is the bias correction constant for m registers
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,100 @@
+package sketches
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/0x0FACED/go-collections/filters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertRelativeError(t *testing.T, expected, actual uint64, maxErr float64) {
+	t.Helper()
+	relErr := math.Abs(float64(actual)-float64(expected)) / float64(expected)
+	assert.LessOrEqual(t, relErr, maxErr, "expected ~%d, got %d", expected, actual)
+}
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	hll := NewHyperLogLog[string](14, filters.StringHasher)
+	assert.Equal(t, uint64(0), hll.Estimate())
+
+	for _, n := range []int{10, 1000, 100000} {
+		hll.Reset()
+		for i := 0; i < n; i++ {
+			hll.Add(fmt.Sprintf("user-%d", i))
+			// duplicates don't change estimate
+			hll.Add(fmt.Sprintf("user-%d", i))
+		}
+		// error ~0.81%, take 4 sigma
+		assertRelativeError(t, uint64(n), hll.Estimate(), 0.04)
+	}
+}
+
+func TestHyperLogLog_Precision(t *testing.T) {
+	assert.Equal(t, uint8(14), NewHyperLogLog[string](0, nil).Precision())
+	assert.Equal(t, uint8(14), NewHyperLogLog[string](30, nil).Precision())
+
+	hll := NewHyperLogLog[string](4, nil)
+	assert.Len(t, hll.registers, 16)
+	for i := 0; i < 1000; i++ {
+		hll.Add(fmt.Sprintf("user-%d", i))
+	}
+	// 16 registers -> error ~26%
+	assertRelativeError(t, 1000, hll.Estimate(), 0.8)
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	a := NewHyperLogLog[string](12, filters.StringHasher)
+	b := NewHyperLogLog[string](12, filters.StringHasher)
+	for i := 0; i < 6000; i++ {
+		a.Add(fmt.Sprintf("user-%d", i))
+	}
+	for i := 4000; i < 10000; i++ {
+		b.Add(fmt.Sprintf("user-%d", i))
+	}
+
+	require.NoError(t, a.Merge(b))
+	// error ~1.6%, take 4 sigma
+	assertRelativeError(t, 10000, a.Estimate(), 0.07)
+
+	c := NewHyperLogLog[string](10, filters.StringHasher)
+	assert.Equal(t, errors.New(gocollections.ErrIncompatible), a.Merge(c))
+}
+
+func TestHyperLogLog_Binary(t *testing.T) {
+	hll := NewHyperLogLog[string](10, filters.StringHasher)
+	for i := 0; i < 5000; i++ {
+		hll.Add(fmt.Sprintf("user-%d", i))
+	}
+
+	data, err := hll.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, data, 3+1024)
+
+	decoded := NewHyperLogLog[string](14, filters.StringHasher)
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, uint8(10), decoded.Precision())
+	assert.Equal(t, hll.Estimate(), decoded.Estimate())
+
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), decoded.UnmarshalBinary(data[:100]))
+	assert.Error(t, decoded.UnmarshalBinary([]byte{formatVersion, kindCountMinSketch, 10}))
+	data[10] = 200
+	assert.Error(t, decoded.UnmarshalBinary(data))
+}
+
+func BenchmarkHyperLogLog_Add(b *testing.B) {
+	hll := NewHyperLogLog[string](14, filters.StringHasher)
+	items := make([]string, 1024)
+	for i := range items {
+		items[i] = fmt.Sprintf("user-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hll.Add(items[i%len(items)])
+	}
+}
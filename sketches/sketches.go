@@ -0,0 +1,8 @@
+package sketches
+
+const (
+	formatVersion byte = 1
+
+	kindHyperLogLog    byte = 'H'
+	kindCountMinSketch byte = 'M'
+)
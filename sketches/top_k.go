@@ -0,0 +1,147 @@
+package sketches
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/0x0FACED/go-collections/filters"
+	"github.com/0x0FACED/go-collections/heaps"
+)
+
+// HeavyHitter is an item with its estimated count
+type HeavyHitter[T comparable] struct {
+	Item  T
+	Count uint64
+}
+
+// topK - tracker of k most frequent items (heavy hitters) on top of Count-Min Sketch.
+//
+// Tracked items are stored in map with their current estimates and in min-heap,
+// so the least frequent tracked item is always on top.
+// heaps.Heap can't update items, so updated item is just inserted again,
+// and old heap entries become stale: they are skipped when they reach the top
+// and thrown away when heap grows too big.
+type topK[T comparable] struct {
+	k      int
+	sketch *countMinSketch[T]
+
+	counts map[T]uint64
+	heap   heaps.Heap[HeavyHitter[T]]
+
+	mu sync.Mutex
+}
+
+// NewTopK creates tracker of `k` heavy hitters, which counts items with `sketch`.
+//
+// Add items only through tracker, otherwise it won't see them
+func NewTopK[T comparable](k int, sketch *countMinSketch[T]) *topK[T] {
+	return &topK[T]{
+		k:      max(k, 1),
+		sketch: sketch,
+		counts: make(map[T]uint64),
+		heap:   heaps.NewHeap(minCountComparator[T]),
+	}
+}
+
+// Add adds `n` occurrences of `item` to sketch and updates heavy hitters
+func (tk *topK[T]) Add(item T, n uint64) {
+	h1, h2 := filters.SplitHash(tk.sketch.hash(item))
+
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+
+	tk.sketch.mu.Lock()
+	est := tk.sketch.add(h1, h2, n)
+	tk.sketch.mu.Unlock()
+
+	if curr, tracked := tk.counts[item]; tracked {
+		if est != curr {
+			tk.counts[item] = est
+			tk.heap.Insert(HeavyHitter[T]{Item: item, Count: est})
+			tk.compact()
+		}
+		return
+	}
+
+	if len(tk.counts) < tk.k {
+		tk.counts[item] = est
+		tk.heap.Insert(HeavyHitter[T]{Item: item, Count: est})
+		return
+	}
+
+	least := tk.peekLeast()
+	if est > least.Count {
+		_, _ = tk.heap.Extract()
+		delete(tk.counts, least.Item)
+		tk.counts[item] = est
+		tk.heap.Insert(HeavyHitter[T]{Item: item, Count: est})
+	}
+}
+
+// Top returns heavy hitters sorted by count in descending order
+func (tk *topK[T]) Top() []HeavyHitter[T] {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+
+	res := make([]HeavyHitter[T], 0, len(tk.counts))
+	for item, count := range tk.counts {
+		res = append(res, HeavyHitter[T]{Item: item, Count: count})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Count > res[j].Count
+	})
+	return res
+}
+
+// Estimate returns estimated number of occurrences of `item`
+func (tk *topK[T]) Estimate(item T) uint64 {
+	return tk.sketch.Estimate(item)
+}
+
+// Reset removes all items from tracker and its sketch
+func (tk *topK[T]) Reset() {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+
+	tk.sketch.Reset()
+	tk.counts = make(map[T]uint64)
+	tk.heap = heaps.NewHeap(minCountComparator[T])
+}
+
+// peekLeast throws away stale entries from the top of heap
+// and returns the least frequent tracked item
+func (tk *topK[T]) peekLeast() HeavyHitter[T] {
+	for {
+		top, err := tk.heap.Peek()
+		if err != nil {
+			// never happens: every tracked item has its entry in heap
+			return HeavyHitter[T]{}
+		}
+		if count, tracked := tk.counts[top.Item]; tracked && count == top.Count {
+			return *top
+		}
+		_, _ = tk.heap.Extract()
+	}
+}
+
+// compact rebuilds heap from tracked items if there are too many stale entries
+func (tk *topK[T]) compact() {
+	if tk.heap.Size() <= 2*tk.k+16 {
+		return
+	}
+	tk.heap = heaps.NewHeap(minCountComparator[T])
+	for item, count := range tk.counts {
+		tk.heap.Insert(HeavyHitter[T]{Item: item, Count: count})
+	}
+}
+
+// minCountComparator makes min-heap by count
+func minCountComparator[T comparable](a, b HeavyHitter[T]) int {
+	if a.Count == b.Count {
+		return 0
+	} else if a.Count > b.Count {
+		return -1
+	} else {
+		return 1
+	}
+}
@@ -0,0 +1,57 @@
+package sketches
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/0x0FACED/go-collections/filters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopK_HeavyHitters(t *testing.T) {
+	tk := NewTopK(3, NewCountMinSketch[string](0.001, 0.01, filters.StringHasher))
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		tk.Add(fmt.Sprintf("noise-%d", rnd.Intn(10000)), 1)
+		if i%10 == 0 {
+			tk.Add("hot-1", 3)
+			tk.Add("hot-2", 2)
+			tk.Add("hot-3", 1)
+		}
+	}
+
+	top := tk.Top()
+	require.Len(t, top, 3)
+	assert.Equal(t, "hot-1", top[0].Item)
+	assert.Equal(t, "hot-2", top[1].Item)
+	assert.Equal(t, "hot-3", top[2].Item)
+	assert.GreaterOrEqual(t, top[0].Count, uint64(6000))
+	assert.Equal(t, top[0].Count, tk.Estimate("hot-1"))
+
+	// heap doesn't grow with stale entries forever
+	assert.LessOrEqual(t, tk.heap.Size(), 2*3+16+1)
+}
+
+func TestTopK_Replacement(t *testing.T) {
+	tk := NewTopK(2, NewCountMinSketchWithSize[string](1000, 5, filters.StringHasher))
+
+	tk.Add("a", 5)
+	tk.Add("b", 3)
+	tk.Add("c", 1)
+	assert.Equal(t, []HeavyHitter[string]{{"a", 5}, {"b", 3}}, tk.Top())
+
+	// c overtakes b
+	tk.Add("c", 3)
+	assert.Equal(t, []HeavyHitter[string]{{"a", 5}, {"c", 4}}, tk.Top())
+
+	// b comes back
+	tk.Add("b", 3)
+	assert.Equal(t, []HeavyHitter[string]{{"b", 6}, {"a", 5}}, tk.Top())
+
+	tk.Reset()
+	assert.Empty(t, tk.Top())
+	assert.Equal(t, uint64(0), tk.Estimate("a"))
+}
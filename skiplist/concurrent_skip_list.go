@@ -0,0 +1,338 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// cnode is the node of Concurrent Skip List.
+//
+// # val 		-> ptr to val, replaced atomically by Put
+//
+// # next 		-> next[i] is the next node on level i
+//
+// # marked 		-> node is logically deleted
+//
+// # fullyLinked 	-> node is linked on all its levels, so it's logically in the list
+//
+// # mu 		-> lock of the node, it's taken only by writers
+type cnode[K any, V any] struct {
+	key K
+	val atomic.Pointer[V]
+
+	next []atomic.Pointer[cnode[K, V]]
+
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+
+	mu sync.Mutex
+}
+
+// concurrentSkipList - Skip List for many goroutines (lazy skip list).
+//
+// There is no global lock:
+//
+// 1. Get never takes locks, it just walks the levels
+//
+// 2. Put and Delete lock only predecessors of the key
+// (and the node itself), validate them and relink
+//
+// Put, Get and Delete are linearizable.
+//
+// Floor, Ceiling, Min, Max, Range, ForEach, Keys and Entries are weakly consistent:
+// they never fail because of concurrent writes, but may or may not see
+// changes made while they are running.
+type concurrentSkipList[K any, V any] struct {
+	// head is sentinel node with maxLevel levels, it's never compared.
+	// nil is the tail of every level
+	head *cnode[K, V]
+
+	maxLevel int
+	p        float64
+	size     atomic.Int64
+
+	// level is the highest level in use. It only grows,
+	// so searches can start from it instead of maxLevel
+	level atomic.Int32
+
+	// rand.Rand is not safe for concurrent use
+	rnd   *rand.Rand
+	rndMu sync.Mutex
+
+	compare Comparator[K]
+}
+
+// NewConcurrentSkipList creates Concurrent Skip List with max level 32 and probability 0.25
+func NewConcurrentSkipList[K any, V any](compare Comparator[K]) *concurrentSkipList[K, V] {
+	src := rand.NewSource(time.Now().UnixNano())
+	return NewConcurrentSkipListWithConfig[K, V](compare, defaultMaxLevel, defaultProbability, src)
+}
+
+// NewConcurrentSkipListWithConfig creates Concurrent Skip List with custom config.
+//
+// Params are the same as in NewSkipListWithConfig
+func NewConcurrentSkipListWithConfig[K any, V any](compare Comparator[K], maxLevel int, p float64, src rand.Source) *concurrentSkipList[K, V] {
+	if maxLevel < 1 {
+		maxLevel = defaultMaxLevel
+	}
+	if p <= 0 || p >= 1 {
+		p = defaultProbability
+	}
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+
+	head := &cnode[K, V]{next: make([]atomic.Pointer[cnode[K, V]], maxLevel)}
+	head.fullyLinked.Store(true)
+
+	return &concurrentSkipList[K, V]{
+		head:     head,
+		maxLevel: maxLevel,
+		p:        p,
+		rnd:      rand.New(src),
+		compare:  compare,
+	}
+}
+
+// Put adds `key` with `val`.
+//
+// If key already exists -> replaces its val
+func (sl *concurrentSkipList[K, V]) Put(key K, val V) {
+	topLevel := sl.randomLevel()
+	sl.raiseLevel(topLevel)
+	preds := make([]*cnode[K, V], sl.maxLevel)
+	succs := make([]*cnode[K, V], sl.maxLevel)
+
+	for {
+		lFound := sl.find(key, preds, succs)
+		if lFound != -1 {
+			found := succs[lFound]
+			// wait until concurrent Put links this node
+			for !found.fullyLinked.Load() {
+				runtime.Gosched()
+			}
+			found.mu.Lock()
+			if found.marked.Load() {
+				// node is being deleted right now -> retry and insert a new one
+				found.mu.Unlock()
+				continue
+			}
+			found.val.Store(&val)
+			found.mu.Unlock()
+			return
+		}
+
+		highestLocked, valid := sl.lockPreds(preds, topLevel, func(level int) bool {
+			succ := succs[level]
+			return !preds[level].marked.Load() &&
+				(succ == nil || !succ.marked.Load()) &&
+				preds[level].next[level].Load() == succ
+		})
+		if !valid {
+			sl.unlockPreds(preds, highestLocked)
+			continue
+		}
+
+		newNode := &cnode[K, V]{key: key, next: make([]atomic.Pointer[cnode[K, V]], topLevel)}
+		newNode.val.Store(&val)
+		for level := 0; level < topLevel; level++ {
+			newNode.next[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].next[level].Store(newNode)
+		}
+		newNode.fullyLinked.Store(true)
+
+		sl.unlockPreds(preds, highestLocked)
+		sl.size.Add(1)
+		return
+	}
+}
+
+// Get returns val by `key`.
+//
+// Get doesn't take any locks
+func (sl *concurrentSkipList[K, V]) Get(key K) (*V, error) {
+	pred := sl.head
+	for level := sl.topLevel(); level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && sl.compare(key, curr.key) > 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if curr != nil && sl.compare(key, curr.key) == 0 {
+			if !curr.fullyLinked.Load() || curr.marked.Load() {
+				return nil, fmt.Errorf(gocollections.ErrNotFound)
+			}
+			val := *curr.val.Load()
+			return &val, nil
+		}
+	}
+	return nil, fmt.Errorf(gocollections.ErrNotFound)
+}
+
+// Delete deletes `key`
+func (sl *concurrentSkipList[K, V]) Delete(key K) error {
+	preds := make([]*cnode[K, V], sl.maxLevel)
+	succs := make([]*cnode[K, V], sl.maxLevel)
+
+	var victim *cnode[K, V]
+	isMarked := false
+	topLevel := -1
+
+	for {
+		lFound := sl.find(key, preds, succs)
+		if !isMarked {
+			if lFound == -1 || !sl.okToDelete(succs[lFound], lFound) {
+				return fmt.Errorf(gocollections.ErrNotFound)
+			}
+			victim = succs[lFound]
+			topLevel = len(victim.next)
+
+			victim.mu.Lock()
+			if victim.marked.Load() {
+				// somebody else is deleting it
+				victim.mu.Unlock()
+				return fmt.Errorf(gocollections.ErrNotFound)
+			}
+			// that's the linearization point of Delete
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		highestLocked, valid := sl.lockPreds(preds, topLevel, func(level int) bool {
+			return !preds[level].marked.Load() && preds[level].next[level].Load() == victim
+		})
+		if !valid {
+			sl.unlockPreds(preds, highestLocked)
+			continue
+		}
+
+		for level := topLevel - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		victim.mu.Unlock()
+
+		sl.unlockPreds(preds, highestLocked)
+		sl.size.Add(-1)
+		return nil
+	}
+}
+
+// Floor returns entry with the greatest key <= `key`
+func (sl *concurrentSkipList[K, V]) Floor(key K) (*Entry[K, V], error) {
+	for {
+		pred := sl.head
+		for level := sl.topLevel(); level >= 0; level-- {
+			curr := pred.next[level].Load()
+			for curr != nil && sl.compare(curr.key, key) <= 0 {
+				pred = curr
+				curr = pred.next[level].Load()
+			}
+		}
+		if pred == sl.head {
+			return nil, fmt.Errorf(gocollections.ErrNotFound)
+		}
+		if pred.isLive() {
+			return pred.entry(), nil
+		}
+		// pred is being inserted or deleted right now -> wait and retry
+		runtime.Gosched()
+	}
+}
+
+// Ceiling returns entry with the smallest key >= `key`
+func (sl *concurrentSkipList[K, V]) Ceiling(key K) (*Entry[K, V], error) {
+	curr := sl.firstLive(sl.lowerBound(key))
+	if curr == nil {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return curr.entry(), nil
+}
+
+// Min returns entry with the smallest key
+func (sl *concurrentSkipList[K, V]) Min() (*Entry[K, V], error) {
+	curr := sl.firstLive(sl.head.next[0].Load())
+	if curr == nil {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return curr.entry(), nil
+}
+
+// Max returns entry with the greatest key
+func (sl *concurrentSkipList[K, V]) Max() (*Entry[K, V], error) {
+	for {
+		pred := sl.head
+		for level := sl.topLevel(); level >= 0; level-- {
+			for curr := pred.next[level].Load(); curr != nil; curr = pred.next[level].Load() {
+				pred = curr
+			}
+		}
+		if pred == sl.head {
+			return nil, fmt.Errorf(gocollections.ErrEmpty)
+		}
+		if pred.isLive() {
+			return pred.entry(), nil
+		}
+		runtime.Gosched()
+	}
+}
+
+// Range returns entries with keys in [from, to) in ascending order
+func (sl *concurrentSkipList[K, V]) Range(from, to K) []Entry[K, V] {
+	entries := make([]Entry[K, V], 0)
+	for curr := sl.firstLive(sl.lowerBound(from)); curr != nil && sl.compare(curr.key, to) < 0; curr = sl.firstLive(curr.next[0].Load()) {
+		entries = append(entries, *curr.entry())
+	}
+	return entries
+}
+
+// ForEach calls `fn` for each entry in ascending order of keys
+// until `fn` returns false.
+//
+// Unlike skipList.ForEach, it's ok to modify Concurrent Skip List inside `fn`
+func (sl *concurrentSkipList[K, V]) ForEach(fn func(key K, val V) bool) {
+	for curr := sl.firstLive(sl.head.next[0].Load()); curr != nil; curr = sl.firstLive(curr.next[0].Load()) {
+		if !fn(curr.key, *curr.val.Load()) {
+			return
+		}
+	}
+}
+
+// Keys returns all keys in ascending order
+func (sl *concurrentSkipList[K, V]) Keys() []K {
+	keys := make([]K, 0, sl.Size())
+	sl.ForEach(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Entries returns all entries in ascending order of keys
+func (sl *concurrentSkipList[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, sl.Size())
+	sl.ForEach(func(key K, val V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Val: val})
+		return true
+	})
+	return entries
+}
+
+// Size returns number of keys.
+//
+// If there are concurrent writes -> that's just a snapshot
+func (sl *concurrentSkipList[K, V]) Size() int {
+	return int(sl.size.Load())
+}
+
+// IsEmpty returns true if there are no keys, otherwise false
+func (sl *concurrentSkipList[K, V]) IsEmpty() bool {
+	return sl.Size() == 0
+}
@@ -0,0 +1,119 @@
+package skiplist
+
+// find fills preds[i] with the last node on level i with key < `key`
+// and succs[i] with the next node after preds[i].
+//
+// returns the highest level where node with `key` was found, or -1
+func (sl *concurrentSkipList[K, V]) find(key K, preds, succs []*cnode[K, V]) int {
+	lFound := -1
+	pred := sl.head
+	for level := sl.topLevel(); level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && sl.compare(key, curr.key) > 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if lFound == -1 && curr != nil && sl.compare(key, curr.key) == 0 {
+			lFound = level
+		}
+		preds[level] = pred
+		succs[level] = curr
+	}
+	return lFound
+}
+
+// lowerBound returns the first node on level 0 with key >= `key`.
+// The node may be marked or not fully linked
+func (sl *concurrentSkipList[K, V]) lowerBound(key K) *cnode[K, V] {
+	pred := sl.head
+	for level := sl.topLevel(); level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && sl.compare(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+	}
+	return pred.next[0].Load()
+}
+
+// firstLive skips nodes on level 0 that are not logically in the list
+func (sl *concurrentSkipList[K, V]) firstLive(curr *cnode[K, V]) *cnode[K, V] {
+	for curr != nil && !curr.isLive() {
+		curr = curr.next[0].Load()
+	}
+	return curr
+}
+
+// lockPreds locks preds[0..topLevel) from the bottom up (every node only once)
+// while valid(level) returns true.
+//
+// returns the highest locked level and result of validation.
+// Caller must call unlockPreds with this level in any case
+func (sl *concurrentSkipList[K, V]) lockPreds(preds []*cnode[K, V], topLevel int, valid func(level int) bool) (int, bool) {
+	highestLocked := -1
+	ok := true
+	var prevPred *cnode[K, V]
+	for level := 0; ok && level < topLevel; level++ {
+		pred := preds[level]
+		if pred != prevPred {
+			pred.mu.Lock()
+			prevPred = pred
+		}
+		highestLocked = level
+		ok = valid(level)
+	}
+	return highestLocked, ok
+}
+
+// unlockPreds unlocks nodes locked by lockPreds
+func (sl *concurrentSkipList[K, V]) unlockPreds(preds []*cnode[K, V], highestLocked int) {
+	var prevPred *cnode[K, V]
+	for level := 0; level <= highestLocked; level++ {
+		if preds[level] != prevPred {
+			preds[level].mu.Unlock()
+			prevPred = preds[level]
+		}
+	}
+}
+
+// okToDelete returns true if `node` is fully linked, not deleted yet
+// and was found on its top level
+func (sl *concurrentSkipList[K, V]) okToDelete(node *cnode[K, V], lFound int) bool {
+	return node.fullyLinked.Load() && len(node.next)-1 == lFound && !node.marked.Load()
+}
+
+// topLevel returns index of the highest level in use
+func (sl *concurrentSkipList[K, V]) topLevel() int {
+	return int(sl.level.Load()) - 1
+}
+
+// raiseLevel makes sure that levels [0, lvl) are in use.
+//
+// Put calls it before find, so find fills preds and succs for all levels of new node
+func (sl *concurrentSkipList[K, V]) raiseLevel(lvl int) {
+	for {
+		curr := sl.level.Load()
+		if int(curr) >= lvl || sl.level.CompareAndSwap(curr, int32(lvl)) {
+			return
+		}
+	}
+}
+
+func (sl *concurrentSkipList[K, V]) randomLevel() int {
+	sl.rndMu.Lock()
+	defer sl.rndMu.Unlock()
+
+	lvl := 1
+	for lvl < sl.maxLevel && sl.rnd.Float64() < sl.p {
+		lvl++
+	}
+	return lvl
+}
+
+func (n *cnode[K, V]) isLive() bool {
+	return n.fullyLinked.Load() && !n.marked.Load()
+}
+
+func (n *cnode[K, V]) entry() *Entry[K, V] {
+	return &Entry[K, V]{Key: n.key, Val: *n.val.Load()}
+}
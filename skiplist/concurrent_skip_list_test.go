@@ -0,0 +1,259 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConcurrentSkipList() *concurrentSkipList[int, int] {
+	return NewConcurrentSkipListWithConfig[int, int](intComparator, 16, 0.5, rand.NewSource(42))
+}
+
+func TestConcurrentSkipList_PutGetDelete(t *testing.T) {
+	var sl SkipList[int, int] = newTestConcurrentSkipList()
+
+	_, err := sl.Get(1)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+
+	for i := 0; i < 100; i++ {
+		sl.Put(i, i*10)
+	}
+	sl.Put(5, -5)
+	assert.Equal(t, 100, sl.Size())
+
+	val, err := sl.Get(5)
+	require.NoError(t, err)
+	assert.Equal(t, -5, *val)
+
+	for i := 0; i < 100; i += 2 {
+		require.NoError(t, sl.Delete(i))
+	}
+	assert.Error(t, sl.Delete(0))
+	assert.Equal(t, 50, sl.Size())
+
+	for i := 0; i < 100; i++ {
+		_, err := sl.Get(i)
+		assert.Equal(t, i%2 == 1, err == nil)
+	}
+}
+
+func TestConcurrentSkipList_Ordered(t *testing.T) {
+	sl := newTestConcurrentSkipList()
+
+	_, err := sl.Min()
+	assert.Error(t, err)
+	_, err = sl.Max()
+	assert.Error(t, err)
+
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		sl.Put(k, k)
+	}
+
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, sl.Keys())
+	assert.Equal(t, []Entry[int, int]{{20, 20}, {30, 30}}, sl.Range(15, 40))
+	assert.Len(t, sl.Entries(), 5)
+
+	e, err := sl.Floor(35)
+	require.NoError(t, err)
+	assert.Equal(t, 30, e.Key)
+	_, err = sl.Floor(5)
+	assert.Error(t, err)
+
+	e, err = sl.Ceiling(35)
+	require.NoError(t, err)
+	assert.Equal(t, 40, e.Key)
+	_, err = sl.Ceiling(55)
+	assert.Error(t, err)
+
+	e, err = sl.Min()
+	require.NoError(t, err)
+	assert.Equal(t, 10, e.Key)
+	e, err = sl.Max()
+	require.NoError(t, err)
+	assert.Equal(t, 50, e.Key)
+
+	// modifying inside ForEach is ok
+	sl.ForEach(func(key, _ int) bool {
+		require.NoError(t, sl.Delete(key))
+		return true
+	})
+	assert.True(t, sl.IsEmpty())
+}
+
+// every goroutine owns its keys, so at the end we know exact state
+func TestConcurrentSkipList_StressDisjoint(t *testing.T) {
+	sl := NewConcurrentSkipList[int, int](intComparator)
+	workers, perWorker := 8, 2000
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				sl.Put(i*workers+w, w)
+			}
+			for i := 0; i < perWorker; i += 2 {
+				if err := sl.Delete(i*workers + w); err != nil {
+					t.Error(err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	keys := sl.Keys()
+	assert.Len(t, keys, workers*perWorker/2)
+	assert.Equal(t, len(keys), sl.Size())
+	assert.True(t, sort.IntsAreSorted(keys))
+	for _, k := range keys {
+		assert.Equal(t, 1, (k/workers)%2)
+	}
+}
+
+// all goroutines fight for the same small set of keys
+func TestConcurrentSkipList_StressContended(t *testing.T) {
+	sl := NewConcurrentSkipList[int, int](intComparator)
+	workers, ops, keySpace := 8, 5000, 64
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < ops; i++ {
+				k := rnd.Intn(keySpace)
+				switch rnd.Intn(4) {
+				case 0:
+					_ = sl.Delete(k)
+				case 1:
+					if val, err := sl.Get(k); err == nil && *val != k {
+						t.Errorf("key %d has val %d", k, *val)
+					}
+				case 2:
+					_ = sl.Range(k, k+8)
+				default:
+					sl.Put(k, k)
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	keys := sl.Keys()
+	assert.True(t, sort.IntsAreSorted(keys))
+	assert.Equal(t, len(keys), sl.Size())
+	for i := 1; i < len(keys); i++ {
+		assert.NotEqual(t, keys[i-1], keys[i])
+	}
+	for _, k := range keys {
+		val, err := sl.Get(k)
+		require.NoError(t, err)
+		assert.Equal(t, k, *val)
+	}
+}
+
+func TestConcurrentSkipList_ReadersDuringWrites(t *testing.T) {
+	sl := NewConcurrentSkipList[int, int](intComparator)
+	// even keys are never touched by writers
+	for i := 0; i < 1000; i += 2 {
+		sl.Put(i, i)
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 1; ; i += 2 {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				k := (i + w*250) % 1000
+				sl.Put(k, k)
+				_ = sl.Delete(k)
+			}
+		}(w)
+	}
+
+	for r := 0; r < 50; r++ {
+		evens := 0
+		prev := -1
+		sl.ForEach(func(key, val int) bool {
+			assert.Greater(t, key, prev)
+			assert.Equal(t, key, val)
+			prev = key
+			if key%2 == 0 {
+				evens++
+			}
+			return true
+		})
+		// weakly consistent iteration still sees every untouched key
+		assert.Equal(t, 500, evens)
+		for i := 0; i < 1000; i += 2 {
+			_, err := sl.Get(i)
+			assert.NoError(t, err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func BenchmarkConcurrentSkipList_Parallel(b *testing.B) {
+	n := 1 << 16
+	sl := NewConcurrentSkipList[int, int](intComparator)
+	for i := 0; i < n; i++ {
+		sl.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			k := rnd.Intn(n)
+			switch rnd.Intn(10) {
+			case 0:
+				sl.Put(k, k)
+			case 1:
+				_ = sl.Delete(k)
+			default:
+				_, _ = sl.Get(k)
+			}
+		}
+	})
+}
+
+func BenchmarkSkipList_Parallel(b *testing.B) {
+	n := 1 << 16
+	sl := NewSkipList[int, int](intComparator)
+	for i := 0; i < n; i++ {
+		sl.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			k := rnd.Intn(n)
+			switch rnd.Intn(10) {
+			case 0:
+				sl.Put(k, k)
+			case 1:
+				_ = sl.Delete(k)
+			default:
+				_, _ = sl.Get(k)
+			}
+		}
+	})
+}
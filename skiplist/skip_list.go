@@ -0,0 +1,277 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+const (
+	// default max number of levels
+	defaultMaxLevel = 32
+
+	// default probability to promote node to the next level
+	defaultProbability = 0.25
+)
+
+// skipNode is the node of Skip List.
+//
+// # next -> next[i] is the next node on level i.
+// len(next) is the height of node
+type skipNode[K any, V any] struct {
+	key K
+	val V
+
+	next []*skipNode[K, V]
+}
+
+// skipList - probabilistic ordered map.
+//
+// Level 0 is a sorted linked list of all nodes,
+// every next level contains a part of nodes of the previous level
+// (each node is promoted with probability p), so search skips a lot of nodes:
+//
+//	level 2: head ------------------------> 30 ------------------> nil
+//	level 1: head --------> 10 -----------> 30 --------> 50 -----> nil
+//	level 0: head -> 5 ---> 10 ---> 20 ---> 30 ---> 40 -> 50 -----> nil
+//
+// Put, Get and Delete are O(log n) on average
+type skipList[K any, V any] struct {
+	// head is sentinel node with maxLevel levels, key and val are empty
+	head *skipNode[K, V]
+
+	// level is the current number of levels in use
+	level    int
+	maxLevel int
+	p        float64
+	size     int
+
+	rnd *rand.Rand
+
+	mu sync.RWMutex
+
+	compare Comparator[K]
+}
+
+// NewSkipList creates Skip List with max level 32 and probability 0.25
+func NewSkipList[K any, V any](compare Comparator[K]) *skipList[K, V] {
+	src := rand.NewSource(time.Now().UnixNano())
+	return NewSkipListWithConfig[K, V](compare, defaultMaxLevel, defaultProbability, src)
+}
+
+// NewSkipListWithConfig creates Skip List with custom config:
+//
+// # maxLevel -> max number of levels. If maxLevel < 1 -> 32 is used
+//
+// # p -> probability to promote node to the next level. If p is not in (0, 1) -> 0.25 is used
+//
+// # src -> source of random levels. Pass rand.NewSource(seed) to get deterministic levels in tests.
+// If src is nil -> source with current time is used
+func NewSkipListWithConfig[K any, V any](compare Comparator[K], maxLevel int, p float64, src rand.Source) *skipList[K, V] {
+	if maxLevel < 1 {
+		maxLevel = defaultMaxLevel
+	}
+	if p <= 0 || p >= 1 {
+		p = defaultProbability
+	}
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+
+	return &skipList[K, V]{
+		head:     &skipNode[K, V]{next: make([]*skipNode[K, V], maxLevel)},
+		level:    1,
+		maxLevel: maxLevel,
+		p:        p,
+		rnd:      rand.New(src),
+		compare:  compare,
+	}
+}
+
+// Put adds `key` with `val`.
+//
+// If key already exists -> replaces its val
+func (sl *skipList[K, V]) Put(key K, val V) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	update := make([]*skipNode[K, V], sl.maxLevel)
+	curr := sl.findPrev(key, update)
+	if curr != nil && sl.compare(key, curr.key) == 0 {
+		curr.val = val
+		return
+	}
+
+	lvl := sl.randomLevel()
+	if lvl > sl.level {
+		for i := sl.level; i < lvl; i++ {
+			update[i] = sl.head
+		}
+		sl.level = lvl
+	}
+
+	newNode := &skipNode[K, V]{key: key, val: val, next: make([]*skipNode[K, V], lvl)}
+	for i := 0; i < lvl; i++ {
+		newNode.next[i] = update[i].next[i]
+		update[i].next[i] = newNode
+	}
+	sl.size++
+}
+
+// Get returns val by `key`
+func (sl *skipList[K, V]) Get(key K) (*V, error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	curr := sl.findPrev(key, nil)
+	if curr == nil || sl.compare(key, curr.key) != 0 {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	val := curr.val
+	return &val, nil
+}
+
+// Delete deletes `key`
+func (sl *skipList[K, V]) Delete(key K) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	update := make([]*skipNode[K, V], sl.maxLevel)
+	curr := sl.findPrev(key, update)
+	if curr == nil || sl.compare(key, curr.key) != 0 {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	for i := 0; i < len(curr.next); i++ {
+		update[i].next[i] = curr.next[i]
+	}
+	for sl.level > 1 && sl.head.next[sl.level-1] == nil {
+		sl.level--
+	}
+	sl.size--
+	return nil
+}
+
+// Floor returns entry with the greatest key <= `key`
+func (sl *skipList[K, V]) Floor(key K) (*Entry[K, V], error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	curr := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for curr.next[i] != nil && sl.compare(curr.next[i].key, key) <= 0 {
+			curr = curr.next[i]
+		}
+	}
+	if curr == sl.head {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return curr.entry(), nil
+}
+
+// Ceiling returns entry with the smallest key >= `key`
+func (sl *skipList[K, V]) Ceiling(key K) (*Entry[K, V], error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	curr := sl.findPrev(key, nil)
+	if curr == nil {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return curr.entry(), nil
+}
+
+// Min returns entry with the smallest key
+func (sl *skipList[K, V]) Min() (*Entry[K, V], error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	if sl.head.next[0] == nil {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return sl.head.next[0].entry(), nil
+}
+
+// Max returns entry with the greatest key
+func (sl *skipList[K, V]) Max() (*Entry[K, V], error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	curr := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for curr.next[i] != nil {
+			curr = curr.next[i]
+		}
+	}
+	if curr == sl.head {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return curr.entry(), nil
+}
+
+// Range returns entries with keys in [from, to) in ascending order
+func (sl *skipList[K, V]) Range(from, to K) []Entry[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	entries := make([]Entry[K, V], 0)
+	for curr := sl.findPrev(from, nil); curr != nil && sl.compare(curr.key, to) < 0; curr = curr.next[0] {
+		entries = append(entries, Entry[K, V]{Key: curr.key, Val: curr.val})
+	}
+	return entries
+}
+
+// ForEach calls `fn` for each entry in ascending order of keys
+// until `fn` returns false.
+//
+// Don't modify Skip List inside `fn` - it will cause deadlock
+func (sl *skipList[K, V]) ForEach(fn func(key K, val V) bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	for curr := sl.head.next[0]; curr != nil; curr = curr.next[0] {
+		if !fn(curr.key, curr.val) {
+			return
+		}
+	}
+}
+
+// Keys returns all keys in ascending order
+func (sl *skipList[K, V]) Keys() []K {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	keys := make([]K, 0, sl.size)
+	for curr := sl.head.next[0]; curr != nil; curr = curr.next[0] {
+		keys = append(keys, curr.key)
+	}
+	return keys
+}
+
+// Entries returns all entries in ascending order of keys
+func (sl *skipList[K, V]) Entries() []Entry[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	entries := make([]Entry[K, V], 0, sl.size)
+	for curr := sl.head.next[0]; curr != nil; curr = curr.next[0] {
+		entries = append(entries, Entry[K, V]{Key: curr.key, Val: curr.val})
+	}
+	return entries
+}
+
+// Size returns number of keys
+func (sl *skipList[K, V]) Size() int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.size
+}
+
+// IsEmpty returns true if there are no keys, otherwise false
+func (sl *skipList[K, V]) IsEmpty() bool {
+	return sl.Size() == 0
+}
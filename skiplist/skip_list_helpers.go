@@ -0,0 +1,33 @@
+package skiplist
+
+// findPrev goes from the top level down and returns the first node with key >= `key`
+// (or nil if there is no such node).
+//
+// If `update` != nil -> update[i] is set to the last node on level i with key < `key`.
+// These are nodes whose next ptrs must be changed by Put and Delete
+func (sl *skipList[K, V]) findPrev(key K, update []*skipNode[K, V]) *skipNode[K, V] {
+	curr := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for curr.next[i] != nil && sl.compare(curr.next[i].key, key) < 0 {
+			curr = curr.next[i]
+		}
+		if update != nil {
+			update[i] = curr
+		}
+	}
+	return curr.next[0]
+}
+
+// randomLevel returns level for new node: 1 + number of successful promotions
+// with probability p, but not more than maxLevel
+func (sl *skipList[K, V]) randomLevel() int {
+	lvl := 1
+	for lvl < sl.maxLevel && sl.rnd.Float64() < sl.p {
+		lvl++
+	}
+	return lvl
+}
+
+func (n *skipNode[K, V]) entry() *Entry[K, V] {
+	return &Entry[K, V]{Key: n.key, Val: n.val}
+}
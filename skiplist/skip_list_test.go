@@ -0,0 +1,207 @@
+package skiplist
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intComparator(a, b int) int {
+	if a == b {
+		return 0
+	} else if a < b {
+		return -1
+	} else {
+		return 1
+	}
+}
+
+func newTestSkipList() *skipList[int, string] {
+	return NewSkipListWithConfig[int, string](intComparator, 16, 0.5, rand.NewSource(42))
+}
+
+func TestSkipList_PutGet(t *testing.T) {
+	var sl SkipList[int, string] = newTestSkipList()
+
+	sl.Put(20, "twenty")
+	sl.Put(10, "ten")
+	sl.Put(30, "thirty")
+	sl.Put(10, "TEN")
+
+	assert.Equal(t, 3, sl.Size())
+
+	val, err := sl.Get(10)
+	require.NoError(t, err)
+	assert.Equal(t, "TEN", *val)
+
+	val, err = sl.Get(30)
+	require.NoError(t, err)
+	assert.Equal(t, "thirty", *val)
+
+	val, err = sl.Get(15)
+	assert.Nil(t, val)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+}
+
+func TestSkipList_Delete(t *testing.T) {
+	sl := newTestSkipList()
+	for i := 0; i < 100; i++ {
+		sl.Put(i, "")
+	}
+
+	for i := 0; i < 100; i += 2 {
+		require.NoError(t, sl.Delete(i))
+	}
+	assert.Error(t, sl.Delete(0))
+	assert.Error(t, sl.Delete(1000))
+	assert.Equal(t, 50, sl.Size())
+
+	for i := 0; i < 100; i++ {
+		_, err := sl.Get(i)
+		if i%2 == 0 {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	for i := 1; i < 100; i += 2 {
+		require.NoError(t, sl.Delete(i))
+	}
+	assert.True(t, sl.IsEmpty())
+	assert.Equal(t, 1, sl.level)
+}
+
+func TestSkipList_FloorCeiling(t *testing.T) {
+	sl := newTestSkipList()
+
+	_, err := sl.Floor(10)
+	assert.Error(t, err)
+	_, err = sl.Min()
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+
+	for _, k := range []int{10, 20, 30, 40} {
+		sl.Put(k, "")
+	}
+
+	e, err := sl.Floor(25)
+	require.NoError(t, err)
+	assert.Equal(t, 20, e.Key)
+
+	e, err = sl.Floor(30)
+	require.NoError(t, err)
+	assert.Equal(t, 30, e.Key)
+
+	_, err = sl.Floor(9)
+	assert.Error(t, err)
+
+	e, err = sl.Ceiling(25)
+	require.NoError(t, err)
+	assert.Equal(t, 30, e.Key)
+
+	e, err = sl.Ceiling(10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, e.Key)
+
+	_, err = sl.Ceiling(41)
+	assert.Error(t, err)
+
+	e, err = sl.Min()
+	require.NoError(t, err)
+	assert.Equal(t, 10, e.Key)
+
+	e, err = sl.Max()
+	require.NoError(t, err)
+	assert.Equal(t, 40, e.Key)
+}
+
+func TestSkipList_RangeAndIteration(t *testing.T) {
+	sl := newTestSkipList()
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		sl.Put(k, "")
+	}
+
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, sl.Keys())
+	assert.Equal(t, []Entry[int, string]{{20, ""}, {30, ""}}, sl.Range(15, 40))
+	assert.Empty(t, sl.Range(60, 100))
+	assert.Len(t, sl.Entries(), 5)
+
+	var visited []int
+	sl.ForEach(func(key int, _ string) bool {
+		visited = append(visited, key)
+		return key < 30
+	})
+	assert.Equal(t, []int{10, 20, 30}, visited)
+}
+
+func TestSkipList_Deterministic(t *testing.T) {
+	a := NewSkipListWithConfig[int, int](intComparator, 8, 0.5, rand.NewSource(7))
+	b := NewSkipListWithConfig[int, int](intComparator, 8, 0.5, rand.NewSource(7))
+	for i := 0; i < 1000; i++ {
+		a.Put(i, i)
+		b.Put(i, i)
+	}
+
+	// same seed -> same tower heights
+	for na, nb := a.head.next[0], b.head.next[0]; na != nil; na, nb = na.next[0], nb.next[0] {
+		require.Equal(t, len(na.next), len(nb.next))
+		require.LessOrEqual(t, len(na.next), 8)
+	}
+}
+
+func TestSkipList_Random(t *testing.T) {
+	sl := NewSkipListWithConfig[int, int](intComparator, 12, 0.5, rand.NewSource(1))
+	rnd := rand.New(rand.NewSource(2))
+	ref := make(map[int]int)
+
+	for i := 0; i < 20000; i++ {
+		k := rnd.Intn(2000)
+		if rnd.Intn(3) == 0 {
+			_, exists := ref[k]
+			err := sl.Delete(k)
+			assert.Equal(t, exists, err == nil)
+			delete(ref, k)
+		} else {
+			sl.Put(k, i)
+			ref[k] = i
+		}
+	}
+
+	keys := make([]int, 0, len(ref))
+	for k := range ref {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	assert.Equal(t, keys, sl.Keys())
+	for k, v := range ref {
+		val, err := sl.Get(k)
+		require.NoError(t, err)
+		assert.Equal(t, v, *val)
+	}
+}
+
+func BenchmarkSkipList_Put(b *testing.B) {
+	sl := NewSkipList[int, int](intComparator)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		sl.Put(rnd.Int(), i)
+	}
+}
+
+func BenchmarkSkipList_Get(b *testing.B) {
+	n := 1 << 16
+	sl := NewSkipList[int, int](intComparator)
+	for i := 0; i < n; i++ {
+		sl.Put(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = sl.Get(i % n)
+	}
+}
@@ -0,0 +1,74 @@
+package skiplist
+
+// If a == b -> return 0
+//
+// If a > b -> return 1
+//
+// If a < b -> return -1
+type Comparator[K any] func(a, b K) int
+
+// Entry is a key-value pair stored in SkipList
+type Entry[K any, V any] struct {
+	Key K
+	Val V
+}
+
+// SkipList is the interface of ordered map on skip list:
+//
+//	var sl skiplist.SkipList[int, string]
+//
+//	sl = skiplist.NewSkipList[int, string](compare)
+//	sl.Put(10, "ten")
+//	sl.Put(20, "twenty")
+//
+//	val, err := sl.Get(10) // *val == "ten"
+//	e, err := sl.Floor(15) // e.Key == 10
+//	e, err = sl.Ceiling(15) // e.Key == 20
+//	sl.Range(0, 100) // [{10 ten} {20 twenty}]
+type SkipList[K any, V any] interface {
+	// Put adds `key` with `val`.
+	//
+	// If key already exists -> replaces its val
+	Put(key K, val V)
+
+	// Get returns val by `key`
+	//
+	// if there is no key -> val = nil, err != nil
+	Get(key K) (*V, error)
+
+	// Delete deletes `key`
+	//
+	// if there is no key -> returns err
+	Delete(key K) error
+
+	// Floor returns entry with the greatest key <= `key`
+	Floor(key K) (*Entry[K, V], error)
+
+	// Ceiling returns entry with the smallest key >= `key`
+	Ceiling(key K) (*Entry[K, V], error)
+
+	// Min returns entry with the smallest key
+	Min() (*Entry[K, V], error)
+
+	// Max returns entry with the greatest key
+	Max() (*Entry[K, V], error)
+
+	// Range returns entries with keys in [from, to) in ascending order
+	Range(from, to K) []Entry[K, V]
+
+	// ForEach calls `fn` for each entry in ascending order of keys
+	// until `fn` returns false
+	ForEach(fn func(key K, val V) bool)
+
+	// Keys returns all keys in ascending order
+	Keys() []K
+
+	// Entries returns all entries in ascending order of keys
+	Entries() []Entry[K, V]
+
+	// Size returns number of keys
+	Size() int
+
+	// IsEmpty returns true if there are no keys, otherwise false
+	IsEmpty() bool
+}
@@ -0,0 +1,175 @@
+package trees
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// MatchKind is the way AhoCorasick reports matches which overlap
+type MatchKind int
+
+const (
+	// MatchOverlapping reports all matches, also overlapping and nested ones,
+	// ordered by end, then longer first
+	MatchOverlapping MatchKind = iota
+
+	// MatchLeftmostLongest reports matches which don't overlap:
+	// from matches which start first it takes the longest one,
+	// then continues search after its end (like regexp with leftmost-longest semantics)
+	MatchLeftmostLongest
+)
+
+// PatternMatch is the match of pattern in text
+//
+// # Pattern 	-> index of pattern in patterns given to constructor
+//
+// # Start, End 	-> byte offsets of match in text: text[Start:End]
+type PatternMatch struct {
+	Pattern int
+	Start   int
+	End     int
+}
+
+// AhoCorasick is the interface of automaton which finds many patterns in text at once
+// in O(len(text) + number of matches), whatever the number of patterns is.
+//
+// Automaton is not changed by search, so it is safe for concurrent use.
+//
+//	ac, err := trees.NewAhoCorasick([]string{"error", "timeout", "out"})
+//	matches := ac.FindAll("request timeout: error") // timeout, out, error
+//
+//	err = ac.FindReader(file, func(m trees.PatternMatch) bool {
+//		return true // continue
+//	})
+type AhoCorasick interface {
+	// FindAll returns matches of patterns in `text`
+	FindAll(text string) []PatternMatch
+
+	// FindReader reads `r` till EOF and calls `fn` for matches until `fn` returns false.
+	// Offsets are counted from the beginning of stream. Memory doesn't depend on
+	// stream length: only the last runes (not longer than the longest pattern) are kept
+	//
+	// if reading fails -> returns err
+	FindReader(r io.Reader, fn func(m PatternMatch) bool) error
+
+	// Contains returns true if `text` has any pattern
+	Contains(text string) bool
+
+	// Size returns number of patterns
+	Size() int
+}
+
+// acNode - state of automaton, it is the node of Trie of patterns.
+//
+// # fail 	-> node of the longest proper suffix of this node which is in Trie
+//
+// # dict 	-> the nearest node by fail links which is the end of pattern
+//
+// # depth 	-> length of node in runes
+//
+// # pattern 	-> index of pattern which ends in this node or -1
+type acNode struct {
+	children map[rune]*acNode
+	fail     *acNode
+	dict     *acNode
+
+	depth   int
+	pattern int
+}
+
+// ahoCorasick - Aho-Corasick automaton over runes.
+// If caseInsensitive, patterns and text are compared by case folded runes
+type ahoCorasick struct {
+	root *acNode
+	size int
+
+	kind            MatchKind
+	caseInsensitive bool
+}
+
+// NewAhoCorasick creates automaton for `patterns` which reports all overlapping matches
+// and is case sensitive.
+// Duplicate patterns are reported by the first index
+//
+// if there are no patterns or any pattern is empty -> returns err
+func NewAhoCorasick(patterns []string) (*ahoCorasick, error) {
+	return NewAhoCorasickWithConfig(patterns, MatchOverlapping, false)
+}
+
+// NewAhoCorasickWithConfig creates automaton for `patterns` with match `kind`.
+// If caseInsensitive, "Error" and "ERROR" match pattern "error"
+func NewAhoCorasickWithConfig(patterns []string, kind MatchKind, caseInsensitive bool) (*ahoCorasick, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	ac := &ahoCorasick{
+		root:            newACNode(0),
+		size:            len(patterns),
+		kind:            kind,
+		caseInsensitive: caseInsensitive,
+	}
+	for i, p := range patterns {
+		if p == "" {
+			return nil, fmt.Errorf(gocollections.ErrInvalidData)
+		}
+		ac.insert(p, i)
+	}
+	ac.buildLinks()
+	return ac, nil
+}
+
+// FindAll returns matches of patterns in `text`
+func (ac *ahoCorasick) FindAll(text string) []PatternMatch {
+	var res []PatternMatch
+	s := ac.newScanner(func(m PatternMatch) bool {
+		res = append(res, m)
+		return true
+	})
+	for off := 0; off < len(text) && !s.stopped; {
+		r, size := utf8.DecodeRuneInString(text[off:])
+		s.feed(r, off, size)
+		off += size
+	}
+	s.flush()
+	return res
+}
+
+// FindReader reads `r` till EOF and calls `fn` for matches until `fn` returns false
+func (ac *ahoCorasick) FindReader(r io.Reader, fn func(m PatternMatch) bool) error {
+	br := bufio.NewReader(r)
+	s := ac.newScanner(fn)
+	for off := 0; !s.stopped; {
+		ch, size, err := br.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.feed(ch, off, size)
+		off += size
+	}
+	s.flush()
+	return nil
+}
+
+// Contains returns true if `text` has any pattern
+func (ac *ahoCorasick) Contains(text string) bool {
+	state := ac.root
+	for _, r := range text {
+		state = ac.next(state, ac.fold(r))
+		if state.pattern >= 0 || state.dict != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns number of patterns
+func (ac *ahoCorasick) Size() int {
+	return ac.size
+}
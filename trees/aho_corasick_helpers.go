@@ -0,0 +1,212 @@
+package trees
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/0x0FACED/go-collections/queue"
+)
+
+func newACNode(depth int) *acNode {
+	return &acNode{children: make(map[rune]*acNode), depth: depth, pattern: -1}
+}
+
+// insert adds pattern to Trie of automaton, like trie.Insert does
+func (ac *ahoCorasick) insert(pattern string, index int) {
+	dummy := ac.root
+	for _, ch := range pattern {
+		ch = ac.fold(ch)
+		if _, exists := dummy.children[ch]; !exists {
+			dummy.children[ch] = newACNode(dummy.depth + 1)
+		}
+		dummy = dummy.children[ch]
+	}
+	if dummy.pattern == -1 {
+		dummy.pattern = index
+	}
+}
+
+// buildLinks sets fail and dict links by bfs: links of node
+// point to less deep nodes, so they are already set
+func (ac *ahoCorasick) buildLinks() {
+	q := queue.NewDynamicListQueue[*acNode]()
+	ac.root.fail = ac.root
+	for _, child := range ac.root.children {
+		child.fail = ac.root
+		q.Enqueue(child)
+	}
+
+	for !q.IsEmpty() {
+		item, _ := q.Dequeue()
+		node := *item
+		for ch, child := range node.children {
+			fail := node.fail
+			for fail != ac.root && fail.children[ch] == nil {
+				fail = fail.fail
+			}
+			if next, ok := fail.children[ch]; ok {
+				child.fail = next
+			} else {
+				child.fail = ac.root
+			}
+
+			if child.fail.pattern >= 0 {
+				child.dict = child.fail
+			} else {
+				child.dict = child.fail.dict
+			}
+			q.Enqueue(child)
+		}
+	}
+}
+
+// next returns state after `ch`
+func (ac *ahoCorasick) next(state *acNode, ch rune) *acNode {
+	for {
+		if next, ok := state.children[ch]; ok {
+			return next
+		}
+		if state == ac.root {
+			return ac.root
+		}
+		state = state.fail
+	}
+}
+
+// fold returns the smallest rune which is equal to `ch` ignoring case
+func (ac *ahoCorasick) fold(ch rune) rune {
+	if !ac.caseInsensitive {
+		return ch
+	}
+	// fast path: for ASCII letter the smallest is upper case ('K' < 'k' < Kelvin sign)
+	if ch < utf8.RuneSelf {
+		if 'a' <= ch && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		return ch
+	}
+	res := ch
+	for f := unicode.SimpleFold(ch); f != ch; f = unicode.SimpleFold(f) {
+		res = min(res, f)
+	}
+	return res
+}
+
+// acRune is the rune of text and its position in bytes
+type acRune struct {
+	ch   rune
+	off  int
+	size int
+}
+
+// acScanner runs automaton over runes given one by one.
+//
+// # buf 	-> last runes of text, buf[0] has index `base`. They are needed to find
+// byte offset of match start and to search again after leftmost-longest match
+//
+// # cursor 	-> index of the next rune for automaton
+//
+// # best 	-> leftmost-longest match which can't be reported yet:
+// a match which starts earlier may still be found
+type acScanner struct {
+	ac    *ahoCorasick
+	state *acNode
+
+	buf    []acRune
+	base   int
+	cursor int
+
+	best      PatternMatch
+	bestEnd   int
+	bestStart int
+	hasBest   bool
+	emit      func(m PatternMatch) bool
+	stopped   bool
+}
+
+func (ac *ahoCorasick) newScanner(emit func(m PatternMatch) bool) *acScanner {
+	return &acScanner{ac: ac, state: ac.root, emit: emit}
+}
+
+// feed adds the next rune of text
+func (s *acScanner) feed(ch rune, off, size int) {
+	s.buf = append(s.buf, acRune{ch: s.ac.fold(ch), off: off, size: size})
+	s.run()
+}
+
+// flush is called at the end of text: pending match can't be beaten anymore
+func (s *acScanner) flush() {
+	for s.hasBest && !s.stopped {
+		s.report()
+		s.run()
+	}
+}
+
+// run passes runes from cursor to the end of buf through automaton
+func (s *acScanner) run() {
+	for !s.stopped && s.cursor < s.base+len(s.buf) {
+		i := s.cursor
+		s.cursor++
+		s.state = s.ac.next(s.state, s.buf[i-s.base].ch)
+
+		if s.ac.kind == MatchOverlapping {
+			for node := s.output(); node != nil && !s.stopped; node = node.dict {
+				s.stopped = !s.emit(s.match(node, i))
+			}
+			continue
+		}
+
+		// the first output is the longest, so it starts first of matches ending at i
+		if node := s.output(); node != nil {
+			start := i + 1 - node.depth
+			if !s.hasBest || start < s.bestStart || (start == s.bestStart && i+1 > s.bestEnd) {
+				s.best = s.match(node, i)
+				s.bestStart, s.bestEnd = start, i+1
+				s.hasBest = true
+			}
+		}
+		// all next matches start after the beginning of current state
+		if s.hasBest && s.cursor-s.state.depth > s.bestStart {
+			s.report()
+		}
+	}
+	s.trim()
+}
+
+// report emits best match and starts search again after its end
+func (s *acScanner) report() {
+	s.stopped = !s.emit(s.best)
+	s.hasBest = false
+	s.state = s.ac.root
+	s.cursor = s.bestEnd
+}
+
+// output returns the first node which is the end of pattern
+// among current state and its fail links
+func (s *acScanner) output() *acNode {
+	if s.state.pattern >= 0 {
+		return s.state
+	}
+	return s.state.dict
+}
+
+// match returns match of pattern of `node` which ends at rune `i`
+func (s *acScanner) match(node *acNode, i int) PatternMatch {
+	first, last := s.buf[i+1-node.depth-s.base], s.buf[i-s.base]
+	return PatternMatch{Pattern: node.pattern, Start: first.off, End: last.off + last.size}
+}
+
+// trim forgets runes which are not needed: before the beginning of current state
+// and before the end of pending match
+func (s *acScanner) trim() {
+	keep := s.cursor - s.state.depth
+	if s.hasBest {
+		keep = min(keep, s.bestEnd)
+	}
+	if keep > s.base {
+		// move to the beginning, so buf doesn't grow: it has at most len of pattern runes
+		n := copy(s.buf, s.buf[keep-s.base:])
+		s.buf = s.buf[:n]
+		s.base = keep
+	}
+}
@@ -0,0 +1,206 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAhoCorasick_FindAll(t *testing.T) {
+	ac, err := NewAhoCorasick([]string{"he", "she", "his", "hers"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, ac.Size())
+
+	text := "ushers"
+	assert.Equal(t, []PatternMatch{
+		{Pattern: 1, Start: 1, End: 4}, // she
+		{Pattern: 0, Start: 2, End: 4}, // he
+		{Pattern: 3, Start: 2, End: 6}, // hers
+	}, ac.FindAll(text))
+	assert.Empty(t, ac.FindAll("xyz"))
+	assert.True(t, ac.Contains("this"))
+	assert.False(t, ac.Contains("hi"))
+
+	_, err = NewAhoCorasick(nil)
+	assert.EqualError(t, err, gocollections.ErrEmpty)
+	_, err = NewAhoCorasick([]string{"a", ""})
+	assert.EqualError(t, err, gocollections.ErrInvalidData)
+}
+
+func TestAhoCorasick_LeftmostLongest(t *testing.T) {
+	ac, err := NewAhoCorasickWithConfig([]string{"he", "she", "his", "hers", "us"}, MatchLeftmostLongest, false)
+	require.NoError(t, err)
+
+	// "us" starts first, then search continues from "hers"
+	assert.Equal(t, []PatternMatch{
+		{Pattern: 4, Start: 0, End: 2},
+		{Pattern: 3, Start: 2, End: 6},
+	}, ac.FindAll("ushers"))
+
+	ac, err = NewAhoCorasickWithConfig([]string{"abcd", "b", "bcdef", "cde"}, MatchLeftmostLongest, false)
+	require.NoError(t, err)
+	// "b" and "cde" end before "abcd" is found, but "abcd" starts first
+	assert.Equal(t, []PatternMatch{{Pattern: 0, Start: 0, End: 4}}, ac.FindAll("abcdef"))
+	assert.Equal(t, []PatternMatch{{Pattern: 2, Start: 1, End: 6}}, ac.FindAll("xbcdef"))
+}
+
+func TestAhoCorasick_CaseInsensitive(t *testing.T) {
+	ac, err := NewAhoCorasickWithConfig([]string{"error", "Привет", "k"}, MatchOverlapping, true)
+	require.NoError(t, err)
+
+	text := "ERROR: привет, Error K"
+	var found []string
+	for _, m := range ac.FindAll(text) {
+		found = append(found, text[m.Start:m.End])
+	}
+	// Kelvin sign is 3 bytes, offsets are in bytes of text
+	assert.Equal(t, []string{"ERROR", "привет", "Error", "K"}, found)
+
+	sensitive, err := NewAhoCorasick([]string{"error"})
+	require.NoError(t, err)
+	assert.Empty(t, sensitive.FindAll("ERROR"))
+}
+
+func TestAhoCorasick_FindReader(t *testing.T) {
+	ac, err := NewAhoCorasickWithConfig([]string{"timeout", "out", "error", "über"}, MatchLeftmostLongest, false)
+	require.NoError(t, err)
+
+	text := strings.Repeat("request timeout, über error; ", 100)
+	var streamed []PatternMatch
+	err = ac.FindReader(iotest.OneByteReader(strings.NewReader(text)), func(m PatternMatch) bool {
+		streamed = append(streamed, m)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ac.FindAll(text), streamed)
+	assert.Len(t, streamed, 300)
+
+	// stop after 2 matches
+	count := 0
+	err = ac.FindReader(strings.NewReader(text), func(m PatternMatch) bool {
+		count++
+		return count < 2
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	readErr := errors.New("read failed")
+	err = ac.FindReader(iotest.ErrReader(readErr), func(PatternMatch) bool { return true })
+	assert.ErrorIs(t, err, readErr)
+}
+
+func TestAhoCorasick_BufferIsBounded(t *testing.T) {
+	for _, kind := range []MatchKind{MatchOverlapping, MatchLeftmostLongest} {
+		ac, err := NewAhoCorasickWithConfig([]string{"aaaa", "ab", "b"}, kind, false)
+		require.NoError(t, err)
+
+		maxBuf := 0
+		s := ac.newScanner(func(PatternMatch) bool { return true })
+		rnd := rand.New(rand.NewSource(1))
+		for i := 0; i < 100000; i++ {
+			s.feed(rune("abc"[rnd.Intn(3)]), i, 1)
+			maxBuf = max(maxBuf, len(s.buf))
+		}
+		assert.LessOrEqual(t, maxBuf, 5)
+	}
+}
+
+func TestAhoCorasick_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randString := func(alphabet string, n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+
+	for iter := 0; iter < 200; iter++ {
+		patterns := make([]string, 1+rnd.Intn(10))
+		for i := range patterns {
+			patterns[i] = randString("abc", 1+rnd.Intn(4))
+		}
+		text := randString("abcABC", rnd.Intn(40))
+		caseInsensitive := rnd.Intn(2) == 0
+		folded := text
+		if caseInsensitive {
+			folded = strings.ToLower(text)
+		}
+
+		overlapping, err := NewAhoCorasickWithConfig(patterns, MatchOverlapping, caseInsensitive)
+		require.NoError(t, err)
+		leftmost, err := NewAhoCorasickWithConfig(patterns, MatchLeftmostLongest, caseInsensitive)
+		require.NoError(t, err)
+
+		msg := fmt.Sprintf("patterns %q, text %q, case insensitive %v", patterns, text, caseInsensitive)
+		require.Equal(t, bruteOverlapping(patterns, folded), overlapping.FindAll(text), msg)
+		require.Equal(t, bruteLeftmostLongest(patterns, folded), leftmost.FindAll(text), msg)
+		require.Equal(t, len(bruteOverlapping(patterns, folded)) > 0, overlapping.Contains(text), msg)
+	}
+}
+
+// bruteOverlapping returns all matches ordered by end, then longer first.
+// Duplicate pattern is reported by the first index
+func bruteOverlapping(patterns []string, text string) []PatternMatch {
+	var res []PatternMatch
+	for end := 1; end <= len(text); end++ {
+		var atEnd []PatternMatch
+		for i, p := range patterns {
+			if slices.Index(patterns, p) == i && strings.HasSuffix(text[:end], p) {
+				atEnd = append(atEnd, PatternMatch{Pattern: i, Start: end - len(p), End: end})
+			}
+		}
+		slices.SortFunc(atEnd, func(a, b PatternMatch) int { return a.Start - b.Start })
+		res = append(res, atEnd...)
+	}
+	return res
+}
+
+// bruteLeftmostLongest takes the longest pattern at the first position where any matches
+func bruteLeftmostLongest(patterns []string, text string) []PatternMatch {
+	var res []PatternMatch
+	for pos := 0; pos < len(text); {
+		best := -1
+		for i, p := range patterns {
+			if strings.HasPrefix(text[pos:], p) && (best == -1 || len(p) > len(patterns[best])) {
+				best = i
+			}
+		}
+		if best == -1 {
+			pos++
+			continue
+		}
+		res = append(res, PatternMatch{Pattern: best, Start: pos, End: pos + len(patterns[best])})
+		pos += len(patterns[best])
+	}
+	return res
+}
+
+func BenchmarkAhoCorasick_FindAll(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	patterns := make([]string, 5000)
+	for i := range patterns {
+		patterns[i] = fmt.Sprintf("keyword%d", rnd.Intn(1000000))
+	}
+	ac, _ := NewAhoCorasickWithConfig(patterns, MatchLeftmostLongest, true)
+
+	var sb strings.Builder
+	for sb.Len() < 1<<20 {
+		fmt.Fprintf(&sb, "2024-01-01 INFO request id=%d keyword%d done\n", rnd.Int(), rnd.Intn(1000000))
+	}
+	text := sb.String()
+
+	b.SetBytes(int64(len(text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ac.FindAll(text)
+	}
+}
@@ -0,0 +1,190 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// bPlusTree - B+Tree.
+//
+// Unlike B-Tree all items are stored in leaves, internal nodes keep only
+// separators to route search. Leaves are linked, so range scan
+// finds the first leaf and then just goes by `next` ptrs.
+//
+// Every node except root has from t-1 to 2t-1 items (t is minimum degree).
+// Separator keys[i] of internal node: all items of children[i] are < keys[i],
+// all items of children[i+1] are >= keys[i]
+type bPlusTree[T comparable] struct {
+	root *bplus_node[T]
+	size int
+	t    int
+
+	mu sync.Mutex
+
+	compare Comparator[T]
+}
+
+// bplus_node is the node of B+Tree.
+//
+// # keys 	-> items of leaf or separators of internal node
+//
+// # children 	-> nil for leaf, otherwise len(keys)+1 children
+//
+// # next 	-> next leaf, nil for internal nodes and the last leaf
+type bplus_node[T comparable] struct {
+	keys     []T
+	children []*bplus_node[T]
+	next     *bplus_node[T]
+}
+
+// NewBPlusTree creates B+Tree with default minimum degree
+func NewBPlusTree[T comparable](compare Comparator[T]) *bPlusTree[T] {
+	return NewBPlusTreeWithDegree(defaultBTreeDegree, compare)
+}
+
+// NewBPlusTreeWithDegree creates B+Tree with minimum degree `t` (>= 2):
+// node has at most 2t-1 keys
+func NewBPlusTreeWithDegree[T comparable](t int, compare Comparator[T]) *bPlusTree[T] {
+	return &bPlusTree[T]{root: &bplus_node[T]{}, t: max(t, 2), compare: compare}
+}
+
+// NewBPlusTreeFromSorted builds B+Tree from `items` sorted in ascending order in O(n).
+//
+// if items are not sorted or have duplicates -> returns err
+func NewBPlusTreeFromSorted[T comparable](items []T, t int, compare Comparator[T]) (*bPlusTree[T], error) {
+	if !strictlySorted(items, compare) {
+		return nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	bp := NewBPlusTreeWithDegree(t, compare)
+	if len(items) > 0 {
+		bp.root = bp.build(items)
+	}
+	bp.size = len(items)
+	return bp, nil
+}
+
+func (bp *bPlusTree[T]) Insert(item T) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	sep, right, added := bp.insertHelper(bp.root, item)
+	if right != nil {
+		bp.root = &bplus_node[T]{
+			keys:     []T{sep},
+			children: []*bplus_node[T]{bp.root, right},
+		}
+	}
+	if added {
+		bp.size++
+	}
+}
+
+func (bp *bPlusTree[T]) Delete(item T) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if !bp.deleteHelper(bp.root, item) {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	if len(bp.root.keys) == 0 && bp.root.children != nil {
+		bp.root = bp.root.children[0]
+	}
+	bp.size--
+	return nil
+}
+
+func (bp *bPlusTree[T]) Search(item T) (*T, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	leaf := bp.findLeaf(item)
+	if i, found := bp.find(leaf, item); found {
+		val := leaf.keys[i]
+		return &val, nil
+	}
+	return nil, fmt.Errorf(gocollections.ErrNotFound)
+}
+
+// Range returns items in [from, to) in ascending order
+func (bp *bPlusTree[T]) Range(from, to T) []T {
+	var items []T
+	bp.RangeFunc(from, to, func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// RangeFunc calls `fn` for items in [from, to) in ascending order
+// until `fn` returns false
+func (bp *bPlusTree[T]) RangeFunc(from, to T, fn func(item T) bool) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	leaf := bp.findLeaf(from)
+	i, _ := bp.find(leaf, from)
+	bp.scan(leaf, i, &to, fn)
+}
+
+// ForEach calls `fn` for each item in ascending order until `fn` returns false
+func (bp *bPlusTree[T]) ForEach(fn func(item T) bool) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	bp.scan(bp.firstLeaf(), 0, nil, fn)
+}
+
+// Min returns the smallest item
+func (bp *bPlusTree[T]) Min() (*T, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if bp.size == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	val := bp.firstLeaf().keys[0]
+	return &val, nil
+}
+
+// Max returns the greatest item
+func (bp *bPlusTree[T]) Max() (*T, error) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	if bp.size == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	curr := bp.root
+	for curr.children != nil {
+		curr = curr.children[len(curr.children)-1]
+	}
+	val := curr.keys[len(curr.keys)-1]
+	return &val, nil
+}
+
+// Size returns number of items
+func (bp *bPlusTree[T]) Size() int {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	return bp.size
+}
+
+// IsEmpty returns true if there are no items
+func (bp *bPlusTree[T]) IsEmpty() bool {
+	return bp.Size() == 0
+}
+
+// Height returns number of levels
+func (bp *bPlusTree[T]) Height() int {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	h := 1
+	for curr := bp.root; curr.children != nil; curr = curr.children[0] {
+		h++
+	}
+	return h
+}
@@ -0,0 +1,230 @@
+package trees
+
+import "slices"
+
+// find returns index of `item` in keys of node (or index where it would be)
+func (bp *bPlusTree[T]) find(node *bplus_node[T], item T) (int, bool) {
+	return slices.BinarySearchFunc(node.keys, item, bp.compare)
+}
+
+// childIndex returns index of child of internal node where `item` may be
+func (bp *bPlusTree[T]) childIndex(node *bplus_node[T], item T) int {
+	i, found := bp.find(node, item)
+	if found {
+		// separator == item -> item is in the right child
+		i++
+	}
+	return i
+}
+
+// findLeaf returns leaf where `item` is or would be
+func (bp *bPlusTree[T]) findLeaf(item T) *bplus_node[T] {
+	curr := bp.root
+	for curr.children != nil {
+		curr = curr.children[bp.childIndex(curr, item)]
+	}
+	return curr
+}
+
+func (bp *bPlusTree[T]) firstLeaf() *bplus_node[T] {
+	curr := bp.root
+	for curr.children != nil {
+		curr = curr.children[0]
+	}
+	return curr
+}
+
+// scan goes by leaves from keys[i] of `leaf` until `to` (nil - no bound)
+func (bp *bPlusTree[T]) scan(leaf *bplus_node[T], i int, to *T, fn func(item T) bool) {
+	for ; leaf != nil; leaf, i = leaf.next, 0 {
+		for ; i < len(leaf.keys); i++ {
+			if to != nil && bp.compare(leaf.keys[i], *to) >= 0 {
+				return
+			}
+			if !fn(leaf.keys[i]) {
+				return
+			}
+		}
+	}
+}
+
+// insertHelper inserts item to subtree. If node overflows, it is split
+// and separator with the new right node are returned to be inserted into the parent
+func (bp *bPlusTree[T]) insertHelper(node *bplus_node[T], item T) (sep T, right *bplus_node[T], added bool) {
+	if node.children == nil {
+		i, found := bp.find(node, item)
+		if found {
+			node.keys[i] = item
+			return sep, nil, false
+		}
+		node.keys = slices.Insert(node.keys, i, item)
+		if len(node.keys) < 2*bp.t {
+			return sep, nil, true
+		}
+		sep, right = bp.splitLeaf(node)
+		return sep, right, true
+	}
+
+	i := bp.childIndex(node, item)
+	childSep, childRight, added := bp.insertHelper(node.children[i], item)
+	if childRight == nil {
+		return sep, nil, added
+	}
+	node.keys = slices.Insert(node.keys, i, childSep)
+	node.children = slices.Insert(node.children, i+1, childRight)
+	if len(node.keys) < 2*bp.t {
+		return sep, nil, added
+	}
+	sep, right = bp.splitInternal(node)
+	return sep, right, added
+}
+
+// splitLeaf divides leaf with 2t items into 2 leaves with t items,
+// the first item of right leaf is copied to parent as separator
+func (bp *bPlusTree[T]) splitLeaf(node *bplus_node[T]) (T, *bplus_node[T]) {
+	right := &bplus_node[T]{keys: slices.Clone(node.keys[bp.t:]), next: node.next}
+	node.keys = slices.Clip(node.keys[:bp.t])
+	node.next = right
+	return right.keys[0], right
+}
+
+// splitInternal divides internal node with 2t keys into node with t keys,
+// separator which goes up and node with t-1 keys
+func (bp *bPlusTree[T]) splitInternal(node *bplus_node[T]) (T, *bplus_node[T]) {
+	t := bp.t
+	sep := node.keys[t]
+	right := &bplus_node[T]{
+		keys:     slices.Clone(node.keys[t+1:]),
+		children: slices.Clone(node.children[t+1:]),
+	}
+	node.keys = slices.Clip(node.keys[:t])
+	node.children = slices.Clip(node.children[:t+1])
+	return sep, right
+}
+
+// deleteHelper deletes item from subtree and returns true if item was found.
+//
+// Separators are not updated when item is deleted: separator which is not
+// in the tree anymore still routes search correctly
+func (bp *bPlusTree[T]) deleteHelper(node *bplus_node[T], item T) bool {
+	if node.children == nil {
+		i, found := bp.find(node, item)
+		if found {
+			node.keys = slices.Delete(node.keys, i, i+1)
+		}
+		return found
+	}
+
+	i := bp.childIndex(node, item)
+	if !bp.deleteHelper(node.children[i], item) {
+		return false
+	}
+	bp.fixChild(node, i)
+	return true
+}
+
+// fixChild makes child `i` of node have at least t-1 keys
+// by borrowing from sibling or merging with it
+func (bp *bPlusTree[T]) fixChild(node *bplus_node[T], i int) {
+	child := node.children[i]
+	if len(child.keys) >= bp.t-1 {
+		return
+	}
+	leaf := child.children == nil
+
+	if i > 0 && len(node.children[i-1].keys) > bp.t-1 {
+		left := node.children[i-1]
+		last := left.keys[len(left.keys)-1]
+		left.keys = left.keys[:len(left.keys)-1]
+		if leaf {
+			// item moves to child, separator is the new first item of child
+			child.keys = slices.Insert(child.keys, 0, last)
+			node.keys[i-1] = last
+		} else {
+			// rotation through parent like in B-Tree
+			child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+			node.keys[i-1] = last
+			child.children = slices.Insert(child.children, 0, left.children[len(left.children)-1])
+			left.children = left.children[:len(left.children)-1]
+		}
+		return
+	}
+
+	if i < len(node.children)-1 && len(node.children[i+1].keys) > bp.t-1 {
+		right := node.children[i+1]
+		first := right.keys[0]
+		right.keys = slices.Delete(right.keys, 0, 1)
+		if leaf {
+			child.keys = append(child.keys, first)
+			node.keys[i] = right.keys[0]
+		} else {
+			child.keys = append(child.keys, node.keys[i])
+			node.keys[i] = first
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return
+	}
+
+	if i == len(node.children)-1 {
+		i--
+	}
+	left, right := node.children[i], node.children[i+1]
+	if leaf {
+		// separator is not needed: leaves keep all items
+		left.keys = append(left.keys, right.keys...)
+		left.next = right.next
+	} else {
+		left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// build makes tree from sorted items: items are divided into linked leaves,
+// then nodes of each level are grouped into parents
+func (bp *bPlusTree[T]) build(items []T) *bplus_node[T] {
+	maxKeys := 2*bp.t - 1
+
+	// leaves: from t-1 to 2t-1 items
+	k := (len(items) + maxKeys - 1) / maxKeys
+	nodes := make([]*bplus_node[T], k)
+	mins := make([]T, k)
+	pos := 0
+	for j := range nodes {
+		cnt := len(items) / k
+		if j < len(items)%k {
+			cnt++
+		}
+		nodes[j] = &bplus_node[T]{keys: slices.Clone(items[pos : pos+cnt])}
+		mins[j] = items[pos]
+		if j > 0 {
+			nodes[j-1].next = nodes[j]
+		}
+		pos += cnt
+	}
+
+	// internal levels: from t to 2t children,
+	// separator before child is the min item of child's subtree
+	for len(nodes) > 1 {
+		k = (len(nodes) + 2*bp.t - 1) / (2 * bp.t)
+		parents := make([]*bplus_node[T], k)
+		parentMins := make([]T, k)
+		pos = 0
+		for j := range parents {
+			cnt := len(nodes) / k
+			if j < len(nodes)%k {
+				cnt++
+			}
+			parents[j] = &bplus_node[T]{
+				keys:     slices.Clone(mins[pos+1 : pos+cnt]),
+				children: slices.Clone(nodes[pos : pos+cnt]),
+			}
+			parentMins[j] = mins[pos]
+			pos += cnt
+		}
+		nodes, mins = parents, parentMins
+	}
+	return nodes[0]
+}
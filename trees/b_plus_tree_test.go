@@ -0,0 +1,186 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBPlusTree_Basic(t *testing.T) {
+	var tr OrderedTree[int] = NewBPlusTreeWithDegree(2, intComparator)
+
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 10, 90, 35} {
+		tr.Insert(v)
+	}
+	assert.Equal(t, 10, tr.Size())
+
+	val, err := tr.Search(35)
+	require.NoError(t, err)
+	assert.Equal(t, 35, *val)
+
+	assert.Equal(t, []int{30, 35, 40, 50}, tr.Range(30, 60))
+	assert.Equal(t, []int{90}, tr.Range(85, 1000))
+	assert.Empty(t, tr.Range(41, 49))
+
+	minVal, _ := tr.Min()
+	maxVal, _ := tr.Max()
+	assert.Equal(t, 10, *minVal)
+	assert.Equal(t, 90, *maxVal)
+
+	require.NoError(t, tr.Delete(50))
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), tr.Delete(50))
+	_, err = tr.Search(50)
+	assert.Error(t, err)
+
+	var scanned []int
+	tr.RangeFunc(20, 100, func(item int) bool {
+		scanned = append(scanned, item)
+		return item < 40
+	})
+	assert.Equal(t, []int{20, 30, 35, 40}, scanned)
+}
+
+func TestBPlusTree_Empty(t *testing.T) {
+	tr := NewBPlusTree(intComparator)
+	assert.True(t, tr.IsEmpty())
+	_, err := tr.Min()
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+	_, err = tr.Max()
+	assert.Error(t, err)
+	_, err = tr.Search(1)
+	assert.Error(t, err)
+	assert.Error(t, tr.Delete(1))
+	assert.Empty(t, tr.Range(0, 100))
+}
+
+func TestBPlusTree_FromSorted(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		for _, n := range []int{0, 1, 3, 4, 5, 10, 17, 100, 1000} {
+			items := make([]int, n)
+			for i := range items {
+				items[i] = i * 2
+			}
+			tr, err := NewBPlusTreeFromSorted(items, degree, intComparator)
+			require.NoError(t, err)
+			checkBPlusTree(t, tr)
+			require.Equal(t, n, tr.Size())
+			require.True(t, slices.Equal(items, tr.Range(-1, 2*n)))
+
+			tr.Insert(1)
+			require.NoError(t, tr.Delete(1))
+			checkBPlusTree(t, tr)
+		}
+	}
+
+	_, err := NewBPlusTreeFromSorted([]int{2, 1}, 2, intComparator)
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), err)
+}
+
+func TestBPlusTree_BruteForce(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		rnd := rand.New(rand.NewSource(int64(degree)))
+		tr := NewBPlusTreeWithDegree(degree, intComparator)
+		var expected []int
+
+		for op := 0; op < 3000; op++ {
+			v := rnd.Intn(500)
+			i, found := slices.BinarySearch(expected, v)
+			if rnd.Intn(2) == 0 {
+				tr.Insert(v)
+				if !found {
+					expected = slices.Insert(expected, i, v)
+				}
+			} else if found {
+				require.NoError(t, tr.Delete(v))
+				expected = slices.Delete(expected, i, i+1)
+			} else {
+				require.Error(t, tr.Delete(v))
+			}
+			require.Equal(t, len(expected), tr.Size())
+
+			if op%50 == 0 {
+				checkBPlusTree(t, tr)
+				from := rnd.Intn(500)
+				to := from + rnd.Intn(100)
+				lo, _ := slices.BinarySearch(expected, from)
+				hi, _ := slices.BinarySearch(expected, to)
+				require.True(t, slices.Equal(expected[lo:hi], tr.Range(from, to)), "[%d, %d)", from, to)
+			}
+		}
+		checkBPlusTree(t, tr)
+	}
+}
+
+// checkBPlusTree checks number of keys in nodes, separators,
+// that all leaves are on the same level and linked in order
+func checkBPlusTree(t *testing.T, tr *bPlusTree[int]) {
+	t.Helper()
+
+	var leaves []*bplus_node[int]
+	leafDepth := -1
+	var check func(n *bplus_node[int], depth int, lo, hi *int)
+	check = func(n *bplus_node[int], depth int, lo, hi *int) {
+		if n != tr.root {
+			require.GreaterOrEqual(t, len(n.keys), tr.t-1)
+		}
+		require.LessOrEqual(t, len(n.keys), 2*tr.t-1)
+		require.True(t, slices.IsSorted(n.keys))
+		for _, v := range n.keys {
+			require.True(t, lo == nil || *lo <= v)
+			require.True(t, hi == nil || v < *hi)
+		}
+		if n.children == nil {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			require.Equal(t, leafDepth, depth)
+			leaves = append(leaves, n)
+			return
+		}
+		require.Len(t, n.children, len(n.keys)+1)
+		for i, child := range n.children {
+			l, h := lo, hi
+			if i > 0 {
+				l = &n.keys[i-1]
+			}
+			if i < len(n.keys) {
+				h = &n.keys[i]
+			}
+			check(child, depth+1, l, h)
+		}
+	}
+	check(tr.root, 0, nil, nil)
+
+	for i, leaf := range leaves {
+		if i == len(leaves)-1 {
+			require.Nil(t, leaf.next)
+		} else {
+			require.Same(t, leaves[i+1], leaf.next)
+		}
+	}
+}
+
+func BenchmarkBPlusTree_RangeScan(b *testing.B) {
+	n := 1 << 20
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	tr, _ := NewBPlusTreeFromSorted(items, defaultBTreeDegree, intComparator)
+	rnd := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		from := rnd.Intn(n)
+		sum := 0
+		tr.RangeFunc(from, from+1000, func(item int) bool {
+			sum += item
+			return true
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// defaultBTreeDegree is the default minimum degree of B-Tree and B+Tree
+const defaultBTreeDegree = 32
+
+// OrderedTree is Tree which keeps items in order and can iterate over them.
+//
+// Items are unique: Insert of item which is equal to existing one replaces it
+type OrderedTree[T comparable] interface {
+	Tree[T]
+
+	// Range returns items in [from, to) in ascending order
+	Range(from, to T) []T
+
+	// RangeFunc calls `fn` for items in [from, to) in ascending order
+	// until `fn` returns false
+	RangeFunc(from, to T, fn func(item T) bool)
+
+	// ForEach calls `fn` for each item in ascending order until `fn` returns false
+	ForEach(fn func(item T) bool)
+
+	// Min returns the smallest item
+	Min() (*T, error)
+
+	// Max returns the greatest item
+	Max() (*T, error)
+
+	Size() int
+	IsEmpty() bool
+}
+
+// bTree - B-Tree.
+//
+// Every node except root has from t-1 to 2t-1 sorted items (t is minimum degree),
+// internal node with k items has k+1 children. All leaves are on the same level.
+// Items are stored in slices, so a node fits in a few cache lines and
+// depth is log_t(n) instead of log_2(n) of binary trees
+type bTree[T comparable] struct {
+	root *btree_node[T]
+	size int
+	t    int
+
+	mu sync.Mutex
+
+	compare Comparator[T]
+}
+
+// btree_node is the node of B-Tree.
+//
+// # items 	-> sorted items
+//
+// # children 	-> nil for leaf, otherwise len(items)+1 children
+type btree_node[T comparable] struct {
+	items    []T
+	children []*btree_node[T]
+}
+
+// NewBTree creates B-Tree with default minimum degree
+func NewBTree[T comparable](compare Comparator[T]) *bTree[T] {
+	return NewBTreeWithDegree(defaultBTreeDegree, compare)
+}
+
+// NewBTreeWithDegree creates B-Tree with minimum degree `t` (>= 2):
+// node has at most 2t-1 items
+func NewBTreeWithDegree[T comparable](t int, compare Comparator[T]) *bTree[T] {
+	return &bTree[T]{root: &btree_node[T]{}, t: max(t, 2), compare: compare}
+}
+
+// NewBTreeFromSorted builds B-Tree from `items` sorted in ascending order in O(n).
+//
+// if items are not sorted or have duplicates -> returns err
+func NewBTreeFromSorted[T comparable](items []T, t int, compare Comparator[T]) (*bTree[T], error) {
+	if !strictlySorted(items, compare) {
+		return nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	bt := NewBTreeWithDegree(t, compare)
+	if len(items) > 0 {
+		bt.root = bt.build(items)
+	}
+	bt.size = len(items)
+	return bt, nil
+}
+
+func (bt *bTree[T]) Insert(item T) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	mid, right, added := bt.insertHelper(bt.root, item)
+	if right != nil {
+		bt.root = &btree_node[T]{
+			items:    []T{mid},
+			children: []*btree_node[T]{bt.root, right},
+		}
+	}
+	if added {
+		bt.size++
+	}
+}
+
+func (bt *bTree[T]) Delete(item T) error {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if !bt.deleteHelper(bt.root, item) {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	if len(bt.root.items) == 0 && bt.root.children != nil {
+		bt.root = bt.root.children[0]
+	}
+	bt.size--
+	return nil
+}
+
+func (bt *bTree[T]) Search(item T) (*T, error) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	curr := bt.root
+	for {
+		i, found := bt.find(curr, item)
+		if found {
+			val := curr.items[i]
+			return &val, nil
+		}
+		if curr.children == nil {
+			return nil, fmt.Errorf(gocollections.ErrNotFound)
+		}
+		curr = curr.children[i]
+	}
+}
+
+// Range returns items in [from, to) in ascending order
+func (bt *bTree[T]) Range(from, to T) []T {
+	var items []T
+	bt.RangeFunc(from, to, func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// RangeFunc calls `fn` for items in [from, to) in ascending order
+// until `fn` returns false
+func (bt *bTree[T]) RangeFunc(from, to T, fn func(item T) bool) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.rangeHelper(bt.root, &from, &to, fn)
+}
+
+// ForEach calls `fn` for each item in ascending order until `fn` returns false
+func (bt *bTree[T]) ForEach(fn func(item T) bool) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	bt.rangeHelper(bt.root, nil, nil, fn)
+}
+
+// Min returns the smallest item
+func (bt *bTree[T]) Min() (*T, error) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if bt.size == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	curr := bt.root
+	for curr.children != nil {
+		curr = curr.children[0]
+	}
+	val := curr.items[0]
+	return &val, nil
+}
+
+// Max returns the greatest item
+func (bt *bTree[T]) Max() (*T, error) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	if bt.size == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	last := bt.maxNode(bt.root)
+	val := last.items[len(last.items)-1]
+	return &val, nil
+}
+
+// Size returns number of items
+func (bt *bTree[T]) Size() int {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	return bt.size
+}
+
+// IsEmpty returns true if there are no items
+func (bt *bTree[T]) IsEmpty() bool {
+	return bt.Size() == 0
+}
+
+// Height returns number of levels
+func (bt *bTree[T]) Height() int {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+
+	h := 1
+	for curr := bt.root; curr.children != nil; curr = curr.children[0] {
+		h++
+	}
+	return h
+}
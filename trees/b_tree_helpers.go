@@ -0,0 +1,218 @@
+package trees
+
+import "slices"
+
+// find returns index of `item` in node or index of child where it may be
+func (bt *bTree[T]) find(node *btree_node[T], item T) (int, bool) {
+	return slices.BinarySearchFunc(node.items, item, bt.compare)
+}
+
+// insertHelper inserts item to subtree. If node overflows, it is split:
+// node keeps the left half, the middle item and the right half are returned
+// to be inserted into the parent
+func (bt *bTree[T]) insertHelper(node *btree_node[T], item T) (mid T, right *btree_node[T], added bool) {
+	i, found := bt.find(node, item)
+	if found {
+		node.items[i] = item
+		return mid, nil, false
+	}
+
+	if node.children == nil {
+		node.items = slices.Insert(node.items, i, item)
+	} else {
+		childMid, childRight, childAdded := bt.insertHelper(node.children[i], item)
+		added = childAdded
+		if childRight == nil {
+			return mid, nil, added
+		}
+		node.items = slices.Insert(node.items, i, childMid)
+		node.children = slices.Insert(node.children, i+1, childRight)
+	}
+
+	if len(node.items) < 2*bt.t {
+		return mid, nil, true
+	}
+	mid, right = bt.split(node)
+	return mid, right, true
+}
+
+// split divides node with 2t items into node with t items,
+// middle item and right node with t-1 items
+func (bt *bTree[T]) split(node *btree_node[T]) (T, *btree_node[T]) {
+	t := bt.t
+	mid := node.items[t]
+	right := &btree_node[T]{items: slices.Clone(node.items[t+1:])}
+	node.items = slices.Clip(node.items[:t])
+	if node.children != nil {
+		right.children = slices.Clone(node.children[t+1:])
+		node.children = slices.Clip(node.children[:t+1])
+	}
+	return mid, right
+}
+
+// deleteHelper deletes item from subtree and returns true if item was found.
+// Children with less than t-1 items after delete are fixed by fixChild
+func (bt *bTree[T]) deleteHelper(node *btree_node[T], item T) bool {
+	i, found := bt.find(node, item)
+	if node.children == nil {
+		if found {
+			node.items = slices.Delete(node.items, i, i+1)
+		}
+		return found
+	}
+
+	if found {
+		// internal item is replaced by its predecessor - max of left subtree
+		node.items[i] = bt.deleteMax(node.children[i])
+	} else if !bt.deleteHelper(node.children[i], item) {
+		return false
+	}
+	bt.fixChild(node, i)
+	return true
+}
+
+// deleteMax deletes the greatest item of subtree and returns it
+func (bt *bTree[T]) deleteMax(node *btree_node[T]) T {
+	if node.children == nil {
+		last := node.items[len(node.items)-1]
+		node.items = node.items[:len(node.items)-1]
+		return last
+	}
+	i := len(node.children) - 1
+	last := bt.deleteMax(node.children[i])
+	bt.fixChild(node, i)
+	return last
+}
+
+// fixChild makes child `i` of node have at least t-1 items:
+//
+// 1. if sibling has more than t-1 items -> item of sibling goes to parent
+// and item of parent goes to child (rotation)
+//
+// 2. otherwise child, separator from parent and sibling are merged into one node
+func (bt *bTree[T]) fixChild(node *btree_node[T], i int) {
+	child := node.children[i]
+	if len(child.items) >= bt.t-1 {
+		return
+	}
+
+	if i > 0 && len(node.children[i-1].items) > bt.t-1 {
+		left := node.children[i-1]
+		child.items = slices.Insert(child.items, 0, node.items[i-1])
+		node.items[i-1] = left.items[len(left.items)-1]
+		left.items = left.items[:len(left.items)-1]
+		if left.children != nil {
+			child.children = slices.Insert(child.children, 0, left.children[len(left.children)-1])
+			left.children = left.children[:len(left.children)-1]
+		}
+		return
+	}
+
+	if i < len(node.children)-1 && len(node.children[i+1].items) > bt.t-1 {
+		right := node.children[i+1]
+		child.items = append(child.items, node.items[i])
+		node.items[i] = right.items[0]
+		right.items = slices.Delete(right.items, 0, 1)
+		if right.children != nil {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return
+	}
+
+	if i == len(node.children)-1 {
+		i--
+	}
+	left, right := node.children[i], node.children[i+1]
+	left.items = append(append(left.items, node.items[i]), right.items...)
+	left.children = append(left.children, right.children...)
+	node.items = slices.Delete(node.items, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// rangeHelper goes in-order through items in [from, to), nil bound means no bound.
+// Returns false if `fn` asked to stop
+func (bt *bTree[T]) rangeHelper(node *btree_node[T], from, to *T, fn func(item T) bool) bool {
+	start := 0
+	if from != nil {
+		start, _ = bt.find(node, *from)
+	}
+	for i := start; i <= len(node.items); i++ {
+		if node.children != nil && !bt.rangeHelper(node.children[i], from, to, fn) {
+			return false
+		}
+		if i == len(node.items) {
+			break
+		}
+		if to != nil && bt.compare(node.items[i], *to) >= 0 {
+			return false
+		}
+		if !fn(node.items[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (bt *bTree[T]) maxNode(node *btree_node[T]) *btree_node[T] {
+	for node.children != nil {
+		node = node.children[len(node.children)-1]
+	}
+	return node
+}
+
+// build makes tree from sorted items level by level:
+// items are divided into nodes, separators between nodes go to the level above
+func (bt *bTree[T]) build(items []T) *btree_node[T] {
+	var children []*btree_node[T]
+	for {
+		nodes, separators := bt.buildLevel(items, children)
+		if len(nodes) == 1 {
+			return nodes[0]
+		}
+		items, children = separators, nodes
+	}
+}
+
+// buildLevel divides `items` into k nodes with k-1 separators between them,
+// every node gets from t-1 to 2t-1 items. `children` is nil for leaves,
+// otherwise it has len(items)+1 nodes of the level below
+func (bt *bTree[T]) buildLevel(items []T, children []*btree_node[T]) ([]*btree_node[T], []T) {
+	n := len(items)
+	k := 1
+	if n > 2*bt.t-1 {
+		k = (n + 2*bt.t) / (2 * bt.t) // ceil((n+1) / 2t)
+	}
+	base, extra := (n-k+1)/k, (n-k+1)%k
+
+	nodes := make([]*btree_node[T], 0, k)
+	separators := make([]T, 0, k-1)
+	pos := 0
+	for j := 0; j < k; j++ {
+		cnt := base
+		if j < extra {
+			cnt++
+		}
+		node := &btree_node[T]{items: slices.Clone(items[pos : pos+cnt])}
+		if children != nil {
+			node.children = slices.Clone(children[pos : pos+cnt+1])
+		}
+		nodes = append(nodes, node)
+		pos += cnt
+		if j < k-1 {
+			separators = append(separators, items[pos])
+			pos++
+		}
+	}
+	return nodes, separators
+}
+
+// strictlySorted returns true if items are sorted and have no duplicates
+func strictlySorted[T any](items []T, compare func(a, b T) int) bool {
+	for i := 1; i < len(items); i++ {
+		if compare(items[i-1], items[i]) >= 0 {
+			return false
+		}
+	}
+	return true
+}
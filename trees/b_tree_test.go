@@ -0,0 +1,210 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBTree_Basic(t *testing.T) {
+	var tr OrderedTree[int] = NewBTreeWithDegree(2, intComparator)
+
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 10, 90, 35} {
+		tr.Insert(v)
+	}
+	assert.Equal(t, 10, tr.Size())
+
+	val, err := tr.Search(35)
+	require.NoError(t, err)
+	assert.Equal(t, 35, *val)
+
+	assert.Equal(t, []int{30, 35, 40, 50}, tr.Range(30, 60))
+	assert.Empty(t, tr.Range(41, 49))
+
+	minVal, _ := tr.Min()
+	maxVal, _ := tr.Max()
+	assert.Equal(t, 10, *minVal)
+	assert.Equal(t, 90, *maxVal)
+
+	require.NoError(t, tr.Delete(50))
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), tr.Delete(50))
+	_, err = tr.Search(50)
+	assert.Error(t, err)
+
+	// duplicate replaces
+	tr.Insert(40)
+	assert.Equal(t, 9, tr.Size())
+
+	var firstThree []int
+	tr.ForEach(func(item int) bool {
+		firstThree = append(firstThree, item)
+		return len(firstThree) < 3
+	})
+	assert.Equal(t, []int{10, 20, 30}, firstThree)
+}
+
+func TestBTree_ReplacesEqual(t *testing.T) {
+	tr := NewBTree(comparePersonByAge)
+	tr.Insert(Person{Name: "Alice", Age: 30})
+	tr.Insert(Person{Name: "Bob", Age: 30})
+
+	p, err := tr.Search(Person{Age: 30})
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", p.Name)
+	assert.Equal(t, 1, tr.Size())
+}
+
+func TestBTree_Empty(t *testing.T) {
+	tr := NewBTree(intComparator)
+	assert.True(t, tr.IsEmpty())
+	_, err := tr.Min()
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+	_, err = tr.Max()
+	assert.Error(t, err)
+	_, err = tr.Search(1)
+	assert.Error(t, err)
+	assert.Error(t, tr.Delete(1))
+	assert.Empty(t, tr.Range(0, 100))
+}
+
+func TestBTree_FromSorted(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		for _, n := range []int{0, 1, 3, 4, 5, 10, 17, 100, 1000} {
+			items := make([]int, n)
+			for i := range items {
+				items[i] = i * 2
+			}
+			tr, err := NewBTreeFromSorted(items, degree, intComparator)
+			require.NoError(t, err)
+			checkBTree(t, tr)
+			require.Equal(t, n, tr.Size())
+			require.True(t, slices.Equal(items, tr.Range(-1, 2*n)))
+
+			// tree works after bulk load
+			tr.Insert(1)
+			require.NoError(t, tr.Delete(1))
+			checkBTree(t, tr)
+		}
+	}
+
+	_, err := NewBTreeFromSorted([]int{1, 3, 2}, 2, intComparator)
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), err)
+	_, err = NewBTreeFromSorted([]int{1, 1}, 2, intComparator)
+	assert.Error(t, err)
+}
+
+func TestBTree_BruteForce(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		rnd := rand.New(rand.NewSource(int64(degree)))
+		tr := NewBTreeWithDegree(degree, intComparator)
+		var expected []int
+
+		for op := 0; op < 3000; op++ {
+			v := rnd.Intn(500)
+			i, found := slices.BinarySearch(expected, v)
+			if rnd.Intn(2) == 0 {
+				tr.Insert(v)
+				if !found {
+					expected = slices.Insert(expected, i, v)
+				}
+			} else if found {
+				require.NoError(t, tr.Delete(v))
+				expected = slices.Delete(expected, i, i+1)
+			} else {
+				require.Error(t, tr.Delete(v))
+			}
+			require.Equal(t, len(expected), tr.Size())
+
+			if op%50 == 0 {
+				checkBTree(t, tr)
+				from := rnd.Intn(500)
+				to := from + rnd.Intn(100)
+				lo, _ := slices.BinarySearch(expected, from)
+				hi, _ := slices.BinarySearch(expected, to)
+				require.True(t, slices.Equal(expected[lo:hi], tr.Range(from, to)), "[%d, %d)", from, to)
+			}
+		}
+		checkBTree(t, tr)
+		var all []int
+		tr.ForEach(func(item int) bool {
+			all = append(all, item)
+			return true
+		})
+		require.Equal(t, expected, all)
+	}
+}
+
+// checkBTree checks number of items in nodes, order of items
+// and that all leaves are on the same level
+func checkBTree(t *testing.T, tr *bTree[int]) {
+	t.Helper()
+
+	leafDepth := -1
+	var check func(n *btree_node[int], depth int, lo, hi *int)
+	check = func(n *btree_node[int], depth int, lo, hi *int) {
+		if n != tr.root {
+			require.GreaterOrEqual(t, len(n.items), tr.t-1)
+		}
+		require.LessOrEqual(t, len(n.items), 2*tr.t-1)
+		require.True(t, slices.IsSorted(n.items))
+		for _, v := range n.items {
+			require.True(t, lo == nil || *lo < v)
+			require.True(t, hi == nil || v < *hi)
+		}
+		if n.children == nil {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			require.Equal(t, leafDepth, depth)
+			return
+		}
+		require.Len(t, n.children, len(n.items)+1)
+		for i, child := range n.children {
+			l, h := lo, hi
+			if i > 0 {
+				l = &n.items[i-1]
+			}
+			if i < len(n.items) {
+				h = &n.items[i]
+			}
+			check(child, depth+1, l, h)
+		}
+	}
+	check(tr.root, 0, nil, nil)
+}
+
+func BenchmarkBTree_Insert(b *testing.B) {
+	tr := NewBTree(intComparator)
+	rnd := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.Insert(rnd.Int())
+	}
+}
+
+func BenchmarkBTree_Search(b *testing.B) {
+	benchmarkSearch(b, NewBTree(intComparator))
+}
+
+func BenchmarkRBT_Search(b *testing.B) {
+	benchmarkSearch(b, NewRBT(intComparator))
+}
+
+func benchmarkSearch(b *testing.B, tr Tree[int]) {
+	n := 1 << 20
+	rnd := rand.New(rand.NewSource(1))
+	for _, v := range rnd.Perm(n) {
+		tr.Insert(v)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tr.Search(rnd.Intn(n))
+	}
+}
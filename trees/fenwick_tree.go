@@ -0,0 +1,180 @@
+package trees
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// FenwickTree is the interface of Fenwick Tree (Binary Indexed Tree).
+//
+// All ranges are half-open: [l, r)
+type FenwickTree[N Number] interface {
+	// Add adds `delta` to value at index `i`
+	Add(i int, delta N) error
+
+	// Set sets value at index `i`
+	Set(i int, val N) error
+
+	// Get returns value at index `i`
+	Get(i int) (N, error)
+
+	// PrefixSum returns sum of the first `i` values: [0, i)
+	PrefixSum(i int) (N, error)
+
+	// RangeSum returns sum of values in [l, r)
+	RangeSum(l, r int) (N, error)
+
+	// LowerBound returns the smallest index `i` such that PrefixSum(i + 1) >= sum.
+	//
+	// All values must be >= 0. If values are frequencies,
+	// LowerBound(k) is the index of k-th item (k starts from 1)
+	LowerBound(sum N) (int, error)
+
+	// Size returns number of values
+	Size() int
+}
+
+// fenwickTree - Fenwick Tree (Binary Indexed Tree).
+//
+// tree[i] (1-based) stores sum of values (i - lowbit(i), i],
+// where lowbit(i) = i & -i is the lowest set bit.
+// Add and PrefixSum go through O(log n) such nodes
+type fenwickTree[N Number] struct {
+	tree []N
+	n    int
+
+	mu sync.Mutex
+}
+
+// NewFenwickTree creates Fenwick Tree with `n` zeros
+func NewFenwickTree[N Number](n int) *fenwickTree[N] {
+	n = max(n, 0)
+	return &fenwickTree[N]{tree: make([]N, n+1), n: n}
+}
+
+// NewFenwickTreeFrom builds Fenwick Tree from `items` in O(n)
+func NewFenwickTreeFrom[N Number](items []N) *fenwickTree[N] {
+	ft := NewFenwickTree[N](len(items))
+	copy(ft.tree[1:], items)
+	for i := 1; i <= ft.n; i++ {
+		if parent := i + lowbit(i); parent <= ft.n {
+			ft.tree[parent] += ft.tree[i]
+		}
+	}
+	return ft
+}
+
+// Add adds `delta` to value at index `i`
+func (ft *fenwickTree[N]) Add(i int, delta N) error {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if i < 0 || i >= ft.n {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	ft.add(i, delta)
+	return nil
+}
+
+// Set sets value at index `i`
+func (ft *fenwickTree[N]) Set(i int, val N) error {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if i < 0 || i >= ft.n {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	ft.add(i, val-(ft.prefixSum(i+1)-ft.prefixSum(i)))
+	return nil
+}
+
+// Get returns value at index `i`
+func (ft *fenwickTree[N]) Get(i int) (N, error) {
+	return ft.RangeSum(i, i+1)
+}
+
+// PrefixSum returns sum of the first `i` values: [0, i)
+func (ft *fenwickTree[N]) PrefixSum(i int) (N, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if i < 0 || i > ft.n {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	return ft.prefixSum(i), nil
+}
+
+// RangeSum returns sum of values in [l, r)
+func (ft *fenwickTree[N]) RangeSum(l, r int) (N, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if l < 0 || r > ft.n || l > r {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	return ft.prefixSum(r) - ft.prefixSum(l), nil
+}
+
+// LowerBound returns the smallest index `i` such that PrefixSum(i + 1) >= sum.
+//
+// If sum of all values < sum -> returns err
+func (ft *fenwickTree[N]) LowerBound(sum N) (int, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if sum <= 0 {
+		if ft.n == 0 {
+			return 0, fmt.Errorf(gocollections.ErrNotFound)
+		}
+		return 0, nil
+	}
+
+	// binary lifting: go down from the highest power of 2,
+	// pos is the longest prefix with sum < `sum`
+	pos := 0
+	for step := highestPowerOfTwo(ft.n); step > 0; step /= 2 {
+		if next := pos + step; next <= ft.n && ft.tree[next] < sum {
+			pos = next
+			sum -= ft.tree[next]
+		}
+	}
+	if pos == ft.n {
+		return 0, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return pos, nil
+}
+
+// Size returns number of values
+func (ft *fenwickTree[N]) Size() int {
+	return ft.n
+}
+
+func (ft *fenwickTree[N]) add(i int, delta N) {
+	for i++; i <= ft.n; i += lowbit(i) {
+		ft.tree[i] += delta
+	}
+}
+
+func (ft *fenwickTree[N]) prefixSum(i int) N {
+	var sum N
+	for ; i > 0; i -= lowbit(i) {
+		sum += ft.tree[i]
+	}
+	return sum
+}
+
+// lowbit returns the lowest set bit of i
+func lowbit(i int) int {
+	return i & -i
+}
+
+// highestPowerOfTwo returns the highest power of 2 <= n (0 for n == 0)
+func highestPowerOfTwo(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return 1 << (bits.Len(uint(n)) - 1)
+}
@@ -0,0 +1,92 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// fenwickTree2D - 2D Fenwick Tree for grid counts.
+//
+// Every row of Fenwick Trees is a Fenwick Tree itself:
+// tree[x][y] stores sum of cells (x - lowbit(x), x] x (y - lowbit(y), y].
+//
+// Add and PrefixSum are O(log rows * log cols).
+// All rectangles are half-open: [x1, x2) x [y1, y2)
+type fenwickTree2D[N Number] struct {
+	tree [][]N
+	rows int
+	cols int
+
+	mu sync.Mutex
+}
+
+// NewFenwickTree2D creates 2D Fenwick Tree with `rows` x `cols` zeros
+func NewFenwickTree2D[N Number](rows, cols int) *fenwickTree2D[N] {
+	rows, cols = max(rows, 0), max(cols, 0)
+	tree := make([][]N, rows+1)
+	for i := range tree {
+		tree[i] = make([]N, cols+1)
+	}
+	return &fenwickTree2D[N]{tree: tree, rows: rows, cols: cols}
+}
+
+// Add adds `delta` to cell (x, y)
+func (ft *fenwickTree2D[N]) Add(x, y int, delta N) error {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if x < 0 || x >= ft.rows || y < 0 || y >= ft.cols {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	for i := x + 1; i <= ft.rows; i += lowbit(i) {
+		for j := y + 1; j <= ft.cols; j += lowbit(j) {
+			ft.tree[i][j] += delta
+		}
+	}
+	return nil
+}
+
+// Get returns value of cell (x, y)
+func (ft *fenwickTree2D[N]) Get(x, y int) (N, error) {
+	return ft.RangeSum(x, y, x+1, y+1)
+}
+
+// PrefixSum returns sum of cells in [0, x) x [0, y)
+func (ft *fenwickTree2D[N]) PrefixSum(x, y int) (N, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if x < 0 || x > ft.rows || y < 0 || y > ft.cols {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	return ft.prefixSum(x, y), nil
+}
+
+// RangeSum returns sum of cells in [x1, x2) x [y1, y2)
+func (ft *fenwickTree2D[N]) RangeSum(x1, y1, x2, y2 int) (N, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if x1 < 0 || y1 < 0 || x2 > ft.rows || y2 > ft.cols || x1 > x2 || y1 > y2 {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	// inclusion-exclusion
+	return ft.prefixSum(x2, y2) - ft.prefixSum(x1, y2) - ft.prefixSum(x2, y1) + ft.prefixSum(x1, y1), nil
+}
+
+// Size returns number of rows and columns
+func (ft *fenwickTree2D[N]) Size() (int, int) {
+	return ft.rows, ft.cols
+}
+
+func (ft *fenwickTree2D[N]) prefixSum(x, y int) N {
+	var sum N
+	for i := x; i > 0; i -= lowbit(i) {
+		for j := y; j > 0; j -= lowbit(j) {
+			sum += ft.tree[i][j]
+		}
+	}
+	return sum
+}
@@ -0,0 +1,75 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFenwickTree2D_Basic(t *testing.T) {
+	ft := NewFenwickTree2D[int](3, 4)
+
+	require.NoError(t, ft.Add(0, 0, 1))
+	require.NoError(t, ft.Add(1, 2, 5))
+	require.NoError(t, ft.Add(2, 3, 2))
+	require.NoError(t, ft.Add(1, 2, 1))
+
+	sum, err := ft.PrefixSum(3, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 9, sum)
+
+	sum, err = ft.RangeSum(1, 1, 3, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 6, sum)
+
+	val, err := ft.Get(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 6, val)
+
+	rows, cols := ft.Size()
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, 4, cols)
+
+	assert.Error(t, ft.Add(3, 0, 1))
+	assert.Error(t, ft.Add(0, -1, 1))
+	_, err = ft.PrefixSum(0, 5)
+	assert.Error(t, err)
+	_, err = ft.RangeSum(2, 0, 1, 4)
+	assert.Error(t, err)
+}
+
+func TestFenwickTree2D_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for _, size := range [][2]int{{1, 1}, {1, 7}, {5, 3}, {16, 16}, {13, 29}} {
+		rows, cols := size[0], size[1]
+		grid := make([][]int, rows)
+		for i := range grid {
+			grid[i] = make([]int, cols)
+		}
+		ft := NewFenwickTree2D[int](rows, cols)
+
+		for op := 0; op < 500; op++ {
+			if rnd.Intn(2) == 0 {
+				x, y, d := rnd.Intn(rows), rnd.Intn(cols), rnd.Intn(21)-10
+				require.NoError(t, ft.Add(x, y, d))
+				grid[x][y] += d
+				continue
+			}
+			x1 := rnd.Intn(rows + 1)
+			x2 := x1 + rnd.Intn(rows-x1+1)
+			y1 := rnd.Intn(cols + 1)
+			y2 := y1 + rnd.Intn(cols-y1+1)
+			expected := 0
+			for x := x1; x < x2; x++ {
+				for y := y1; y < y2; y++ {
+					expected += grid[x][y]
+				}
+			}
+			sum, err := ft.RangeSum(x1, y1, x2, y2)
+			require.NoError(t, err)
+			require.Equal(t, expected, sum, "[%d, %d) x [%d, %d)", x1, x2, y1, y2)
+		}
+	}
+}
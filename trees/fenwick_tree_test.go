@@ -0,0 +1,139 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFenwickTree_Basic(t *testing.T) {
+	var ft FenwickTree[int] = NewFenwickTreeFrom([]int{5, 3, 8, 1, 4, 7})
+
+	sum, err := ft.PrefixSum(6)
+	require.NoError(t, err)
+	assert.Equal(t, 28, sum)
+
+	sum, err = ft.PrefixSum(0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sum)
+
+	sum, err = ft.RangeSum(1, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 12, sum)
+
+	require.NoError(t, ft.Add(2, -8))
+	sum, _ = ft.RangeSum(0, 6)
+	assert.Equal(t, 20, sum)
+
+	require.NoError(t, ft.Set(0, 10))
+	val, err := ft.Get(0)
+	require.NoError(t, err)
+	assert.Equal(t, 10, val)
+
+	assert.Equal(t, errors.New(gocollections.ErrOutOfBounds), ft.Add(6, 1))
+	_, err = ft.PrefixSum(7)
+	assert.Error(t, err)
+	_, err = ft.RangeSum(4, 2)
+	assert.Error(t, err)
+	assert.Error(t, ft.Set(-1, 1))
+}
+
+func TestFenwickTree_LowerBound(t *testing.T) {
+	// frequencies of values 0..5: multiset {1, 1, 3, 4, 4, 4}
+	ft := NewFenwickTreeFrom([]int{0, 2, 0, 1, 3, 0})
+
+	expected := []int{1, 1, 3, 4, 4, 4}
+	for k, v := range expected {
+		i, err := ft.LowerBound(k + 1)
+		require.NoError(t, err)
+		assert.Equal(t, v, i, "k=%d", k+1)
+	}
+
+	_, err := ft.LowerBound(7)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+
+	i, err := ft.LowerBound(0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, i)
+
+	_, err = NewFenwickTree[int](0).LowerBound(1)
+	assert.Error(t, err)
+}
+
+func TestFenwickTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 7, 16, 33, 100} {
+		items := make([]int, n)
+		ft := NewFenwickTree[int](n)
+
+		for op := 0; op < 500; op++ {
+			switch rnd.Intn(3) {
+			case 0:
+				i, d := rnd.Intn(n), rnd.Intn(10)
+				require.NoError(t, ft.Add(i, d))
+				items[i] += d
+			case 1:
+				l := rnd.Intn(n + 1)
+				r := l + rnd.Intn(n-l+1)
+				expected := 0
+				for i := l; i < r; i++ {
+					expected += items[i]
+				}
+				sum, err := ft.RangeSum(l, r)
+				require.NoError(t, err)
+				require.Equal(t, expected, sum, "n=%d [%d, %d)", n, l, r)
+			default:
+				target := rnd.Intn(10*n) + 1
+				expected, acc := -1, 0
+				for i, v := range items {
+					acc += v
+					if acc >= target {
+						expected = i
+						break
+					}
+				}
+				i, err := ft.LowerBound(target)
+				if expected == -1 {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+					require.Equal(t, expected, i, "n=%d sum=%d", n, target)
+				}
+			}
+		}
+
+		// building from items must give the same tree
+		require.Equal(t, ft.tree, NewFenwickTreeFrom(items).tree)
+	}
+}
+
+func TestFenwickTree_Float(t *testing.T) {
+	ft := NewFenwickTreeFrom([]float64{0.5, 1.5, 2.5})
+	sum, err := ft.RangeSum(1, 3)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, sum, 1e-9)
+
+	i, err := ft.LowerBound(1.9)
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+}
+
+func BenchmarkFenwickTree(b *testing.B) {
+	n := 1 << 16
+	ft := NewFenwickTree[int](n)
+	rnd := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		j := rnd.Intn(n)
+		if i%2 == 0 {
+			_ = ft.Add(j, 1)
+		} else {
+			_, _ = ft.PrefixSum(j)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// Interval is the closed interval [Lo, Hi] with payload Val
+type Interval[K comparable, V any] struct {
+	Lo  K
+	Hi  K
+	Val V
+}
+
+// IntervalTree stores closed intervals [lo, hi] with payloads
+// and finds intervals which overlap with given one.
+//
+// Two intervals [a, b] and [c, d] overlap if a <= d and c <= b,
+// so [1, 5] and [5, 9] overlap.
+//
+//	tr := trees.NewIntervalTree[int, string](compare)
+//	_ = tr.Insert(9, 11, "standup")
+//	_ = tr.Insert(10, 12, "review")
+//	booked := tr.Overlapping(11, 13) // both
+type IntervalTree[K comparable, V any] interface {
+	// Insert adds interval [lo, hi] with payload `val`
+	//
+	// if lo > hi -> returns err
+	Insert(lo, hi K, val V) error
+
+	// Delete deletes one interval [lo, hi].
+	// If there are several such intervals, any of them is deleted
+	//
+	// if there is no such interval -> returns err
+	Delete(lo, hi K) error
+
+	// Overlapping returns all intervals which overlap with [lo, hi], sorted by Lo
+	Overlapping(lo, hi K) []Interval[K, V]
+
+	// Stab returns all intervals which contain `point`, sorted by Lo
+	Stab(point K) []Interval[K, V]
+
+	// AnyOverlap returns any interval which overlaps with [lo, hi] in O(log n)
+	//
+	// if there is no such interval -> val = nil, err != nil
+	AnyOverlap(lo, hi K) (*Interval[K, V], error)
+
+	// Intervals returns all intervals sorted by Lo, then by Hi
+	Intervals() []Interval[K, V]
+
+	Size() int
+	IsEmpty() bool
+}
+
+// intervalTree - Interval Tree.
+//
+// It is Red-Black Tree ordered by (lo, hi), where every node also keeps
+// max hi of its subtree. Balancing is done by rbt, intervalTree only gives it
+// `augment` func to keep max hi correct after rotations.
+//
+// If max hi of subtree < lo of query -> nothing in this subtree overlaps with query
+type intervalTree[K comparable, V any] struct {
+	tree *rbt[*intervalItem[K, V]]
+	size int
+
+	mu sync.Mutex
+
+	compare Comparator[K]
+}
+
+// intervalItem is the value of rbt node.
+// Ptr is used because V may be not comparable
+//
+// # maxHi 	-> max hi in subtree of the node
+type intervalItem[K comparable, V any] struct {
+	Interval[K, V]
+	maxHi K
+}
+
+// NewIntervalTree creates Interval Tree with intervals ends compared by `compare`
+func NewIntervalTree[K comparable, V any](compare Comparator[K]) *intervalTree[K, V] {
+	it := &intervalTree[K, V]{compare: compare}
+	it.tree = &rbt[*intervalItem[K, V]]{
+		compare: it.compareItems,
+		augment: it.augment,
+	}
+	return it
+}
+
+// Insert adds interval [lo, hi] with payload `val`
+func (it *intervalTree[K, V]) Insert(lo, hi K, val V) error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.compare(lo, hi) > 0 {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	it.tree.insert(&intervalItem[K, V]{
+		Interval: Interval[K, V]{Lo: lo, Hi: hi, Val: val},
+		maxHi:    hi,
+	})
+	it.size++
+	return nil
+}
+
+// Delete deletes one interval [lo, hi]
+func (it *intervalTree[K, V]) Delete(lo, hi K) error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	probe := &intervalItem[K, V]{Interval: Interval[K, V]{Lo: lo, Hi: hi}}
+	node := it.tree.searchHelper(it.tree.root, probe)
+	if node == nil {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	it.tree.deleteHelper(node)
+	it.size--
+	return nil
+}
+
+// Overlapping returns all intervals which overlap with [lo, hi], sorted by Lo
+func (it *intervalTree[K, V]) Overlapping(lo, hi K) []Interval[K, V] {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	var res []Interval[K, V]
+	if it.compare(lo, hi) > 0 {
+		return res
+	}
+	it.overlappingHelper(it.tree.root, lo, hi, &res)
+	return res
+}
+
+// Stab returns all intervals which contain `point`, sorted by Lo
+func (it *intervalTree[K, V]) Stab(point K) []Interval[K, V] {
+	return it.Overlapping(point, point)
+}
+
+// AnyOverlap returns any interval which overlaps with [lo, hi] in O(log n)
+func (it *intervalTree[K, V]) AnyOverlap(lo, hi K) (*Interval[K, V], error) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.compare(lo, hi) <= 0 {
+		if node := it.anyOverlapHelper(lo, hi); node != nil {
+			res := node.val.Interval
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf(gocollections.ErrNotFound)
+}
+
+// Intervals returns all intervals sorted by Lo, then by Hi
+func (it *intervalTree[K, V]) Intervals() []Interval[K, V] {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	items := make([]*intervalItem[K, V], 0, it.size)
+	it.tree.inOrderHelper(it.tree.root, &items)
+
+	res := make([]Interval[K, V], len(items))
+	for i, item := range items {
+		res[i] = item.Interval
+	}
+	return res
+}
+
+// Size returns number of intervals
+func (it *intervalTree[K, V]) Size() int {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	return it.size
+}
+
+// IsEmpty returns true if there are no intervals
+func (it *intervalTree[K, V]) IsEmpty() bool {
+	return it.Size() == 0
+}
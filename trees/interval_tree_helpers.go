@@ -0,0 +1,58 @@
+package trees
+
+// compareItems orders intervals by lo, then by hi
+func (it *intervalTree[K, V]) compareItems(a, b *intervalItem[K, V]) int {
+	if c := it.compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return it.compare(a.Hi, b.Hi)
+}
+
+// augment recomputes max hi of node's subtree from its children
+func (it *intervalTree[K, V]) augment(node *rbt_node[*intervalItem[K, V]]) {
+	maxHi := node.val.Hi
+	if node.left != nil && it.compare(node.left.val.maxHi, maxHi) > 0 {
+		maxHi = node.left.val.maxHi
+	}
+	if node.right != nil && it.compare(node.right.val.maxHi, maxHi) > 0 {
+		maxHi = node.right.val.maxHi
+	}
+	node.val.maxHi = maxHi
+}
+
+// overlappingHelper goes in-order and skips subtrees which can't overlap with [lo, hi]:
+//
+// 1. if max hi of subtree < lo -> whole subtree ends before query
+//
+// 2. if node.lo > hi -> node and its right subtree start after query
+func (it *intervalTree[K, V]) overlappingHelper(node *rbt_node[*intervalItem[K, V]], lo, hi K, res *[]Interval[K, V]) {
+	if node == nil || it.compare(node.val.maxHi, lo) < 0 {
+		return
+	}
+	it.overlappingHelper(node.left, lo, hi, res)
+	if it.compare(node.val.Lo, hi) > 0 {
+		return
+	}
+	if it.compare(lo, node.val.Hi) <= 0 {
+		*res = append(*res, node.val.Interval)
+	}
+	it.overlappingHelper(node.right, lo, hi, res)
+}
+
+// anyOverlapHelper goes down only once:
+// if left subtree has interval which ends after lo, then either it overlaps with query
+// or all intervals of left subtree start after hi -> right subtree starts after hi too
+func (it *intervalTree[K, V]) anyOverlapHelper(lo, hi K) *rbt_node[*intervalItem[K, V]] {
+	curr := it.tree.root
+	for curr != nil {
+		if it.compare(curr.val.Lo, hi) <= 0 && it.compare(lo, curr.val.Hi) <= 0 {
+			return curr
+		}
+		if curr.left != nil && it.compare(curr.left.val.maxHi, lo) >= 0 {
+			curr = curr.left
+		} else {
+			curr = curr.right
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalTree_Basic(t *testing.T) {
+	var tr IntervalTree[int, string] = NewIntervalTree[int, string](intComparator)
+
+	require.NoError(t, tr.Insert(9, 11, "standup"))
+	require.NoError(t, tr.Insert(10, 12, "review"))
+	require.NoError(t, tr.Insert(14, 15, "lunch"))
+	require.NoError(t, tr.Insert(1, 3, "night"))
+	assert.Equal(t, 4, tr.Size())
+
+	res := tr.Overlapping(11, 13)
+	require.Len(t, res, 2)
+	assert.Equal(t, "standup", res[0].Val)
+	assert.Equal(t, "review", res[1].Val)
+
+	// closed intervals: touching ends overlap
+	res = tr.Stab(15)
+	require.Len(t, res, 1)
+	assert.Equal(t, Interval[int, string]{Lo: 14, Hi: 15, Val: "lunch"}, res[0])
+
+	assert.Empty(t, tr.Stab(13))
+	assert.Empty(t, tr.Overlapping(4, 8))
+
+	iv, err := tr.AnyOverlap(2, 9)
+	require.NoError(t, err)
+	assert.Contains(t, []string{"night", "standup"}, iv.Val)
+
+	_, err = tr.AnyOverlap(4, 8)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+
+	require.NoError(t, tr.Delete(10, 12))
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), tr.Delete(10, 12))
+	assert.Len(t, tr.Overlapping(11, 13), 1)
+
+	assert.Equal(t, errors.New(gocollections.ErrInvalidData), tr.Insert(5, 4, "bad"))
+	assert.Empty(t, tr.Overlapping(20, 0))
+
+	assert.Equal(t, []Interval[int, string]{
+		{1, 3, "night"}, {9, 11, "standup"}, {14, 15, "lunch"},
+	}, tr.Intervals())
+}
+
+func TestIntervalTree_Duplicates(t *testing.T) {
+	tr := NewIntervalTree[int, int](intComparator)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, tr.Insert(1, 2, i))
+	}
+	assert.Len(t, tr.Stab(2), 5)
+
+	require.NoError(t, tr.Delete(1, 2))
+	require.NoError(t, tr.Delete(1, 2))
+	assert.Len(t, tr.Stab(1), 3)
+	assert.Equal(t, 3, tr.Size())
+}
+
+func TestIntervalTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tr := NewIntervalTree[int, int](intComparator)
+	var all []Interval[int, int]
+
+	overlaps := func(iv Interval[int, int], lo, hi int) bool {
+		return iv.Lo <= hi && lo <= iv.Hi
+	}
+
+	for op := 0; op < 3000; op++ {
+		switch {
+		case rnd.Intn(3) > 0 || len(all) == 0:
+			lo := rnd.Intn(1000)
+			iv := Interval[int, int]{Lo: lo, Hi: lo + rnd.Intn(50), Val: op}
+			require.NoError(t, tr.Insert(iv.Lo, iv.Hi, iv.Val))
+			all = append(all, iv)
+		default:
+			i := rnd.Intn(len(all))
+			require.NoError(t, tr.Delete(all[i].Lo, all[i].Hi))
+			// any interval with the same ends may be deleted, so drop
+			// whichever one is not in the tree anymore
+			left := map[int]bool{}
+			for _, iv := range tr.Stab(all[i].Lo) {
+				left[iv.Val] = true
+			}
+			for j, iv := range all {
+				if iv.Lo == all[i].Lo && iv.Hi == all[i].Hi && !left[iv.Val] {
+					all = append(all[:j], all[j+1:]...)
+					break
+				}
+			}
+		}
+		require.Equal(t, len(all), tr.Size())
+		checkIntervalTree(t, tr)
+
+		lo := rnd.Intn(1100) - 50
+		hi := lo + rnd.Intn(30)
+		var expected []int
+		for _, iv := range all {
+			if overlaps(iv, lo, hi) {
+				expected = append(expected, iv.Val)
+			}
+		}
+		var got []int
+		for _, iv := range tr.Overlapping(lo, hi) {
+			require.True(t, overlaps(iv, lo, hi))
+			got = append(got, iv.Val)
+		}
+		sort.Ints(expected)
+		sort.Ints(got)
+		require.Equal(t, expected, got, "[%d, %d]", lo, hi)
+
+		iv, err := tr.AnyOverlap(lo, hi)
+		if len(expected) == 0 {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			require.True(t, overlaps(*iv, lo, hi))
+		}
+	}
+}
+
+// checkIntervalTree checks RBT props and max hi of every subtree
+func checkIntervalTree(t *testing.T, tr *intervalTree[int, int]) {
+	t.Helper()
+
+	var check func(node *rbt_node[*intervalItem[int, int]]) (blackHeight, maxHi int)
+	check = func(node *rbt_node[*intervalItem[int, int]]) (int, int) {
+		if node == nil {
+			return 1, -1 << 31
+		}
+		if node.clr == red {
+			require.False(t, node.left != nil && node.left.clr == red, "red node with red child")
+			require.False(t, node.right != nil && node.right.clr == red, "red node with red child")
+		}
+		lh, lmax := check(node.left)
+		rh, rmax := check(node.right)
+		require.Equal(t, lh, rh, "black heights differ")
+		maxHi := max(node.val.Hi, lmax, rmax)
+		require.Equal(t, maxHi, node.val.maxHi)
+		if node.clr == black {
+			lh++
+		}
+		return lh, maxHi
+	}
+	if tr.tree.root != nil {
+		require.Equal(t, COLOR(black), tr.tree.root.clr)
+	}
+	check(tr.tree.root)
+}
+
+func BenchmarkIntervalTree_Overlapping(b *testing.B) {
+	tr := NewIntervalTree[int, int](intComparator)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1<<16; i++ {
+		lo := rnd.Intn(1 << 20)
+		_ = tr.Insert(lo, lo+rnd.Intn(100), i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lo := rnd.Intn(1 << 20)
+		_ = tr.Overlapping(lo, lo+100)
+	}
+}
@@ -0,0 +1,203 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// CoordFunc returns coordinate of point `p` on axis `axis` (0 <= axis < dims)
+type CoordFunc[P any] func(p P, axis int) float64
+
+// SliceCoord is CoordFunc for points stored as []float64
+func SliceCoord(p []float64, axis int) float64 {
+	return p[axis]
+}
+
+// KDTree is the interface of K-D Tree - the tree of k-dimensional points.
+//
+// Distance between points is Euclidean.
+//
+//	tr := trees.NewKDTreeFrom([][]float64{{2, 3}, {5, 4}, {9, 6}, {4, 7}}, 2, trees.SliceCoord)
+//	p, _ := tr.Nearest([]float64{9, 2}) // {9, 6}
+//	pts := tr.KNearest([]float64{9, 2}, 2) // {9, 6}, {5, 4}
+type KDTree[P any] interface {
+	// Insert adds point `p`
+	Insert(p P)
+
+	// Delete deletes one point with the same coordinates as `p`
+	//
+	// if there is no such point -> returns err
+	Delete(p P) error
+
+	// Nearest returns the nearest point to `q`
+	//
+	// if tree is empty -> val = nil, err != nil
+	Nearest(q P) (*P, error)
+
+	// KNearest returns at most `k` nearest points to `q` sorted by distance
+	KNearest(q P, k int) []P
+
+	// RangeSearch returns all points inside box [lo, hi] (bounds included)
+	RangeSearch(lo, hi P) []P
+
+	// RadiusSearch returns all points with distance to `q` <= r
+	RadiusSearch(q P, r float64) []P
+
+	// Points returns all points in pre-order
+	Points() []P
+
+	Size() int
+	IsEmpty() bool
+}
+
+// kdTree - K-D Tree.
+//
+// Node on depth `d` splits space by axis d % dims:
+// left subtree has points with coord < node's coord,
+// right subtree has points with coord >= node's coord
+type kdTree[P any] struct {
+	root *kdNode[P]
+	size int
+	dims int
+
+	mu sync.Mutex
+
+	coord CoordFunc[P]
+}
+
+type kdNode[P any] struct {
+	point P
+
+	left  *kdNode[P]
+	right *kdNode[P]
+}
+
+// NewKDTree creates empty K-D Tree of `dims`-dimensional points
+func NewKDTree[P any](dims int, coord CoordFunc[P]) *kdTree[P] {
+	return &kdTree[P]{dims: max(dims, 1), coord: coord}
+}
+
+// NewKDTreeFrom builds balanced K-D Tree from `points` in O(n log^2 n).
+// `points` is not modified
+func NewKDTreeFrom[P any](points []P, dims int, coord CoordFunc[P]) *kdTree[P] {
+	kd := NewKDTree(dims, coord)
+	kd.root = kd.build(append([]P(nil), points...), 0)
+	kd.size = len(points)
+	return kd
+}
+
+// Insert adds point `p`
+func (kd *kdTree[P]) Insert(p P) {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	newNode := &kdNode[P]{point: p}
+	kd.size++
+	if kd.root == nil {
+		kd.root = newNode
+		return
+	}
+
+	curr := kd.root
+	for depth := 0; ; depth++ {
+		axis := depth % kd.dims
+		if kd.coord(p, axis) < kd.coord(curr.point, axis) {
+			if curr.left == nil {
+				curr.left = newNode
+				return
+			}
+			curr = curr.left
+		} else {
+			if curr.right == nil {
+				curr.right = newNode
+				return
+			}
+			curr = curr.right
+		}
+	}
+}
+
+// Delete deletes one point with the same coordinates as `p`
+func (kd *kdTree[P]) Delete(p P) error {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	target := kd.searchHelper(p)
+	if target == nil {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	kd.root = kd.deleteHelper(kd.root, target, 0)
+	kd.size--
+	return nil
+}
+
+// Nearest returns the nearest point to `q`
+func (kd *kdTree[P]) Nearest(q P) (*P, error) {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	if kd.root == nil {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	var best *kdNode[P]
+	bestDist := 0.0
+	kd.nearestHelper(kd.root, q, 0, &best, &bestDist)
+
+	res := best.point
+	return &res, nil
+}
+
+// KNearest returns at most `k` nearest points to `q` sorted by distance
+func (kd *kdTree[P]) KNearest(q P, k int) []P {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	return kd.kNearestHelper(q, k)
+}
+
+// RangeSearch returns all points inside box [lo, hi] (bounds included)
+func (kd *kdTree[P]) RangeSearch(lo, hi P) []P {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	var res []P
+	kd.rangeHelper(kd.root, lo, hi, 0, &res)
+	return res
+}
+
+// RadiusSearch returns all points with distance to `q` <= r
+func (kd *kdTree[P]) RadiusSearch(q P, r float64) []P {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	var res []P
+	if r >= 0 {
+		kd.radiusHelper(kd.root, q, r*r, 0, &res)
+	}
+	return res
+}
+
+// Points returns all points in pre-order
+func (kd *kdTree[P]) Points() []P {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	res := make([]P, 0, kd.size)
+	kd.preOrderHelper(kd.root, &res)
+	return res
+}
+
+// Size returns number of points
+func (kd *kdTree[P]) Size() int {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+
+	return kd.size
+}
+
+// IsEmpty returns true if there are no points
+func (kd *kdTree[P]) IsEmpty() bool {
+	return kd.Size() == 0
+}
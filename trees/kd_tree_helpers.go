@@ -0,0 +1,277 @@
+package trees
+
+import (
+	"slices"
+
+	"github.com/0x0FACED/go-collections/heaps"
+)
+
+// build makes balanced subtree from `points` (points are reordered).
+//
+// Median by axis becomes the root. If there are several points with the
+// same coord as median, the first of them is taken: all points of
+// left subtree must be strictly less
+func (kd *kdTree[P]) build(points []P, depth int) *kdNode[P] {
+	if len(points) == 0 {
+		return nil
+	}
+	axis := depth % kd.dims
+	slices.SortFunc(points, func(a, b P) int {
+		ca, cb := kd.coord(a, axis), kd.coord(b, axis)
+		if ca < cb {
+			return -1
+		} else if ca > cb {
+			return 1
+		}
+		return 0
+	})
+
+	mid := len(points) / 2
+	for mid > 0 && kd.coord(points[mid-1], axis) == kd.coord(points[mid], axis) {
+		mid--
+	}
+	return &kdNode[P]{
+		point: points[mid],
+		left:  kd.build(points[:mid], depth+1),
+		right: kd.build(points[mid+1:], depth+1),
+	}
+}
+
+// samePoint returns true if all coords of a and b are equal
+func (kd *kdTree[P]) samePoint(a, b P) bool {
+	for axis := 0; axis < kd.dims; axis++ {
+		if kd.coord(a, axis) != kd.coord(b, axis) {
+			return false
+		}
+	}
+	return true
+}
+
+// dist returns squared distance between a and b
+func (kd *kdTree[P]) dist(a, b P) float64 {
+	var d float64
+	for axis := 0; axis < kd.dims; axis++ {
+		diff := kd.coord(a, axis) - kd.coord(b, axis)
+		d += diff * diff
+	}
+	return d
+}
+
+// searchHelper returns the first node with the same coords as `p`
+func (kd *kdTree[P]) searchHelper(p P) *kdNode[P] {
+	curr := kd.root
+	for depth := 0; curr != nil; depth++ {
+		if kd.samePoint(p, curr.point) {
+			return curr
+		}
+		axis := depth % kd.dims
+		if kd.coord(p, axis) < kd.coord(curr.point, axis) {
+			curr = curr.left
+		} else {
+			curr = curr.right
+		}
+	}
+	return nil
+}
+
+// deleteHelper deletes node `target` from subtree `curr` and returns new subtree.
+//
+// Points can't be moved between subtrees (it breaks split by axis), so:
+//
+// 1. if target has right subtree -> target takes the point with min coord
+// by target's axis from right subtree, and this point is deleted from right subtree
+//
+// 2. if target has only left subtree -> the same with min from left subtree,
+// and left subtree becomes right (min is taken, so all other points are >= min)
+//
+// 3. leaf is just deleted
+//
+// Nodes are found by ptr, not by coords: point may have the same coords
+// as other point but another payload
+func (kd *kdTree[P]) deleteHelper(curr, target *kdNode[P], depth int) *kdNode[P] {
+	if curr == nil {
+		return nil
+	}
+	axis := depth % kd.dims
+
+	if curr != target {
+		if kd.coord(target.point, axis) < kd.coord(curr.point, axis) {
+			curr.left = kd.deleteHelper(curr.left, target, depth+1)
+		} else {
+			curr.right = kd.deleteHelper(curr.right, target, depth+1)
+		}
+		return curr
+	}
+
+	switch {
+	case curr.right != nil:
+		m := kd.findMin(curr.right, axis, depth+1)
+		curr.point = m.point
+		curr.right = kd.deleteHelper(curr.right, m, depth+1)
+	case curr.left != nil:
+		m := kd.findMin(curr.left, axis, depth+1)
+		curr.point = m.point
+		curr.right = kd.deleteHelper(curr.left, m, depth+1)
+		curr.left = nil
+	default:
+		return nil
+	}
+	return curr
+}
+
+// findMin returns node with min coord by `axis` in subtree `curr`.
+// If there are several such nodes, the shallowest one is returned
+func (kd *kdTree[P]) findMin(curr *kdNode[P], axis, depth int) *kdNode[P] {
+	if curr == nil {
+		return nil
+	}
+	// right subtree of node which splits by `axis` has only coords >= node's coord
+	if depth%kd.dims == axis {
+		if curr.left == nil {
+			return curr
+		}
+		return kd.minNode(curr, kd.findMin(curr.left, axis, depth+1), axis)
+	}
+	res := kd.minNode(curr, kd.findMin(curr.left, axis, depth+1), axis)
+	return kd.minNode(res, kd.findMin(curr.right, axis, depth+1), axis)
+}
+
+func (kd *kdTree[P]) minNode(a, b *kdNode[P], axis int) *kdNode[P] {
+	if b == nil || kd.coord(a.point, axis) <= kd.coord(b.point, axis) {
+		return a
+	}
+	return b
+}
+
+// nearestHelper goes to the side of `q` first,
+// the other side is checked only if splitting plane is closer than best point
+func (kd *kdTree[P]) nearestHelper(curr *kdNode[P], q P, depth int, best **kdNode[P], bestDist *float64) {
+	if curr == nil {
+		return
+	}
+	if d := kd.dist(q, curr.point); *best == nil || d < *bestDist {
+		*best, *bestDist = curr, d
+	}
+
+	axis := depth % kd.dims
+	diff := kd.coord(q, axis) - kd.coord(curr.point, axis)
+	near, far := curr.right, curr.left
+	if diff < 0 {
+		near, far = far, near
+	}
+	kd.nearestHelper(near, q, depth+1, best, bestDist)
+	if diff*diff < *bestDist {
+		kd.nearestHelper(far, q, depth+1, best, bestDist)
+	}
+}
+
+// kdCandidate is the point of KNearest with its squared distance to query
+type kdCandidate[P any] struct {
+	node *kdNode[P]
+	dist float64
+}
+
+// maxDistComparator makes Max-Heap by distance:
+// bounded Max-Heap keeps k nearest points, the farthest one is on top
+func maxDistComparator[P any](a, b kdCandidate[P]) int {
+	if a.dist < b.dist {
+		return -1
+	} else if a.dist > b.dist {
+		return 1
+	}
+	return 0
+}
+
+func (kd *kdTree[P]) kNearestHelper(q P, k int) []P {
+	k = min(k, kd.size)
+	if k <= 0 {
+		return nil
+	}
+	h := heaps.NewBoundedHeap(k, maxDistComparator[P])
+	kd.kNearestVisit(kd.root, q, 0, h, k)
+
+	// Extract returns the farthest first
+	res := make([]P, h.Size())
+	for i := len(res) - 1; i >= 0; i-- {
+		c, _ := h.Extract()
+		res[i] = c.node.point
+	}
+	return res
+}
+
+// kNearestVisit is like nearestHelper, but the other side is checked
+// if heap is not full yet or splitting plane is closer than the farthest of k points
+func (kd *kdTree[P]) kNearestVisit(curr *kdNode[P], q P, depth int, h heaps.Heap[kdCandidate[P]], k int) {
+	if curr == nil {
+		return
+	}
+	h.Insert(kdCandidate[P]{node: curr, dist: kd.dist(q, curr.point)})
+
+	axis := depth % kd.dims
+	diff := kd.coord(q, axis) - kd.coord(curr.point, axis)
+	near, far := curr.right, curr.left
+	if diff < 0 {
+		near, far = far, near
+	}
+	kd.kNearestVisit(near, q, depth+1, h, k)
+
+	if h.Size() < k {
+		kd.kNearestVisit(far, q, depth+1, h, k)
+	} else if top, _ := h.Peek(); diff*diff < top.dist {
+		kd.kNearestVisit(far, q, depth+1, h, k)
+	}
+}
+
+func (kd *kdTree[P]) rangeHelper(curr *kdNode[P], lo, hi P, depth int, res *[]P) {
+	if curr == nil {
+		return
+	}
+	inside := true
+	for axis := 0; axis < kd.dims; axis++ {
+		c := kd.coord(curr.point, axis)
+		if c < kd.coord(lo, axis) || c > kd.coord(hi, axis) {
+			inside = false
+			break
+		}
+	}
+	if inside {
+		*res = append(*res, curr.point)
+	}
+
+	axis := depth % kd.dims
+	c := kd.coord(curr.point, axis)
+	if kd.coord(lo, axis) < c {
+		kd.rangeHelper(curr.left, lo, hi, depth+1, res)
+	}
+	if kd.coord(hi, axis) >= c {
+		kd.rangeHelper(curr.right, lo, hi, depth+1, res)
+	}
+}
+
+// radiusHelper collects points with squared distance <= r2
+func (kd *kdTree[P]) radiusHelper(curr *kdNode[P], q P, r2 float64, depth int, res *[]P) {
+	if curr == nil {
+		return
+	}
+	if kd.dist(q, curr.point) <= r2 {
+		*res = append(*res, curr.point)
+	}
+
+	axis := depth % kd.dims
+	diff := kd.coord(q, axis) - kd.coord(curr.point, axis)
+	if diff < 0 || diff*diff <= r2 {
+		kd.radiusHelper(curr.left, q, r2, depth+1, res)
+	}
+	if diff >= 0 || diff*diff <= r2 {
+		kd.radiusHelper(curr.right, q, r2, depth+1, res)
+	}
+}
+
+func (kd *kdTree[P]) preOrderHelper(curr *kdNode[P], res *[]P) {
+	if curr == nil {
+		return
+	}
+	*res = append(*res, curr.point)
+	kd.preOrderHelper(curr.left, res)
+	kd.preOrderHelper(curr.right, res)
+}
@@ -0,0 +1,211 @@
+package trees
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKDTree_Basic(t *testing.T) {
+	var tr KDTree[[]float64] = NewKDTreeFrom([][]float64{
+		{2, 3}, {5, 4}, {9, 6}, {4, 7}, {8, 1}, {7, 2},
+	}, 2, SliceCoord)
+	assert.Equal(t, 6, tr.Size())
+
+	p, err := tr.Nearest([]float64{9, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{8, 1}, *p)
+
+	assert.Equal(t, [][]float64{{8, 1}, {7, 2}, {9, 6}}, tr.KNearest([]float64{9, 2}, 3))
+	assert.Len(t, tr.KNearest([]float64{0, 0}, 100), 6)
+	assert.Empty(t, tr.KNearest([]float64{0, 0}, 0))
+
+	box := tr.RangeSearch([]float64{4, 2}, []float64{8, 7})
+	assert.ElementsMatch(t, [][]float64{{5, 4}, {4, 7}, {7, 2}}, box)
+
+	near := tr.RadiusSearch([]float64{5, 5}, 1.5)
+	assert.ElementsMatch(t, [][]float64{{5, 4}}, near)
+
+	require.NoError(t, tr.Delete([]float64{8, 1}))
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), tr.Delete([]float64{8, 1}))
+	p, _ = tr.Nearest([]float64{9, 2})
+	assert.Equal(t, []float64{7, 2}, *p)
+
+	tr.Insert([]float64{9, 2})
+	p, _ = tr.Nearest([]float64{9, 2})
+	assert.Equal(t, []float64{9, 2}, *p)
+	assert.Equal(t, 6, tr.Size())
+}
+
+func TestKDTree_Empty(t *testing.T) {
+	tr := NewKDTree(3, SliceCoord)
+	assert.True(t, tr.IsEmpty())
+
+	_, err := tr.Nearest([]float64{1, 2, 3})
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+	assert.Empty(t, tr.KNearest([]float64{1, 2, 3}, 5))
+	assert.Empty(t, tr.RangeSearch([]float64{0, 0, 0}, []float64{9, 9, 9}))
+	assert.Error(t, tr.Delete([]float64{1, 2, 3}))
+}
+
+func TestKDTree_Payload(t *testing.T) {
+	type city struct {
+		name     string
+		lat, lon float64
+	}
+	coord := func(c city, axis int) float64 {
+		if axis == 0 {
+			return c.lat
+		}
+		return c.lon
+	}
+	tr := NewKDTreeFrom([]city{
+		{"Moscow", 55.75, 37.62}, {"Berlin", 52.52, 13.40}, {"Paris", 48.86, 2.35},
+	}, 2, coord)
+
+	c, err := tr.Nearest(city{lat: 53, lon: 12})
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", c.name)
+
+	// same coords, another payload
+	tr.Insert(city{"Berlin-2", 52.52, 13.40})
+	require.NoError(t, tr.Delete(city{lat: 52.52, lon: 13.40}))
+	c, _ = tr.Nearest(city{lat: 53, lon: 12})
+	assert.Contains(t, []string{"Berlin", "Berlin-2"}, c.name)
+	assert.Equal(t, 3, tr.Size())
+}
+
+func TestKDTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const dims = 3
+	randPoint := func() []float64 {
+		p := make([]float64, dims)
+		for i := range p {
+			// small grid, so there are a lot of equal coords
+			p[i] = float64(rnd.Intn(20))
+		}
+		return p
+	}
+
+	var all [][]float64
+	for i := 0; i < 200; i++ {
+		all = append(all, randPoint())
+	}
+	tr := NewKDTreeFrom(all, dims, SliceCoord)
+	checkKDTree(t, tr)
+
+	for op := 0; op < 1000; op++ {
+		switch rnd.Intn(4) {
+		case 0:
+			p := randPoint()
+			tr.Insert(p)
+			all = append(all, p)
+		case 1:
+			if len(all) > 0 {
+				i := rnd.Intn(len(all))
+				require.NoError(t, tr.Delete(all[i]))
+				all = append(all[:i], all[i+1:]...)
+			}
+		default:
+		}
+		require.Equal(t, len(all), tr.Size())
+		checkKDTree(t, tr)
+
+		q := randPoint()
+		dists := make([]float64, len(all))
+		for i, p := range all {
+			dists[i] = tr.dist(q, p)
+		}
+		sort.Float64s(dists)
+
+		if len(all) > 0 {
+			p, err := tr.Nearest(q)
+			require.NoError(t, err)
+			require.Equal(t, dists[0], tr.dist(q, *p))
+		}
+
+		k := rnd.Intn(10) + 1
+		got := tr.KNearest(q, k)
+		require.Len(t, got, min(k, len(all)))
+		for i, p := range got {
+			require.Equal(t, dists[i], tr.dist(q, p), "k=%d i=%d", k, i)
+		}
+
+		r := float64(rnd.Intn(8))
+		expected := 0
+		for _, d := range dists {
+			if d <= r*r {
+				expected++
+			}
+		}
+		require.Len(t, tr.RadiusSearch(q, r), expected)
+
+		lo, hi := randPoint(), randPoint()
+		for i := range lo {
+			lo[i], hi[i] = math.Min(lo[i], hi[i]), math.Max(lo[i], hi[i])
+		}
+		var inBox [][]float64
+		for _, p := range all {
+			inside := true
+			for i := range p {
+				inside = inside && lo[i] <= p[i] && p[i] <= hi[i]
+			}
+			if inside {
+				inBox = append(inBox, p)
+			}
+		}
+		require.ElementsMatch(t, inBox, tr.RangeSearch(lo, hi))
+	}
+}
+
+// checkKDTree checks that left subtree is strictly less by node's axis
+// and right subtree is greater or equal
+func checkKDTree(t *testing.T, tr *kdTree[[]float64]) {
+	t.Helper()
+
+	var collect func(n *kdNode[[]float64]) [][]float64
+	collect = func(n *kdNode[[]float64]) [][]float64 {
+		if n == nil {
+			return nil
+		}
+		return slices.Concat([][]float64{n.point}, collect(n.left), collect(n.right))
+	}
+	var check func(n *kdNode[[]float64], depth int)
+	check = func(n *kdNode[[]float64], depth int) {
+		if n == nil {
+			return
+		}
+		axis := depth % tr.dims
+		for _, p := range collect(n.left) {
+			require.Less(t, p[axis], n.point[axis])
+		}
+		for _, p := range collect(n.right) {
+			require.GreaterOrEqual(t, p[axis], n.point[axis])
+		}
+		check(n.left, depth+1)
+		check(n.right, depth+1)
+	}
+	check(tr.root, 0)
+	require.Len(t, collect(tr.root), tr.size)
+}
+
+func BenchmarkKDTree_KNearest(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	points := make([][]float64, 1<<16)
+	for i := range points {
+		points[i] = []float64{rnd.Float64(), rnd.Float64()}
+	}
+	tr := NewKDTreeFrom(points, 2, SliceCoord)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tr.KNearest([]float64{rnd.Float64(), rnd.Float64()}, 10)
+	}
+}
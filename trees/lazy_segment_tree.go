@@ -0,0 +1,166 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// LazyOp describes range update of type U for Segment Tree with values of type T:
+//
+// # Apply 	-> returns aggregated value of segment with `size` elements after update `u`
+//
+// # Compose 	-> returns one update equal to `older` and then `newer`
+//
+// Range add on sums:
+//
+//	add := trees.LazyOp[int, int]{
+//		Apply:   func(val, u, size int) int { return val + u*size },
+//		Compose: func(newer, older int) int { return newer + older },
+//	}
+type LazyOp[T any, U any] struct {
+	Apply   func(val T, u U, size int) T
+	Compose func(newer, older U) U
+}
+
+// LazySegmentTree is Segment Tree that can update whole range at once
+type LazySegmentTree[T any, U any] interface {
+	SegmentTree[T]
+
+	// RangeUpdate applies update `u` to all values in [l, r)
+	RangeUpdate(l, r int, u U) error
+}
+
+// lazySegmentTree - Segment Tree with lazy propagation.
+//
+// RangeUpdate doesn't go down to leaves: it updates aggregated value of
+// fully covered node and remembers the update in lazy[node].
+// Lazy update is pushed to children only when somebody goes through this node.
+//
+// # tree 	-> aggregated values, root is 1, children of i are 2i and 2i+1
+//
+// # lazy 	-> pending update for children of node
+//
+// # pending 	-> true if node has pending update
+type lazySegmentTree[T any, U any] struct {
+	tree    []T
+	lazy    []U
+	pending []bool
+	n       int
+
+	mu sync.Mutex
+
+	m  Monoid[T]
+	op LazyOp[T, U]
+}
+
+// NewLazySegmentTree builds Segment Tree with range updates from `items` in O(n).
+// `items` is not modified
+func NewLazySegmentTree[T any, U any](items []T, m Monoid[T], op LazyOp[T, U]) *lazySegmentTree[T, U] {
+	n := len(items)
+	st := &lazySegmentTree[T, U]{
+		tree:    make([]T, 4*max(n, 1)),
+		lazy:    make([]U, 4*max(n, 1)),
+		pending: make([]bool, 4*max(n, 1)),
+		n:       n,
+		m:       m,
+		op:      op,
+	}
+	if n > 0 {
+		st.build(1, 0, n, items)
+	}
+	return st
+}
+
+// Query returns Combine of all values in [l, r)
+func (st *lazySegmentTree[T, U]) Query(l, r int) (T, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if l < 0 || r > st.n || l > r {
+		return st.m.Identity, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	if l == r {
+		return st.m.Identity, nil
+	}
+	return st.queryHelper(1, 0, st.n, l, r), nil
+}
+
+// RangeUpdate applies update `u` to all values in [l, r)
+func (st *lazySegmentTree[T, U]) RangeUpdate(l, r int, u U) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if l < 0 || r > st.n || l > r {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	if l < r {
+		st.updateHelper(1, 0, st.n, l, r, u)
+	}
+	return nil
+}
+
+// Update sets value at index `i`
+func (st *lazySegmentTree[T, U]) Update(i int, val T) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if i < 0 || i >= st.n {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	st.setHelper(1, 0, st.n, i, val)
+	return nil
+}
+
+// Get returns value at index `i`
+func (st *lazySegmentTree[T, U]) Get(i int) (*T, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if i < 0 || i >= st.n {
+		return nil, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	val := st.queryHelper(1, 0, st.n, i, i+1)
+	return &val, nil
+}
+
+// Size returns number of values
+func (st *lazySegmentTree[T, U]) Size() int {
+	return st.n
+}
+
+// ==========================================================================================
+
+// RangeAddSum returns range add for SumMonoid
+func RangeAddSum[N Number]() LazyOp[N, N] {
+	return LazyOp[N, N]{
+		Apply:   func(val, u N, size int) N { return val + u*N(size) },
+		Compose: func(newer, older N) N { return newer + older },
+	}
+}
+
+// RangeAssignSum returns range assign for SumMonoid
+func RangeAssignSum[N Number]() LazyOp[N, N] {
+	return LazyOp[N, N]{
+		Apply:   func(_, u N, size int) N { return u * N(size) },
+		Compose: func(newer, _ N) N { return newer },
+	}
+}
+
+// RangeAddMinMax returns range add for MinMonoid and MaxMonoid
+func RangeAddMinMax[N Number]() LazyOp[N, N] {
+	return LazyOp[N, N]{
+		Apply:   func(val, u N, _ int) N { return val + u },
+		Compose: func(newer, older N) N { return newer + older },
+	}
+}
+
+// RangeAssign returns range assign for monoids where
+// Combine(x, x) == x (MinMonoid, MaxMonoid, gcd, and, or ...)
+func RangeAssign[T any]() LazyOp[T, T] {
+	return LazyOp[T, T]{
+		Apply:   func(_, u T, _ int) T { return u },
+		Compose: func(newer, _ T) T { return newer },
+	}
+}
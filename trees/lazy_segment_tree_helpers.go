@@ -0,0 +1,91 @@
+package trees
+
+// build fills node which covers [lo, hi) and all its children
+func (st *lazySegmentTree[T, U]) build(node, lo, hi int, items []T) {
+	if hi-lo == 1 {
+		st.tree[node] = items[lo]
+		return
+	}
+	mid := (lo + hi) / 2
+	st.build(2*node, lo, mid, items)
+	st.build(2*node+1, mid, hi, items)
+	st.tree[node] = st.m.Combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+// apply updates aggregated value of node and remembers update for its children
+func (st *lazySegmentTree[T, U]) apply(node, lo, hi int, u U) {
+	st.tree[node] = st.op.Apply(st.tree[node], u, hi-lo)
+	if hi-lo == 1 {
+		return
+	}
+	if st.pending[node] {
+		st.lazy[node] = st.op.Compose(u, st.lazy[node])
+	} else {
+		st.lazy[node] = u
+		st.pending[node] = true
+	}
+}
+
+// push gives pending update of node to its children
+func (st *lazySegmentTree[T, U]) push(node, lo, hi int) {
+	if !st.pending[node] {
+		return
+	}
+	mid := (lo + hi) / 2
+	st.apply(2*node, lo, mid, st.lazy[node])
+	st.apply(2*node+1, mid, hi, st.lazy[node])
+
+	var zero U
+	st.lazy[node] = zero
+	st.pending[node] = false
+}
+
+func (st *lazySegmentTree[T, U]) queryHelper(node, lo, hi, l, r int) T {
+	if l <= lo && hi <= r {
+		return st.tree[node]
+	}
+	st.push(node, lo, hi)
+
+	mid := (lo + hi) / 2
+	res := st.m.Identity
+	if l < mid {
+		res = st.queryHelper(2*node, lo, mid, l, r)
+	}
+	if r > mid {
+		res = st.m.Combine(res, st.queryHelper(2*node+1, mid, hi, l, r))
+	}
+	return res
+}
+
+func (st *lazySegmentTree[T, U]) updateHelper(node, lo, hi, l, r int, u U) {
+	if l <= lo && hi <= r {
+		st.apply(node, lo, hi, u)
+		return
+	}
+	st.push(node, lo, hi)
+
+	mid := (lo + hi) / 2
+	if l < mid {
+		st.updateHelper(2*node, lo, mid, l, r, u)
+	}
+	if r > mid {
+		st.updateHelper(2*node+1, mid, hi, l, r, u)
+	}
+	st.tree[node] = st.m.Combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *lazySegmentTree[T, U]) setHelper(node, lo, hi, i int, val T) {
+	if hi-lo == 1 {
+		st.tree[node] = val
+		return
+	}
+	st.push(node, lo, hi)
+
+	mid := (lo + hi) / 2
+	if i < mid {
+		st.setHelper(2*node, lo, mid, i, val)
+	} else {
+		st.setHelper(2*node+1, mid, hi, i, val)
+	}
+	st.tree[node] = st.m.Combine(st.tree[2*node], st.tree[2*node+1])
+}
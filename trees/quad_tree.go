@@ -0,0 +1,171 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+const (
+	// defaultQuadCapacity is the max number of points in leaf before split
+	defaultQuadCapacity = 8
+
+	// defaultQuadMaxDepth limits splits when a lot of points have the same coords
+	defaultQuadMaxDepth = 16
+)
+
+// QuadTree is the interface of Quad Tree - index of points on 2D plane
+//
+//	tr := trees.NewQuadTree[string](trees.NewRect(-180, -90, 180, 90))
+//	_ = tr.Insert(trees.Point{X: 37.62, Y: 55.75}, "Moscow")
+//	_ = tr.Insert(trees.Point{X: 13.40, Y: 52.52}, "Berlin")
+//	items := tr.Query(trees.NewRect(0, 40, 20, 60)) // Berlin
+type QuadTree[T comparable] interface {
+	// Insert adds point `p` with payload `val`
+	//
+	// if `p` is out of tree bounds -> returns err
+	Insert(p Point, val T) error
+
+	// Delete deletes point `p` with payload `val`
+	//
+	// if there is no such point -> returns err
+	Delete(p Point, val T) error
+
+	// Query returns all points inside `r`
+	Query(r Rect) []PointItem[T]
+
+	// Nearest returns the nearest point to `p`
+	//
+	// if tree is empty -> val = nil, err != nil
+	Nearest(p Point) (*PointItem[T], error)
+
+	// KNearest returns at most `k` nearest points to `p` sorted by distance
+	KNearest(p Point, k int) []PointItem[T]
+
+	// Bounds returns the area covered by tree
+	Bounds() Rect
+
+	Size() int
+	IsEmpty() bool
+}
+
+// quadTree - bucket point-region Quad Tree.
+//
+// Every node covers a region. Leaf keeps up to `capacity` points,
+// on overflow it is split into 4 equal quadrants (unless it is on `maxDepth`).
+// If after Delete subtree has <= `capacity` points, it is merged back to one leaf
+type quadTree[T comparable] struct {
+	root *quadNode[T]
+	size int
+
+	capacity int
+	maxDepth int
+
+	mu sync.Mutex
+}
+
+// quadNode is the node of Quad Tree
+//
+// # items 	-> points of leaf
+//
+// # children 	-> nil for leaf, otherwise quadrants: SW, SE, NW, NE
+//
+// # count 	-> number of points in subtree
+type quadNode[T comparable] struct {
+	bounds   Rect
+	items    []PointItem[T]
+	children *[4]*quadNode[T]
+	count    int
+}
+
+// NewQuadTree creates Quad Tree which covers `bounds` with default config
+func NewQuadTree[T comparable](bounds Rect) *quadTree[T] {
+	return NewQuadTreeWithConfig[T](bounds, defaultQuadCapacity, defaultQuadMaxDepth)
+}
+
+// NewQuadTreeWithConfig creates Quad Tree which covers `bounds`.
+//
+// # capacity 	-> max number of points in leaf before split (>= 1)
+//
+// # maxDepth 	-> leaf on maxDepth is never split (>= 0)
+func NewQuadTreeWithConfig[T comparable](bounds Rect, capacity, maxDepth int) *quadTree[T] {
+	return &quadTree[T]{
+		root:     &quadNode[T]{bounds: bounds},
+		capacity: max(capacity, 1),
+		maxDepth: max(maxDepth, 0),
+	}
+}
+
+// Insert adds point `p` with payload `val`
+func (qt *quadTree[T]) Insert(p Point, val T) error {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	if !qt.root.bounds.Contains(p) {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	qt.insertHelper(qt.root, PointItem[T]{P: p, Val: val}, 0)
+	qt.size++
+	return nil
+}
+
+// Delete deletes point `p` with payload `val`
+func (qt *quadTree[T]) Delete(p Point, val T) error {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	if !qt.root.bounds.Contains(p) || !qt.deleteHelper(qt.root, PointItem[T]{P: p, Val: val}) {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	qt.size--
+	return nil
+}
+
+// Query returns all points inside `r`
+func (qt *quadTree[T]) Query(r Rect) []PointItem[T] {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	var res []PointItem[T]
+	qt.queryHelper(qt.root, r, &res)
+	return res
+}
+
+// Nearest returns the nearest point to `p`
+func (qt *quadTree[T]) Nearest(p Point) (*PointItem[T], error) {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	res := qt.kNearestHelper(p, 1)
+	if len(res) == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return &res[0], nil
+}
+
+// KNearest returns at most `k` nearest points to `p` sorted by distance
+func (qt *quadTree[T]) KNearest(p Point, k int) []PointItem[T] {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	return qt.kNearestHelper(p, k)
+}
+
+// Bounds returns the area covered by tree
+func (qt *quadTree[T]) Bounds() Rect {
+	return qt.root.bounds
+}
+
+// Size returns number of points
+func (qt *quadTree[T]) Size() int {
+	qt.mu.Lock()
+	defer qt.mu.Unlock()
+
+	return qt.size
+}
+
+// IsEmpty returns true if there are no points
+func (qt *quadTree[T]) IsEmpty() bool {
+	return qt.Size() == 0
+}
@@ -0,0 +1,135 @@
+package trees
+
+import (
+	"math"
+
+	"github.com/0x0FACED/go-collections/heaps"
+)
+
+func (qt *quadTree[T]) insertHelper(node *quadNode[T], item PointItem[T], depth int) {
+	node.count++
+	if node.children != nil {
+		qt.insertHelper(node.children[node.quadrant(item.P)], item, depth+1)
+		return
+	}
+
+	node.items = append(node.items, item)
+	if len(node.items) > qt.capacity && depth < qt.maxDepth {
+		qt.split(node, depth)
+	}
+}
+
+// split makes 4 children and moves points of leaf to them
+func (qt *quadTree[T]) split(node *quadNode[T], depth int) {
+	b := node.bounds
+	mid := Point{X: (b.Min.X + b.Max.X) / 2, Y: (b.Min.Y + b.Max.Y) / 2}
+	node.children = &[4]*quadNode[T]{
+		{bounds: Rect{Min: b.Min, Max: mid}},
+		{bounds: Rect{Min: Point{mid.X, b.Min.Y}, Max: Point{b.Max.X, mid.Y}}},
+		{bounds: Rect{Min: Point{b.Min.X, mid.Y}, Max: Point{mid.X, b.Max.Y}}},
+		{bounds: Rect{Min: mid, Max: b.Max}},
+	}
+
+	items := node.items
+	node.items = nil
+	for _, item := range items {
+		// all points may go to one child, so child can be split too
+		qt.insertHelper(node.children[node.quadrant(item.P)], item, depth+1)
+	}
+}
+
+// quadrant returns index of child which covers `p`.
+// Point on the border between children goes to the east/north one
+func (node *quadNode[T]) quadrant(p Point) int {
+	b := node.bounds
+	i := 0
+	if p.X >= (b.Min.X+b.Max.X)/2 {
+		i++
+	}
+	if p.Y >= (b.Min.Y+b.Max.Y)/2 {
+		i += 2
+	}
+	return i
+}
+
+// deleteHelper returns true if item was deleted
+func (qt *quadTree[T]) deleteHelper(node *quadNode[T], item PointItem[T]) bool {
+	if node.children == nil {
+		for i, it := range node.items {
+			if it == item {
+				node.items = append(node.items[:i], node.items[i+1:]...)
+				node.count--
+				return true
+			}
+		}
+		return false
+	}
+
+	if !qt.deleteHelper(node.children[node.quadrant(item.P)], item) {
+		return false
+	}
+	node.count--
+	if node.count <= qt.capacity {
+		qt.merge(node)
+	}
+	return true
+}
+
+// merge collects all points of subtree to node and makes it leaf
+func (qt *quadTree[T]) merge(node *quadNode[T]) {
+	items := make([]PointItem[T], 0, node.count)
+	qt.queryHelper(node, node.bounds, &items)
+	node.items = items
+	node.children = nil
+}
+
+func (qt *quadTree[T]) queryHelper(node *quadNode[T], r Rect, res *[]PointItem[T]) {
+	if !node.bounds.Intersects(r) {
+		return
+	}
+	if node.children == nil {
+		for _, item := range node.items {
+			if r.Contains(item.P) {
+				*res = append(*res, item)
+			}
+		}
+		return
+	}
+	for _, child := range node.children {
+		qt.queryHelper(child, r, res)
+	}
+}
+
+// kNearestHelper is best-first search: nodes and points are taken from queue
+// by distance to `p`. When point is taken, all the rest are not closer
+func (qt *quadTree[T]) kNearestHelper(p Point, k int) []PointItem[T] {
+	type candidate = spatialCandidate[quadNode[T], PointItem[T]]
+
+	var res []PointItem[T]
+	if k <= 0 || qt.size == 0 {
+		return res
+	}
+	pq := heaps.NewHeap(nearestFirst[quadNode[T], PointItem[T]])
+	pq.Insert(candidate{node: qt.root})
+
+	for len(res) < k && !pq.IsEmpty() {
+		c, _ := pq.Extract()
+		if c.isItem {
+			res = append(res, c.item)
+			continue
+		}
+		node := c.node
+		if node.children == nil {
+			for _, item := range node.items {
+				pq.Insert(candidate{item: item, isItem: true, dist: math.Hypot(item.P.X-p.X, item.P.Y-p.Y)})
+			}
+			continue
+		}
+		for _, child := range node.children {
+			if child.count > 0 {
+				pq.Insert(candidate{node: child, dist: child.bounds.Distance(p)})
+			}
+		}
+	}
+	return res
+}
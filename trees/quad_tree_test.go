@@ -0,0 +1,123 @@
+package trees
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuadTree_Basic(t *testing.T) {
+	var tr QuadTree[string] = NewQuadTreeWithConfig[string](NewRect(-180, -90, 180, 90), 2, 8)
+
+	require.NoError(t, tr.Insert(Point{37.62, 55.75}, "Moscow"))
+	require.NoError(t, tr.Insert(Point{13.40, 52.52}, "Berlin"))
+	require.NoError(t, tr.Insert(Point{2.35, 48.86}, "Paris"))
+	require.NoError(t, tr.Insert(Point{-74.01, 40.71}, "New York"))
+	require.NoError(t, tr.Insert(Point{139.69, 35.69}, "Tokyo"))
+	assert.Equal(t, 5, tr.Size())
+
+	europe := tr.Query(NewRect(-10, 35, 40, 70))
+	var names []string
+	for _, it := range europe {
+		names = append(names, it.Val)
+	}
+	assert.ElementsMatch(t, []string{"Moscow", "Berlin", "Paris"}, names)
+
+	c, err := tr.Nearest(Point{10, 50})
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", c.Val)
+
+	near := tr.KNearest(Point{10, 50}, 2)
+	require.Len(t, near, 2)
+	assert.Equal(t, "Paris", near[1].Val)
+
+	assert.Equal(t, errors.New(gocollections.ErrOutOfBounds), tr.Insert(Point{200, 0}, "Mars"))
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), tr.Delete(Point{13.40, 52.52}, "Paris"))
+
+	require.NoError(t, tr.Delete(Point{13.40, 52.52}, "Berlin"))
+	c, _ = tr.Nearest(Point{10, 50})
+	assert.Equal(t, "Paris", c.Val)
+	assert.Equal(t, 4, tr.Size())
+}
+
+func TestQuadTree_SamePoints(t *testing.T) {
+	tr := NewQuadTreeWithConfig[int](NewRect(0, 0, 1, 1), 1, 4)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, tr.Insert(Point{0.5, 0.5}, i))
+	}
+	assert.Len(t, tr.Query(NewRect(0.5, 0.5, 0.5, 0.5)), 100)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, tr.Delete(Point{0.5, 0.5}, i))
+	}
+	assert.True(t, tr.IsEmpty())
+	assert.Nil(t, tr.root.children)
+
+	_, err := tr.Nearest(Point{0, 0})
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+}
+
+func TestQuadTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tr := NewQuadTreeWithConfig[int](NewRect(0, 0, 100, 100), 4, 10)
+	var all []PointItem[int]
+
+	randPoint := func() Point {
+		return Point{float64(rnd.Intn(1001)) / 10, float64(rnd.Intn(1001)) / 10}
+	}
+	for op := 0; op < 2000; op++ {
+		if rnd.Intn(3) > 0 || len(all) == 0 {
+			item := PointItem[int]{P: randPoint(), Val: op}
+			require.NoError(t, tr.Insert(item.P, item.Val))
+			all = append(all, item)
+		} else {
+			i := rnd.Intn(len(all))
+			require.NoError(t, tr.Delete(all[i].P, all[i].Val))
+			all = append(all[:i], all[i+1:]...)
+		}
+		require.Equal(t, len(all), tr.Size())
+		require.Equal(t, len(all), tr.root.count)
+
+		p := randPoint()
+		r := NewRect(p.X, p.Y, p.X+float64(rnd.Intn(30)), p.Y+float64(rnd.Intn(30)))
+		var expected []PointItem[int]
+		for _, it := range all {
+			if r.Contains(it.P) {
+				expected = append(expected, it)
+			}
+		}
+		require.ElementsMatch(t, expected, tr.Query(r))
+
+		dists := make([]float64, len(all))
+		for i, it := range all {
+			dists[i] = math.Hypot(it.P.X-p.X, it.P.Y-p.Y)
+		}
+		sort.Float64s(dists)
+		k := rnd.Intn(5) + 1
+		got := tr.KNearest(p, k)
+		require.Len(t, got, min(k, len(all)))
+		for i, it := range got {
+			require.Equal(t, dists[i], math.Hypot(it.P.X-p.X, it.P.Y-p.Y))
+		}
+	}
+}
+
+func BenchmarkQuadTree_Query(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	tr := NewQuadTree[int](NewRect(0, 0, 1, 1))
+	for i := 0; i < 1<<16; i++ {
+		_ = tr.Insert(Point{rnd.Float64(), rnd.Float64()}, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, y := rnd.Float64(), rnd.Float64()
+		_ = tr.Query(NewRect(x, y, x+0.01, y+0.01))
+	}
+}
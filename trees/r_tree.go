@@ -0,0 +1,172 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// defaultRTreeMaxEntries is the max number of entries in node
+const defaultRTreeMaxEntries = 9
+
+// RTree is the interface of R-Tree - index of rectangles on 2D plane
+//
+//	tr := trees.NewRTree[string]()
+//	tr.Insert(trees.NewRect(0, 0, 256, 256), "tile-0-0")
+//	tr.Insert(trees.NewRect(256, 0, 512, 256), "tile-1-0")
+//	tiles := tr.Search(trees.NewRect(200, 100, 300, 120)) // both
+type RTree[T comparable] interface {
+	// Insert adds rectangle `r` with payload `val`
+	Insert(r Rect, val T)
+
+	// Delete deletes rectangle `r` with payload `val`
+	//
+	// if there is no such rectangle -> returns err
+	Delete(r Rect, val T) error
+
+	// Search returns all rectangles which intersect with `r`
+	Search(r Rect) []RectItem[T]
+
+	// Nearest returns the nearest rectangle to `p`
+	//
+	// if tree is empty -> val = nil, err != nil
+	Nearest(p Point) (*RectItem[T], error)
+
+	// KNearest returns at most `k` nearest rectangles to `p` sorted by distance
+	KNearest(p Point, k int) []RectItem[T]
+
+	// Bounds returns bounding box of all rectangles
+	//
+	// if tree is empty -> val = nil, err != nil
+	Bounds() (*Rect, error)
+
+	Size() int
+	IsEmpty() bool
+}
+
+// rTree - R-Tree with quadratic split (Guttman, 1984).
+//
+// Every node has from minEntries to maxEntries entries (root may have less)
+// and keeps bounding box of its subtree. All leaves are on the same level.
+// Rectangles of siblings may overlap, so Search may go to several children
+type rTree[T comparable] struct {
+	root *rNode[T]
+	size int
+
+	maxEntries int
+	minEntries int
+
+	mu sync.Mutex
+}
+
+// rNode is the node of R-Tree
+//
+// # items 	-> rectangles of leaf
+//
+// # children 	-> children of internal node
+type rNode[T comparable] struct {
+	bbox     Rect
+	leaf     bool
+	items    []RectItem[T]
+	children []*rNode[T]
+	parent   *rNode[T]
+}
+
+// NewRTree creates R-Tree with default max entries in node
+func NewRTree[T comparable]() *rTree[T] {
+	return NewRTreeWithConfig[T](defaultRTreeMaxEntries)
+}
+
+// NewRTreeWithConfig creates R-Tree with `maxEntries` in node (>= 4).
+// Min entries in node is 40% of max
+func NewRTreeWithConfig[T comparable](maxEntries int) *rTree[T] {
+	maxEntries = max(maxEntries, 4)
+	return &rTree[T]{
+		root:       &rNode[T]{leaf: true},
+		maxEntries: maxEntries,
+		minEntries: max(maxEntries*2/5, 2),
+	}
+}
+
+// Insert adds rectangle `r` with payload `val`
+func (rt *rTree[T]) Insert(r Rect, val T) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.insertItem(RectItem[T]{R: r, Val: val})
+	rt.size++
+}
+
+// Delete deletes rectangle `r` with payload `val`
+func (rt *rTree[T]) Delete(r Rect, val T) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	item := RectItem[T]{R: r, Val: val}
+	leaf, i := rt.findLeaf(rt.root, item)
+	if leaf == nil {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	leaf.items = append(leaf.items[:i], leaf.items[i+1:]...)
+	rt.condense(leaf)
+	rt.size--
+	return nil
+}
+
+// Search returns all rectangles which intersect with `r`
+func (rt *rTree[T]) Search(r Rect) []RectItem[T] {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	var res []RectItem[T]
+	if rt.size > 0 {
+		rt.searchHelper(rt.root, r, &res)
+	}
+	return res
+}
+
+// Nearest returns the nearest rectangle to `p`
+func (rt *rTree[T]) Nearest(p Point) (*RectItem[T], error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	res := rt.kNearestHelper(p, 1)
+	if len(res) == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return &res[0], nil
+}
+
+// KNearest returns at most `k` nearest rectangles to `p` sorted by distance
+func (rt *rTree[T]) KNearest(p Point, k int) []RectItem[T] {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.kNearestHelper(p, k)
+}
+
+// Bounds returns bounding box of all rectangles
+func (rt *rTree[T]) Bounds() (*Rect, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.size == 0 {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	bbox := rt.root.bbox
+	return &bbox, nil
+}
+
+// Size returns number of rectangles
+func (rt *rTree[T]) Size() int {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.size
+}
+
+// IsEmpty returns true if there are no rectangles
+func (rt *rTree[T]) IsEmpty() bool {
+	return rt.Size() == 0
+}
@@ -0,0 +1,304 @@
+package trees
+
+import "github.com/0x0FACED/go-collections/heaps"
+
+func (rt *rTree[T]) insertItem(item RectItem[T]) {
+	leaf := rt.chooseLeaf(item.R)
+	leaf.items = append(leaf.items, item)
+	rt.adjust(leaf)
+}
+
+// chooseLeaf goes down to the child whose bbox needs the least enlargement
+// to include `r`, if there are several such children - to the smallest one
+func (rt *rTree[T]) chooseLeaf(r Rect) *rNode[T] {
+	node := rt.root
+	for !node.leaf {
+		best := node.children[0]
+		bestEnl, bestArea := enlargement(best.bbox, r), best.bbox.Area()
+		for _, child := range node.children[1:] {
+			enl, area := enlargement(child.bbox, r), child.bbox.Area()
+			if enl < bestEnl || (enl == bestEnl && area < bestArea) {
+				best, bestEnl, bestArea = child, enl, area
+			}
+		}
+		node = best
+	}
+	return node
+}
+
+// enlargement returns how much area of `bbox` grows to include `r`
+func enlargement(bbox, r Rect) float64 {
+	return bbox.Union(r).Area() - bbox.Area()
+}
+
+// adjust goes up from node, splits overflowed nodes and updates bboxes
+func (rt *rTree[T]) adjust(node *rNode[T]) {
+	for node != nil {
+		if node.entries() > rt.maxEntries {
+			sibling := rt.split(node)
+			if node == rt.root {
+				rt.root = &rNode[T]{children: []*rNode[T]{node, sibling}}
+				node.parent, sibling.parent = rt.root, rt.root
+				rt.root.updateBBox()
+				return
+			}
+			sibling.parent = node.parent
+			node.parent.children = append(node.parent.children, sibling)
+		}
+		node.updateBBox()
+		node = node.parent
+	}
+}
+
+// split divides entries of node between node and new sibling.
+//
+// Quadratic split:
+//
+// 1. seeds of 2 groups are 2 entries which waste the most area if they are together
+//
+// 2. then the entry with the biggest difference of enlargements of groups
+// goes to group which needs less enlargement, and so on
+//
+// 3. if group needs all the rest to have minEntries -> all the rest go to it
+func (rt *rTree[T]) split(node *rNode[T]) *rNode[T] {
+	rects := node.rects()
+	inSecond := quadraticSplit(rects, rt.minEntries)
+
+	sibling := &rNode[T]{leaf: node.leaf}
+	if node.leaf {
+		items := node.items
+		node.items = nil
+		for i, item := range items {
+			if inSecond[i] {
+				sibling.items = append(sibling.items, item)
+			} else {
+				node.items = append(node.items, item)
+			}
+		}
+	} else {
+		children := node.children
+		node.children = nil
+		for i, child := range children {
+			if inSecond[i] {
+				sibling.children = append(sibling.children, child)
+				child.parent = sibling
+			} else {
+				node.children = append(node.children, child)
+			}
+		}
+	}
+	node.updateBBox()
+	sibling.updateBBox()
+	return sibling
+}
+
+// quadraticSplit returns for every rect true if it goes to the second group
+func quadraticSplit(rects []Rect, minEntries int) []bool {
+	n := len(rects)
+
+	// pick seeds
+	s1, s2, worst := 0, 1, -1.0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			d := rects[i].Union(rects[j]).Area() - rects[i].Area() - rects[j].Area()
+			if d > worst {
+				s1, s2, worst = i, j, d
+			}
+		}
+	}
+
+	inSecond := make([]bool, n)
+	assigned := make([]bool, n)
+	assigned[s1], assigned[s2] = true, true
+	inSecond[s2] = true
+	bbox1, bbox2 := rects[s1], rects[s2]
+	count1, count2 := 1, 1
+
+	for left := n - 2; left > 0; left-- {
+		if count1+left <= minEntries || count2+left <= minEntries {
+			toSecond := count2+left <= minEntries
+			for i := range rects {
+				if !assigned[i] {
+					assigned[i], inSecond[i] = true, toSecond
+				}
+			}
+			return inSecond
+		}
+
+		// pick next
+		next, nextDiff := -1, -1.0
+		var d1, d2 float64
+		for i, r := range rects {
+			if assigned[i] {
+				continue
+			}
+			e1, e2 := enlargement(bbox1, r), enlargement(bbox2, r)
+			diff := e1 - e2
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff > nextDiff {
+				next, nextDiff, d1, d2 = i, diff, e1, e2
+			}
+		}
+
+		toSecond := d2 < d1
+		if d1 == d2 {
+			a1, a2 := bbox1.Area(), bbox2.Area()
+			toSecond = a2 < a1 || (a1 == a2 && count2 < count1)
+		}
+		assigned[next] = true
+		if toSecond {
+			inSecond[next] = true
+			bbox2 = bbox2.Union(rects[next])
+			count2++
+		} else {
+			bbox1 = bbox1.Union(rects[next])
+			count1++
+		}
+	}
+	return inSecond
+}
+
+// findLeaf returns leaf with item and index of item in leaf, or nil
+func (rt *rTree[T]) findLeaf(node *rNode[T], item RectItem[T]) (*rNode[T], int) {
+	if node.leaf {
+		for i, it := range node.items {
+			if it == item {
+				return node, i
+			}
+		}
+		return nil, -1
+	}
+	for _, child := range node.children {
+		if child.bbox.ContainsRect(item.R) {
+			if leaf, i := rt.findLeaf(child, item); leaf != nil {
+				return leaf, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// condense goes up from leaf after Delete. Nodes with less than minEntries
+// are removed, their rectangles are inserted again.
+// If root has only one child, child becomes root
+func (rt *rTree[T]) condense(leaf *rNode[T]) {
+	var orphans []RectItem[T]
+	for node := leaf; node != rt.root; {
+		parent := node.parent
+		if node.entries() < rt.minEntries {
+			for i, child := range parent.children {
+				if child == node {
+					parent.children = append(parent.children[:i], parent.children[i+1:]...)
+					break
+				}
+			}
+			orphans = node.collect(orphans)
+		} else {
+			node.updateBBox()
+		}
+		node = parent
+	}
+	rt.root.updateBBox()
+
+	for !rt.root.leaf && len(rt.root.children) == 1 {
+		rt.root = rt.root.children[0]
+		rt.root.parent = nil
+	}
+	if !rt.root.leaf && len(rt.root.children) == 0 {
+		rt.root = &rNode[T]{leaf: true}
+	}
+
+	for _, item := range orphans {
+		rt.insertItem(item)
+	}
+}
+
+func (rt *rTree[T]) searchHelper(node *rNode[T], r Rect, res *[]RectItem[T]) {
+	if !node.bbox.Intersects(r) {
+		return
+	}
+	if node.leaf {
+		for _, item := range node.items {
+			if item.R.Intersects(r) {
+				*res = append(*res, item)
+			}
+		}
+		return
+	}
+	for _, child := range node.children {
+		rt.searchHelper(child, r, res)
+	}
+}
+
+// kNearestHelper is best-first search like in Quad Tree:
+// distance to bbox of node is never more than distance to its rectangles
+func (rt *rTree[T]) kNearestHelper(p Point, k int) []RectItem[T] {
+	type candidate = spatialCandidate[rNode[T], RectItem[T]]
+
+	var res []RectItem[T]
+	if k <= 0 || rt.size == 0 {
+		return res
+	}
+	pq := heaps.NewHeap(nearestFirst[rNode[T], RectItem[T]])
+	pq.Insert(candidate{node: rt.root})
+
+	for len(res) < k && !pq.IsEmpty() {
+		c, _ := pq.Extract()
+		if c.isItem {
+			res = append(res, c.item)
+			continue
+		}
+		for _, item := range c.node.items {
+			pq.Insert(candidate{item: item, isItem: true, dist: item.R.Distance(p)})
+		}
+		for _, child := range c.node.children {
+			pq.Insert(candidate{node: child, dist: child.bbox.Distance(p)})
+		}
+	}
+	return res
+}
+
+// entries returns number of items of leaf or children of internal node
+func (node *rNode[T]) entries() int {
+	if node.leaf {
+		return len(node.items)
+	}
+	return len(node.children)
+}
+
+// rects returns rects of all entries of node
+func (node *rNode[T]) rects() []Rect {
+	rects := make([]Rect, 0, node.entries())
+	for _, item := range node.items {
+		rects = append(rects, item.R)
+	}
+	for _, child := range node.children {
+		rects = append(rects, child.bbox)
+	}
+	return rects
+}
+
+// updateBBox recomputes bbox from entries
+func (node *rNode[T]) updateBBox() {
+	rects := node.rects()
+	if len(rects) == 0 {
+		node.bbox = Rect{}
+		return
+	}
+	bbox := rects[0]
+	for _, r := range rects[1:] {
+		bbox = bbox.Union(r)
+	}
+	node.bbox = bbox
+}
+
+// collect appends all items of subtree to `items`
+func (node *rNode[T]) collect(items []RectItem[T]) []RectItem[T] {
+	items = append(items, node.items...)
+	for _, child := range node.children {
+		items = child.collect(items)
+	}
+	return items
+}
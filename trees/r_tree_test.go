@@ -0,0 +1,152 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRTree_Basic(t *testing.T) {
+	var tr RTree[string] = NewRTree[string]()
+
+	tr.Insert(NewRect(0, 0, 256, 256), "tile-0-0")
+	tr.Insert(NewRect(256, 0, 512, 256), "tile-1-0")
+	tr.Insert(NewRect(0, 256, 256, 512), "tile-0-1")
+	tr.Insert(NewRect(1000, 1000, 1001, 1001), "far")
+	assert.Equal(t, 4, tr.Size())
+
+	var names []string
+	for _, it := range tr.Search(NewRect(200, 100, 300, 120)) {
+		names = append(names, it.Val)
+	}
+	assert.ElementsMatch(t, []string{"tile-0-0", "tile-1-0"}, names)
+
+	it, err := tr.Nearest(Point{900, 900})
+	require.NoError(t, err)
+	assert.Equal(t, "far", it.Val)
+
+	// point inside rect -> distance is 0
+	near := tr.KNearest(Point{300, 10}, 2)
+	require.Len(t, near, 2)
+	assert.Equal(t, "tile-1-0", near[0].Val)
+	assert.Equal(t, "tile-0-0", near[1].Val)
+
+	bbox, err := tr.Bounds()
+	require.NoError(t, err)
+	assert.Equal(t, NewRect(0, 0, 1001, 1001), *bbox)
+
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), tr.Delete(NewRect(0, 0, 256, 256), "far"))
+	require.NoError(t, tr.Delete(NewRect(1000, 1000, 1001, 1001), "far"))
+	bbox, _ = tr.Bounds()
+	assert.Equal(t, NewRect(0, 0, 512, 512), *bbox)
+}
+
+func TestRTree_Empty(t *testing.T) {
+	tr := NewRTree[int]()
+	assert.True(t, tr.IsEmpty())
+
+	_, err := tr.Nearest(Point{})
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+	_, err = tr.Bounds()
+	assert.Error(t, err)
+	assert.Empty(t, tr.Search(NewRect(-1, -1, 1, 1)))
+	assert.Error(t, tr.Delete(Rect{}, 0))
+}
+
+func TestRTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tr := NewRTreeWithConfig[int](4)
+	var all []RectItem[int]
+
+	randRect := func(size int) Rect {
+		x, y := float64(rnd.Intn(1000)), float64(rnd.Intn(1000))
+		return NewRect(x, y, x+float64(rnd.Intn(size)), y+float64(rnd.Intn(size)))
+	}
+	for op := 0; op < 2000; op++ {
+		if rnd.Intn(3) > 0 || len(all) == 0 {
+			item := RectItem[int]{R: randRect(50), Val: op}
+			tr.Insert(item.R, item.Val)
+			all = append(all, item)
+		} else {
+			i := rnd.Intn(len(all))
+			require.NoError(t, tr.Delete(all[i].R, all[i].Val))
+			all = append(all[:i], all[i+1:]...)
+		}
+		require.Equal(t, len(all), tr.Size())
+		checkRTree(t, tr)
+
+		q := randRect(100)
+		var expected []RectItem[int]
+		for _, it := range all {
+			if q.Intersects(it.R) {
+				expected = append(expected, it)
+			}
+		}
+		require.ElementsMatch(t, expected, tr.Search(q))
+
+		p := Point{float64(rnd.Intn(1000)), float64(rnd.Intn(1000))}
+		dists := make([]float64, len(all))
+		for i, it := range all {
+			dists[i] = it.R.Distance(p)
+		}
+		sort.Float64s(dists)
+		k := rnd.Intn(5) + 1
+		got := tr.KNearest(p, k)
+		require.Len(t, got, min(k, len(all)))
+		for i, it := range got {
+			require.Equal(t, dists[i], it.R.Distance(p))
+		}
+	}
+}
+
+// checkRTree checks bboxes, parents, number of entries and that all leaves are on the same level
+func checkRTree(t *testing.T, tr *rTree[int]) {
+	t.Helper()
+
+	leafDepth := -1
+	var check func(node *rNode[int], depth int)
+	check = func(node *rNode[int], depth int) {
+		if node != tr.root {
+			require.GreaterOrEqual(t, node.entries(), tr.minEntries)
+		}
+		require.LessOrEqual(t, node.entries(), tr.maxEntries)
+
+		bbox := node.bbox
+		node.updateBBox()
+		require.Equal(t, node.bbox, bbox)
+
+		if node.leaf {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			require.Equal(t, leafDepth, depth)
+			return
+		}
+		for _, child := range node.children {
+			require.Same(t, node, child.parent)
+			check(child, depth+1)
+		}
+	}
+	check(tr.root, 0)
+	require.Len(t, tr.root.collect(nil), tr.size)
+}
+
+func BenchmarkRTree_Search(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	tr := NewRTree[int]()
+	for i := 0; i < 1<<16; i++ {
+		x, y := rnd.Float64(), rnd.Float64()
+		tr.Insert(NewRect(x, y, x+0.001, y+0.001), i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, y := rnd.Float64(), rnd.Float64()
+		_ = tr.Search(NewRect(x, y, x+0.01, y+0.01))
+	}
+}
@@ -0,0 +1,247 @@
+package trees
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// RadixTree is the interface of Radix Tree (Patricia Trie) with string keys and values.
+//
+// Unlike Trie, node stores the whole common part of keys, not one char,
+// so long keys with few branches take few nodes.
+//
+//	rt := trees.NewRadixTree[int]()
+//	rt.Insert("/api/users", 1)
+//	rt.Insert("/api/users/admin", 2)
+//	rt.Insert("/api/orders", 3)
+//
+//	key, val, err := rt.LongestPrefix("/api/users/42") // "/api/users", 1
+//	keys := rt.KeysWithPrefix("/api/u")                // /api/users, /api/users/admin
+type RadixTree[V any] interface {
+	// Insert adds `key` with `val`. If key already exists -> replaces its val
+	Insert(key string, val V)
+
+	// Get returns val by `key`
+	//
+	// if there is no key -> val = zero value, err != nil
+	Get(key string) (V, error)
+
+	// Contains returns true if `key` exists
+	Contains(key string) bool
+
+	// Delete deletes `key`
+	//
+	// if there is no key -> returns err
+	Delete(key string) error
+
+	// WalkPrefix calls `fn` for keys which start with `prefix` in lexicographic order
+	// until `fn` returns false
+	WalkPrefix(prefix string, fn func(key string, val V) bool)
+
+	// KeysWithPrefix returns keys which start with `prefix` in lexicographic order
+	KeysWithPrefix(prefix string) []string
+
+	// LongestPrefix returns the longest key which is a prefix of `s`
+	//
+	// if there is no such key -> err != nil
+	LongestPrefix(s string) (string, V, error)
+
+	Size() int
+	IsEmpty() bool
+}
+
+// radixNode - node of Radix Tree.
+//
+// # prefix 	-> part of key between parent and this node, empty only for root
+//
+// # children 	-> sorted by the first byte of prefix, first bytes are unique
+//
+// # isEnd 	-> node is the end of key and `val` is its value
+type radixNode[V any] struct {
+	prefix   string
+	children []*radixNode[V]
+
+	val   V
+	isEnd bool
+}
+
+// radixTree - Radix Tree over bytes of keys.
+//
+// Every node which is not the end of key has at least 2 children (except root),
+// Delete merges nodes to keep it so.
+type radixTree[V any] struct {
+	root *radixNode[V]
+	size int
+
+	mu sync.Mutex
+}
+
+// NewRadixTree creates empty Radix Tree
+func NewRadixTree[V any]() *radixTree[V] {
+	return &radixTree[V]{root: &radixNode[V]{}}
+}
+
+// Insert adds `key` with `val`. If key already exists -> replaces its val
+func (rt *radixTree[V]) Insert(key string, val V) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	node := rt.root
+	for key != "" {
+		i, found := node.childIndex(key[0])
+		if !found {
+			node.children = slices.Insert(node.children, i, &radixNode[V]{prefix: key, val: val, isEnd: true})
+			rt.size++
+			return
+		}
+
+		child := node.children[i]
+		l := commonPrefixLen(child.prefix, key)
+		if l < len(child.prefix) {
+			// key goes out of the middle of child: split child into mid and the rest
+			mid := &radixNode[V]{prefix: child.prefix[:l], children: []*radixNode[V]{child}}
+			child.prefix = child.prefix[l:]
+			node.children[i] = mid
+			child = mid
+		}
+		node = child
+		key = key[l:]
+	}
+
+	if !node.isEnd {
+		rt.size++
+	}
+	node.val = val
+	node.isEnd = true
+}
+
+// Get returns val by `key`
+func (rt *radixTree[V]) Get(key string) (V, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	node := rt.find(key)
+	if node == nil || !node.isEnd {
+		var zero V
+		return zero, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return node.val, nil
+}
+
+// Contains returns true if `key` exists
+func (rt *radixTree[V]) Contains(key string) bool {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	node := rt.find(key)
+	return node != nil && node.isEnd
+}
+
+// Delete deletes `key`
+func (rt *radixTree[V]) Delete(key string) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	// path[i] is parent of path[i+1]
+	path := []*radixNode[V]{rt.root}
+	node := rt.root
+	for key != "" {
+		i, found := node.childIndex(key[0])
+		if !found || !strings.HasPrefix(key, node.children[i].prefix) {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+		node = node.children[i]
+		key = key[len(node.prefix):]
+		path = append(path, node)
+	}
+	if !node.isEnd {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	var zero V
+	node.val = zero
+	node.isEnd = false
+	rt.size--
+
+	if len(path) > 1 {
+		parent := path[len(path)-2]
+		if len(node.children) == 0 {
+			parent.removeChild(node.prefix[0])
+			if len(path) > 2 {
+				// parent may have one child now
+				path[len(path)-3].compress(parent)
+			}
+		} else {
+			parent.compress(node)
+		}
+	}
+	return nil
+}
+
+// WalkPrefix calls `fn` for keys which start with `prefix` in lexicographic order
+// until `fn` returns false
+func (rt *radixTree[V]) WalkPrefix(prefix string, fn func(key string, val V) bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	node, key := rt.findPrefix(prefix)
+	if node == nil {
+		return
+	}
+	walkRadix(node, []byte(key), fn)
+}
+
+// KeysWithPrefix returns keys which start with `prefix` in lexicographic order
+func (rt *radixTree[V]) KeysWithPrefix(prefix string) []string {
+	var keys []string
+	rt.WalkPrefix(prefix, func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// LongestPrefix returns the longest key which is a prefix of `s`
+func (rt *radixTree[V]) LongestPrefix(s string) (string, V, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	best := -1
+	var val V
+	node := rt.root
+	consumed := 0
+	for {
+		if node.isEnd {
+			best, val = consumed, node.val
+		}
+		if consumed == len(s) {
+			break
+		}
+		i, found := node.childIndex(s[consumed])
+		if !found || !strings.HasPrefix(s[consumed:], node.children[i].prefix) {
+			break
+		}
+		node = node.children[i]
+		consumed += len(node.prefix)
+	}
+
+	if best == -1 {
+		return "", val, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return s[:best], val, nil
+}
+
+func (rt *radixTree[V]) Size() int {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.size
+}
+
+func (rt *radixTree[V]) IsEmpty() bool {
+	return rt.Size() == 0
+}
@@ -0,0 +1,96 @@
+package trees
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// childIndex returns index of child which prefix starts with `b`,
+// or index where such child should be inserted
+func (n *radixNode[V]) childIndex(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	return i, i < len(n.children) && n.children[i].prefix[0] == b
+}
+
+// removeChild removes child which prefix starts with `b`
+func (n *radixNode[V]) removeChild(b byte) {
+	if i, found := n.childIndex(b); found {
+		n.children = slices.Delete(n.children, i, i+1)
+	}
+}
+
+// compress merges `child` of `n` with its only child if `child` is not the end of key
+func (n *radixNode[V]) compress(child *radixNode[V]) {
+	if child.isEnd || len(child.children) != 1 {
+		return
+	}
+	grandchild := child.children[0]
+	grandchild.prefix = child.prefix + grandchild.prefix
+	i, _ := n.childIndex(child.prefix[0])
+	n.children[i] = grandchild
+}
+
+// find returns node of exact `key` or nil
+func (rt *radixTree[V]) find(key string) *radixNode[V] {
+	node := rt.root
+	for key != "" {
+		i, found := node.childIndex(key[0])
+		if !found || !strings.HasPrefix(key, node.children[i].prefix) {
+			return nil
+		}
+		node = node.children[i]
+		key = key[len(node.prefix):]
+	}
+	return node
+}
+
+// findPrefix returns the highest node which keys start with `prefix` and the key of this node.
+// Prefix may end in the middle of node prefix
+func (rt *radixTree[V]) findPrefix(prefix string) (*radixNode[V], string) {
+	node := rt.root
+	consumed := 0
+	for consumed < len(prefix) {
+		rest := prefix[consumed:]
+		i, found := node.childIndex(rest[0])
+		if !found {
+			return nil, ""
+		}
+		node = node.children[i]
+		l := commonPrefixLen(node.prefix, rest)
+		if l == len(rest) {
+			return node, prefix[:consumed] + node.prefix
+		}
+		if l < len(node.prefix) {
+			return nil, ""
+		}
+		consumed += l
+	}
+	return node, prefix
+}
+
+// walkRadix calls `fn` for keys of subtree in lexicographic order: node key is less
+// than keys of its children, children are sorted. `key` is the key of `node`
+func walkRadix[V any](node *radixNode[V], key []byte, fn func(key string, val V) bool) bool {
+	if node.isEnd && !fn(string(key), node.val) {
+		return false
+	}
+	for _, child := range node.children {
+		if !walkRadix(child, append(key, child.prefix...), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,269 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRadixTree_InsertGet(t *testing.T) {
+	rt := NewRadixTree[int]()
+	assert.True(t, rt.IsEmpty())
+
+	rt.Insert("romane", 1)
+	rt.Insert("romanus", 2)
+	rt.Insert("romulus", 3)
+	rt.Insert("rubens", 4)
+	rt.Insert("ruber", 5)
+	rt.Insert("rubicon", 6)
+	rt.Insert("rubicundus", 7)
+	rt.Insert("", 0)
+	rt.Insert("rom", 8)
+	assert.Equal(t, 9, rt.Size())
+
+	for key, val := range map[string]int{"romane": 1, "rubicundus": 7, "": 0, "rom": 8} {
+		got, err := rt.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, val, got)
+	}
+	_, err := rt.Get("roma")
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+	assert.False(t, rt.Contains("rubi"))
+	assert.False(t, rt.Contains("rubiconx"))
+
+	rt.Insert("ruber", 50)
+	val, _ := rt.Get("ruber")
+	assert.Equal(t, 50, val)
+	assert.Equal(t, 9, rt.Size())
+
+	// common parts are stored once: r -> om, ub
+	require.Len(t, rt.root.children, 1)
+	assert.Equal(t, "r", rt.root.children[0].prefix)
+	checkRadixTree(t, rt)
+}
+
+func TestRadixTree_Delete(t *testing.T) {
+	rt := NewRadixTree[int]()
+	for i, key := range []string{"test", "team", "toast", "te", "tea"} {
+		rt.Insert(key, i)
+	}
+
+	assert.EqualError(t, rt.Delete("t"), gocollections.ErrNotFound)
+	assert.EqualError(t, rt.Delete("teams"), gocollections.ErrNotFound)
+
+	require.NoError(t, rt.Delete("tea"))
+	assert.False(t, rt.Contains("tea"))
+	assert.True(t, rt.Contains("team"))
+	checkRadixTree(t, rt)
+
+	require.NoError(t, rt.Delete("te"))
+	require.NoError(t, rt.Delete("test"))
+	checkRadixTree(t, rt)
+	assert.Equal(t, []string{"team", "toast"}, rt.KeysWithPrefix(""))
+
+	require.NoError(t, rt.Delete("team"))
+	require.NoError(t, rt.Delete("toast"))
+	assert.True(t, rt.IsEmpty())
+	assert.Empty(t, rt.root.children)
+}
+
+func TestRadixTree_Prefix(t *testing.T) {
+	rt := NewRadixTree[int]()
+	for i, key := range []string{"/api/users", "/api/users/admin", "/api/orders", "/static/app.js", "/api"} {
+		rt.Insert(key, i)
+	}
+
+	assert.Equal(t, []string{"/api", "/api/orders", "/api/users", "/api/users/admin"}, rt.KeysWithPrefix("/api"))
+	assert.Equal(t, []string{"/api/users", "/api/users/admin"}, rt.KeysWithPrefix("/api/u"))
+	assert.Equal(t, []string{"/api/users/admin"}, rt.KeysWithPrefix("/api/users/"))
+	assert.Empty(t, rt.KeysWithPrefix("/apx"))
+	assert.Empty(t, rt.KeysWithPrefix("/api/users/admins"))
+
+	var first []string
+	rt.WalkPrefix("", func(key string, _ int) bool {
+		first = append(first, key)
+		return len(first) < 2
+	})
+	assert.Equal(t, []string{"/api", "/api/orders"}, first)
+
+	key, val, err := rt.LongestPrefix("/api/users/42")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/users", key)
+	assert.Equal(t, 0, val)
+
+	key, _, err = rt.LongestPrefix("/api/users")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/users", key)
+
+	key, _, err = rt.LongestPrefix("/api/us")
+	require.NoError(t, err)
+	assert.Equal(t, "/api", key)
+
+	_, _, err = rt.LongestPrefix("/static")
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+}
+
+func TestRadixTree_Random(t *testing.T) {
+	rt := NewRadixTree[int]()
+	expected := make(map[string]int)
+	rnd := rand.New(rand.NewSource(1))
+	randKey := func() string {
+		b := make([]byte, rnd.Intn(8))
+		for i := range b {
+			b[i] = "abc"[rnd.Intn(3)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 5000; i++ {
+		key := randKey()
+		if rnd.Intn(3) == 0 {
+			_, ok := expected[key]
+			err := rt.Delete(key)
+			assert.Equal(t, ok, err == nil)
+			delete(expected, key)
+		} else {
+			rt.Insert(key, i)
+			expected[key] = i
+		}
+
+		if i%100 == 0 {
+			checkRadixTree(t, rt)
+			require.Equal(t, len(expected), rt.Size())
+
+			prefix := randKey()
+			var want []string
+			for key := range expected {
+				if strings.HasPrefix(key, prefix) {
+					want = append(want, key)
+				}
+			}
+			slices.Sort(want)
+			require.Equal(t, want, rt.KeysWithPrefix(prefix))
+
+			s := randKey() + randKey()
+			best := -1
+			for key := range expected {
+				if strings.HasPrefix(s, key) && len(key) > best {
+					best = len(key)
+				}
+			}
+			key, val, err := rt.LongestPrefix(s)
+			if best == -1 {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, s[:best], key)
+				require.Equal(t, expected[key], val)
+			}
+		}
+	}
+	for key, val := range expected {
+		got, err := rt.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, val, got)
+	}
+}
+
+// checkRadixTree checks that children are sorted by unique first byte
+// and that every inner node is the end of key or has at least 2 children
+func checkRadixTree(t *testing.T, rt *radixTree[int]) {
+	t.Helper()
+	var check func(n *radixNode[int], isRoot bool)
+	check = func(n *radixNode[int], isRoot bool) {
+		if !isRoot {
+			require.NotEmpty(t, n.prefix)
+			require.True(t, n.isEnd || len(n.children) >= 2, "node %q must be merged", n.prefix)
+		}
+		for i, child := range n.children {
+			if i > 0 {
+				require.Less(t, n.children[i-1].prefix[0], child.prefix[0])
+			}
+			check(child, false)
+		}
+	}
+	check(rt.root, true)
+}
+
+// memoryKeys returns long keys with common prefixes, like urls or file paths
+func memoryKeys() []string {
+	rnd := rand.New(rand.NewSource(1))
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("https://example.com/api/v%d/users/%d/documents/%x",
+			rnd.Intn(3), rnd.Intn(1000), rnd.Int63())
+	}
+	return keys
+}
+
+// benchmarkMemory reports heap bytes per key of the structure built by `build`
+func benchmarkMemory(b *testing.B, build func(keys []string) any) {
+	keys := memoryKeys()
+	var before, after runtime.MemStats
+	var keep any
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		keep = build(keys)
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(len(keys)), "heap-B/key")
+	}
+	runtime.KeepAlive(keep)
+}
+
+func BenchmarkRadixTree_Memory(b *testing.B) {
+	benchmarkMemory(b, func(keys []string) any {
+		rt := NewRadixTree[struct{}]()
+		for _, key := range keys {
+			rt.Insert(key, struct{}{})
+		}
+		return rt
+	})
+}
+
+func BenchmarkTrie_Memory(b *testing.B) {
+	benchmarkMemory(b, func(keys []string) any {
+		tr := NewTrie(stringComparator, stringToString)
+		for _, key := range keys {
+			tr.Insert(key)
+		}
+		return tr
+	})
+}
+
+func BenchmarkRadixTree_Search(b *testing.B) {
+	keys := memoryKeys()
+	rt := NewRadixTree[struct{}]()
+	for _, key := range keys {
+		rt.Insert(key, struct{}{})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rt.Contains(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkTrie_Search(b *testing.B) {
+	keys := memoryKeys()
+	tr := NewTrie(stringComparator, stringToString)
+	for _, key := range keys {
+		tr.Insert(key)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.Search(keys[i%len(keys)])
+	}
+}
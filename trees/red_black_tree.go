@@ -8,12 +8,16 @@ import (
 )
 
 // rbt - Red-Black Tree
+//
+// # augment 	-> optional, recomputes data that node keeps about its subtree
+// (interval tree keeps max end of intervals). nil for plain RBT
 type rbt[T comparable] struct {
 	root *rbt_node[T]
 
 	mu sync.Mutex
 
 	compare Comparator[T]
+	augment func(node *rbt_node[T])
 }
 
 func NewRBT[T comparable](compare Comparator[T]) *rbt[T] {
@@ -24,9 +28,7 @@ func (rbt *rbt[T]) Insert(item T) {
 	rbt.mu.Lock()
 	defer rbt.mu.Unlock()
 
-	newNode := rbt.insertHelper(rbt.root, item)
-
-	rbt.fixInsert(newNode)
+	rbt.insert(item)
 }
 
 func (rbt *rbt[T]) Delete(item T) error {
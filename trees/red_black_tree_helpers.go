@@ -6,6 +6,27 @@ import (
 	"github.com/0x0FACED/go-collections/queue"
 )
 
+// insert adds item and restores RBT props
+func (rbt *rbt[T]) insert(item T) *rbt_node[T] {
+	newNode := rbt.insertHelper(rbt.root, item)
+
+	// augmented data of all ancestors must be correct before fixInsert,
+	// rotations recompute only 2 rotated nodes
+	rbt.augmentUp(newNode)
+	rbt.fixInsert(newNode)
+	return newNode
+}
+
+// augmentUp recomputes augmented data from node up to the root
+func (rbt *rbt[T]) augmentUp(node *rbt_node[T]) {
+	if rbt.augment == nil {
+		return
+	}
+	for ; node != nil; node = node.parent {
+		rbt.augment(node)
+	}
+}
+
 func (rbt *rbt[T]) insertHelper(curr *rbt_node[T], item T) *rbt_node[T] {
 	newNode := &rbt_node[T]{val: item, clr: red}
 
@@ -119,6 +140,12 @@ func (rbt *rbt[T]) rotateLeft(x *rbt_node[T]) {
 
 	y.left = x
 	x.parent = y
+
+	// x is child of y now, so x goes first
+	if rbt.augment != nil {
+		rbt.augment(x)
+		rbt.augment(y)
+	}
 }
 
 func (rbt *rbt[T]) rotateRight(y *rbt_node[T]) {
@@ -141,6 +168,11 @@ func (rbt *rbt[T]) rotateRight(y *rbt_node[T]) {
 
 	x.right = y
 	y.parent = x
+
+	if rbt.augment != nil {
+		rbt.augment(y)
+		rbt.augment(x)
+	}
 }
 
 // Super Uber Mega HARD to implement
@@ -171,6 +203,8 @@ func (rbt *rbt[T]) deleteHelper(node *rbt_node[T]) {
 			node.parent.right = nil
 		}
 
+		rbt.augmentUp(node.parent)
+
 		// Important moment: if NODE is black -> his parent black too
 		// So we have to call fixDelete with nil child and node.parent
 		if node.clr == black {
@@ -208,6 +242,8 @@ func (rbt *rbt[T]) deleteHelper(node *rbt_node[T]) {
 				par.right = child
 			}
 		}
+		rbt.augmentUp(par)
+
 		// if NODE was black -> fixDelete with our child and parent
 		if color == black {
 			rbt.fixDelete(child, par)
@@ -2,6 +2,7 @@ package trees
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -298,3 +299,33 @@ func TestRBT_Traversal(t *testing.T) {
 	assert.Equal(t, expectedPostOrder, postOrder, "postOrder traversal does not match expected order")
 	assert.Equal(t, expectedLevelOrder, levelOrder, "levelOrder traversal does not match expected order")
 }
+
+// Same workload as BenchmarkConcurrentSkipList_Parallel in skiplist:
+// 80% Search, 10% Insert, 10% Delete on 2^16 keys.
+//
+// Compare them with:
+//
+//	go test -run xxx -bench Parallel -cpu 1,4,8 ./trees/ ./skiplist/
+func BenchmarkRBT_Parallel(b *testing.B) {
+	n := 1 << 16
+	tr := NewRBT(compare)
+	for i := 0; i < n; i++ {
+		tr.Insert(i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			k := rnd.Intn(n)
+			switch rnd.Intn(10) {
+			case 0:
+				tr.Insert(k)
+			case 1:
+				_ = tr.Delete(k)
+			default:
+				_, _ = tr.Search(k)
+			}
+		}
+	})
+}
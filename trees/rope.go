@@ -0,0 +1,319 @@
+package trees
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// Rope is the interface of string for editing of large text:
+// insert and delete in the middle are O(log n) instead of O(n) copy.
+//
+// Positions are byte offsets. Runes and lines are counted for valid UTF-8,
+// line is ended by '\n'.
+//
+//	r := trees.NewRope("hello world")
+//	r.Insert(5, ",")          // "hello, world"
+//	r.Delete(0, 7)            // "world"
+//	left, right, _ := r.Split(2) // "wo", "rld"
+type Rope interface {
+	// Len returns length of text in bytes
+	Len() int
+
+	// RuneCount returns number of runes
+	RuneCount() int
+
+	// LineCount returns number of lines: number of '\n' + 1
+	LineCount() int
+
+	// Index returns byte at position `i`
+	//
+	// if i is out of range -> returns err
+	Index(i int) (byte, error)
+
+	// Insert inserts `s` before position `pos` (pos == Len() appends)
+	//
+	// if pos is out of range -> returns err
+	Insert(pos int, s string) error
+
+	// Delete deletes `n` bytes from position `pos`
+	//
+	// if range is out of text -> returns err
+	Delete(pos, n int) error
+
+	// Substring returns `n` bytes from position `pos`
+	//
+	// if range is out of text -> returns err
+	Substring(pos, n int) (string, error)
+
+	// Concat returns new Rope of this text followed by text of `other`.
+	// Both ropes are not changed
+	Concat(other Rope) Rope
+
+	// Split returns new Ropes of text before `pos` and from `pos`.
+	// Rope is not changed
+	//
+	// if pos is out of range -> returns err
+	Split(pos int) (Rope, Rope, error)
+
+	// RuneOffset returns byte position of rune with index `n`.
+	// n == RuneCount() gives Len()
+	//
+	// if n is out of range -> returns err
+	RuneOffset(n int) (int, error)
+
+	// LineOffset returns byte position of the beginning of line `line` (from 0)
+	//
+	// if line is out of range -> returns err
+	LineOffset(line int) (int, error)
+
+	// Line returns text of line `line` (from 0) without '\n'
+	//
+	// if line is out of range -> returns err
+	Line(line int) (string, error)
+
+	// Reader returns reader of current text. Later changes of Rope
+	// are not seen by reader, text is not copied
+	Reader() io.Reader
+
+	// String returns the whole text
+	String() string
+}
+
+// ropeLeafSize is max length of text in leaf
+const ropeLeafSize = 512
+
+// ropeNode - node of Rope. Leaf keeps piece of text, internal node keeps
+// 2 children and sums of its subtree. Nodes are never changed after creation,
+// so ropes made by Split and Concat share them.
+//
+// # length 	-> length of text of subtree in bytes
+//
+// # runes 	-> number of runes (bytes which are not UTF-8 continuation bytes)
+//
+// # lines 	-> number of '\n'
+//
+// # height 	-> height of subtree, leaf has 0
+type ropeNode struct {
+	left  *ropeNode
+	right *ropeNode
+	text  string
+
+	length int
+	runes  int
+	lines  int
+	height int
+}
+
+// rope - Rope as AVL-balanced binary tree of text pieces up to `leafSize` bytes.
+//
+// All changes are built on 2 primitives (like in Treap):
+//
+// # split 	-> divides tree into text before position and text from position
+//
+// # join 	-> joins 2 trees and keeps heights of children different at most by 1,
+// so tree is rebalanced automatically and its depth is O(log n)
+type rope struct {
+	root     *ropeNode
+	leafSize int
+
+	mu sync.Mutex
+}
+
+// NewRope creates Rope of `s`
+func NewRope(s string) *rope {
+	return newRope(s, ropeLeafSize)
+}
+
+func newRope(s string, leafSize int) *rope {
+	return &rope{root: buildRope(s, leafSize), leafSize: leafSize}
+}
+
+// Len returns length of text in bytes
+func (r *rope) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return lengthRope(r.root)
+}
+
+// RuneCount returns number of runes
+func (r *rope) RuneCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.root == nil {
+		return 0
+	}
+	return r.root.runes
+}
+
+// LineCount returns number of lines: number of '\n' + 1
+func (r *rope) LineCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.root == nil {
+		return 1
+	}
+	return r.root.lines + 1
+}
+
+// Index returns byte at position `i`
+func (r *rope) Index(i int) (byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i < 0 || i >= lengthRope(r.root) {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	node := r.root
+	for node.left != nil {
+		if i < node.left.length {
+			node = node.left
+		} else {
+			i -= node.left.length
+			node = node.right
+		}
+	}
+	return node.text[i], nil
+}
+
+// Insert inserts `s` before position `pos`
+func (r *rope) Insert(pos int, s string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pos < 0 || pos > lengthRope(r.root) {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	left, right := r.split(r.root, pos)
+	r.root = r.join(r.join(left, buildRope(s, r.leafSize)), right)
+	return nil
+}
+
+// Delete deletes `n` bytes from position `pos`
+func (r *rope) Delete(pos, n int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.inRange(pos, n) {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	left, rest := r.split(r.root, pos)
+	_, right := r.split(rest, n)
+	r.root = r.join(left, right)
+	return nil
+}
+
+// Substring returns `n` bytes from position `pos`
+func (r *rope) Substring(pos, n int) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.inRange(pos, n) {
+		return "", fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	var sb strings.Builder
+	sb.Grow(n)
+	substringRope(r.root, pos, pos+n, &sb)
+	return sb.String(), nil
+}
+
+// Concat returns new Rope of this text followed by text of `other`
+func (r *rope) Concat(other Rope) Rope {
+	var otherRoot *ropeNode
+	if o, ok := other.(*rope); ok {
+		o.mu.Lock()
+		otherRoot = o.root
+		o.mu.Unlock()
+	} else {
+		otherRoot = buildRope(other.String(), r.leafSize)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &rope{root: r.join(r.root, otherRoot), leafSize: r.leafSize}
+}
+
+// Split returns new Ropes of text before `pos` and from `pos`
+func (r *rope) Split(pos int) (Rope, Rope, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pos < 0 || pos > lengthRope(r.root) {
+		return nil, nil, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	left, right := r.split(r.root, pos)
+	return &rope{root: left, leafSize: r.leafSize}, &rope{root: right, leafSize: r.leafSize}, nil
+}
+
+// RuneOffset returns byte position of rune with index `n`
+func (r *rope) RuneOffset(n int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runes := 0
+	if r.root != nil {
+		runes = r.root.runes
+	}
+	if n < 0 || n > runes {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	if n == runes {
+		return lengthRope(r.root), nil
+	}
+	return runeOffsetRope(r.root, n), nil
+}
+
+// LineOffset returns byte position of the beginning of line `line`
+func (r *rope) LineOffset(line int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lineOffset(line)
+}
+
+// Line returns text of line `line` without '\n'
+func (r *rope) Line(line int) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start, err := r.lineOffset(line)
+	if err != nil {
+		return "", err
+	}
+	end := lengthRope(r.root)
+	if next, err := r.lineOffset(line + 1); err == nil {
+		end = next - 1
+	}
+	var sb strings.Builder
+	sb.Grow(end - start)
+	substringRope(r.root, start, end, &sb)
+	return sb.String(), nil
+}
+
+// Reader returns reader of current text
+func (r *rope) Reader() io.Reader {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reader := &ropeReader{}
+	reader.pushLeft(r.root)
+	return reader
+}
+
+// String returns the whole text
+func (r *rope) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sb strings.Builder
+	sb.Grow(lengthRope(r.root))
+	substringRope(r.root, 0, lengthRope(r.root), &sb)
+	return sb.String()
+}
@@ -0,0 +1,243 @@
+package trees
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+func newRopeLeaf(s string) *ropeNode {
+	runes := 0
+	for i := 0; i < len(s); i++ {
+		// count first bytes of runes, so pieces can be cut inside of rune
+		if s[i]&0xC0 != 0x80 {
+			runes++
+		}
+	}
+	return &ropeNode{text: s, length: len(s), runes: runes, lines: strings.Count(s, "\n")}
+}
+
+func newRopeNode(left, right *ropeNode) *ropeNode {
+	return &ropeNode{
+		left:   left,
+		right:  right,
+		length: left.length + right.length,
+		runes:  left.runes + right.runes,
+		lines:  left.lines + right.lines,
+		height: max(left.height, right.height) + 1,
+	}
+}
+
+func lengthRope(node *ropeNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.length
+}
+
+func heightRope(node *ropeNode) int {
+	if node == nil {
+		return -1
+	}
+	return node.height
+}
+
+// buildRope builds balanced tree of leaves of `s` by halving
+func buildRope(s string, leafSize int) *ropeNode {
+	if s == "" {
+		return nil
+	}
+	if len(s) <= leafSize {
+		return newRopeLeaf(s)
+	}
+	mid := len(s) / 2
+	return newRopeNode(buildRope(s[:mid], leafSize), buildRope(s[mid:], leafSize))
+}
+
+// join returns tree of text of `left` followed by text of `right`.
+// The higher tree is descended by its side till heights are close,
+// then trees are rebalanced by rotations on the way up (like AVL).
+// Small neighbour leaves are merged, so deletes don't leave many tiny pieces
+func (r *rope) join(left, right *ropeNode) *ropeNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.left == nil && right.left == nil && left.length+right.length <= r.leafSize {
+		return newRopeLeaf(left.text + right.text)
+	}
+
+	switch {
+	case left.height > right.height+1:
+		return balanceRope(left.left, r.join(left.right, right))
+	case right.height > left.height+1:
+		return balanceRope(r.join(left, right.left), right.right)
+	}
+	return newRopeNode(left, right)
+}
+
+// balanceRope returns node of `left` and `right` which heights differ at most by 2,
+// with rotation if they differ by 2
+func balanceRope(left, right *ropeNode) *ropeNode {
+	switch {
+	case left.height > right.height+1:
+		if left.left.height >= left.right.height {
+			return newRopeNode(left.left, newRopeNode(left.right, right))
+		}
+		return newRopeNode(
+			newRopeNode(left.left, left.right.left),
+			newRopeNode(left.right.right, right),
+		)
+	case right.height > left.height+1:
+		if right.right.height >= right.left.height {
+			return newRopeNode(newRopeNode(left, right.left), right.right)
+		}
+		return newRopeNode(
+			newRopeNode(left, right.left.left),
+			newRopeNode(right.left.right, right.right),
+		)
+	}
+	return newRopeNode(left, right)
+}
+
+// split returns trees of text before `pos` and from `pos`
+func (r *rope) split(node *ropeNode, pos int) (*ropeNode, *ropeNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if pos == 0 {
+		return nil, node
+	}
+	if pos == node.length {
+		return node, nil
+	}
+	if node.left == nil {
+		return newRopeLeaf(node.text[:pos]), newRopeLeaf(node.text[pos:])
+	}
+	if pos < node.left.length {
+		left, right := r.split(node.left, pos)
+		return left, r.join(right, node.right)
+	}
+	left, right := r.split(node.right, pos-node.left.length)
+	return r.join(node.left, left), right
+}
+
+// inRange returns true if [pos, pos+n) is in text
+func (r *rope) inRange(pos, n int) bool {
+	return pos >= 0 && n >= 0 && pos+n <= lengthRope(r.root)
+}
+
+// substringRope writes text [from, to) of subtree to `sb`
+func substringRope(node *ropeNode, from, to int, sb *strings.Builder) {
+	if node == nil || from >= to {
+		return
+	}
+	if node.left == nil {
+		sb.WriteString(node.text[from:to])
+		return
+	}
+	mid := node.left.length
+	if from < mid {
+		substringRope(node.left, from, min(to, mid), sb)
+	}
+	if to > mid {
+		substringRope(node.right, max(from-mid, 0), to-mid, sb)
+	}
+}
+
+// runeOffsetRope returns byte position of rune `n` in subtree, n < node.runes
+func runeOffsetRope(node *ropeNode, n int) int {
+	offset := 0
+	for node.left != nil {
+		if n < node.left.runes {
+			node = node.left
+		} else {
+			n -= node.left.runes
+			offset += node.left.length
+			node = node.right
+		}
+	}
+	for i := 0; i < len(node.text); i++ {
+		if node.text[i]&0xC0 != 0x80 {
+			if n == 0 {
+				return offset + i
+			}
+			n--
+		}
+	}
+	return offset + len(node.text)
+}
+
+// lineOffset returns byte position after '\n' with index line-1
+func (r *rope) lineOffset(line int) (int, error) {
+	lines := 0
+	if r.root != nil {
+		lines = r.root.lines
+	}
+	if line < 0 || line > lines {
+		return 0, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	if line == 0 {
+		return 0, nil
+	}
+
+	// find '\n' with index n
+	node, n, offset := r.root, line-1, 0
+	for node.left != nil {
+		if n < node.left.lines {
+			node = node.left
+		} else {
+			n -= node.left.lines
+			offset += node.left.length
+			node = node.right
+		}
+	}
+	for i := 0; i < len(node.text); i++ {
+		if node.text[i] == '\n' {
+			if n == 0 {
+				return offset + i + 1, nil
+			}
+			n--
+		}
+	}
+	return offset + len(node.text), nil
+}
+
+// ropeReader reads leaves in order, `stack` keeps nodes which right subtrees are not read
+type ropeReader struct {
+	stack []*ropeNode
+	text  string
+}
+
+// pushLeft pushes `node` and its left descendants
+func (rr *ropeReader) pushLeft(node *ropeNode) {
+	for node != nil {
+		rr.stack = append(rr.stack, node)
+		node = node.left
+	}
+}
+
+func (rr *ropeReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for rr.text == "" {
+		if len(rr.stack) == 0 {
+			return 0, io.EOF
+		}
+		node := rr.stack[len(rr.stack)-1]
+		rr.stack = rr.stack[:len(rr.stack)-1]
+		if node.left == nil {
+			rr.text = node.text
+		} else {
+			rr.pushLeft(node.right)
+		}
+	}
+	n := copy(p, rr.text)
+	rr.text = rr.text[n:]
+	return n, nil
+}
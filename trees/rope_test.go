@@ -0,0 +1,227 @@
+package trees
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRope_Edit(t *testing.T) {
+	r := NewRope("hello world")
+	require.NoError(t, r.Insert(5, ","))
+	require.NoError(t, r.Insert(r.Len(), "!"))
+	assert.Equal(t, "hello, world!", r.String())
+
+	require.NoError(t, r.Delete(0, 7))
+	assert.Equal(t, "world!", r.String())
+	b, err := r.Index(1)
+	require.NoError(t, err)
+	assert.Equal(t, byte('o'), b)
+	sub, err := r.Substring(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "orl", sub)
+
+	left, right, err := r.Split(2)
+	require.NoError(t, err)
+	assert.Equal(t, "wo", left.String())
+	assert.Equal(t, "rld!", right.String())
+	assert.Equal(t, "rld!wo", right.Concat(left).String())
+	assert.Equal(t, "world!", r.String())
+
+	assert.EqualError(t, r.Insert(7, "x"), gocollections.ErrOutOfBounds)
+	assert.EqualError(t, r.Delete(4, 3), gocollections.ErrOutOfBounds)
+	assert.EqualError(t, r.Delete(-1, 1), gocollections.ErrOutOfBounds)
+	_, err = r.Index(6)
+	assert.EqualError(t, err, gocollections.ErrOutOfBounds)
+	_, _, err = r.Split(-1)
+	assert.EqualError(t, err, gocollections.ErrOutOfBounds)
+
+	empty := NewRope("")
+	assert.Equal(t, 0, empty.Len())
+	assert.Equal(t, 1, empty.LineCount())
+	require.NoError(t, empty.Insert(0, "x"))
+	assert.Equal(t, "x", empty.String())
+}
+
+func TestRope_Lines(t *testing.T) {
+	r := newRope("привет\nмир\n\nlast", 4)
+	checkRope(t, r)
+	assert.Equal(t, 16, r.RuneCount())
+	assert.Equal(t, 4, r.LineCount())
+
+	for i, expected := range []string{"привет", "мир", "", "last"} {
+		line, err := r.Line(i)
+		require.NoError(t, err)
+		assert.Equal(t, expected, line)
+	}
+	offset, err := r.LineOffset(1)
+	require.NoError(t, err)
+	assert.Equal(t, len("привет\n"), offset)
+	_, err = r.Line(4)
+	assert.EqualError(t, err, gocollections.ErrOutOfBounds)
+
+	// pieces are cut inside of runes, but offsets are at rune starts
+	offset, err = r.RuneOffset(2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, offset)
+	offset, err = r.RuneOffset(r.RuneCount())
+	require.NoError(t, err)
+	assert.Equal(t, r.Len(), offset)
+	_, err = r.RuneOffset(17)
+	assert.EqualError(t, err, gocollections.ErrOutOfBounds)
+}
+
+func TestRope_Reader(t *testing.T) {
+	text := strings.Repeat("line of text\n", 1000)
+	r := newRope(text, 16)
+	require.NoError(t, iotest.TestReader(r.Reader(), []byte(text)))
+
+	// reader doesn't see later changes
+	reader := r.Reader()
+	require.NoError(t, r.Delete(0, r.Len()))
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, text, string(data))
+
+	data, err = io.ReadAll(r.Reader())
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestRope_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randText := func() string {
+		b := make([]rune, rnd.Intn(20))
+		for i := range b {
+			b[i] = []rune("ab\nп🙂")[rnd.Intn(5)]
+		}
+		return string(b)
+	}
+
+	// random position at rune start, so text stays valid UTF-8
+	// (pieces of rope are still cut inside of runes)
+	randPos := func(text string, from int) int {
+		pos := from + rnd.Intn(len(text)-from+1)
+		for pos < len(text) && !utf8.RuneStart(text[pos]) {
+			pos++
+		}
+		return pos
+	}
+
+	r := newRope("", 8)
+	expected := ""
+	for i := 0; i < 3000; i++ {
+		pos := randPos(expected, 0)
+		switch rnd.Intn(4) {
+		case 0:
+			n := randPos(expected, pos) - pos
+			require.NoError(t, r.Delete(pos, n))
+			expected = expected[:pos] + expected[pos+n:]
+		case 1:
+			left, right, err := r.Split(pos)
+			require.NoError(t, err)
+			require.Equal(t, expected[:pos], left.String())
+			require.Equal(t, expected[pos:], right.String())
+			// join back in other order
+			r = right.Concat(left).(*rope)
+			expected = expected[pos:] + expected[:pos]
+		default:
+			s := randText()
+			require.NoError(t, r.Insert(pos, s))
+			expected = expected[:pos] + s + expected[pos:]
+		}
+		checkRope(t, r)
+		require.Equal(t, len(expected), r.Len())
+
+		if i%50 != 0 {
+			continue
+		}
+		require.Equal(t, expected, r.String())
+		require.Equal(t, utf8.RuneCountInString(expected), r.RuneCount())
+		require.Equal(t, strings.Count(expected, "\n")+1, r.LineCount())
+		for line, want := range strings.Split(expected, "\n") {
+			got, err := r.Line(line)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		}
+		n := 0
+		for offset := range expected {
+			got, err := r.RuneOffset(n)
+			require.NoError(t, err)
+			require.Equal(t, offset, got)
+			n++
+		}
+		if len(expected) > 0 {
+			pos = rnd.Intn(len(expected))
+			b, err := r.Index(pos)
+			require.NoError(t, err)
+			require.Equal(t, expected[pos], b)
+			n := rnd.Intn(len(expected) - pos + 1)
+			sub, err := r.Substring(pos, n)
+			require.NoError(t, err)
+			require.Equal(t, expected[pos:pos+n], sub)
+		}
+	}
+}
+
+// checkRope checks sums and heights of nodes, AVL balance and sizes of leaves
+func checkRope(t *testing.T, r *rope) {
+	t.Helper()
+	var check func(node *ropeNode)
+	check = func(node *ropeNode) {
+		if node.left == nil {
+			require.NotEmpty(t, node.text)
+			require.LessOrEqual(t, len(node.text), r.leafSize)
+			require.Equal(t, newRopeLeaf(node.text).runes, node.runes)
+			require.Equal(t, 0, node.height)
+			return
+		}
+		check(node.left)
+		check(node.right)
+		require.LessOrEqual(t, node.left.height-node.right.height, 1, "tree is not balanced")
+		require.LessOrEqual(t, node.right.height-node.left.height, 1, "tree is not balanced")
+		require.Equal(t, *newRopeNode(node.left, node.right), *node)
+	}
+	if r.root != nil {
+		check(r.root)
+	}
+}
+
+func BenchmarkRope_Insert(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	r := NewRope(strings.Repeat("0123456789abcdef", 1<<16))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Insert(rnd.Intn(r.Len()), "x")
+	}
+}
+
+func BenchmarkString_Insert(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	s := strings.Repeat("0123456789abcdef", 1<<16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pos := rnd.Intn(len(s))
+		s = s[:pos] + "x" + s[pos:]
+	}
+}
+
+func BenchmarkRope_Line(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 100000; i++ {
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	r := NewRope(sb.String())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Line(i % 100000)
+	}
+}
@@ -0,0 +1,235 @@
+package trees
+
+import (
+	"fmt"
+	"net/netip"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// Route is the prefix of RoutingTable with its value
+type Route[V any] struct {
+	Prefix netip.Prefix
+	Val    V
+}
+
+// RoutingTable is the interface of IP prefix table with longest-prefix match.
+// IPv4 and IPv6 prefixes are stored separately, IPv4-mapped IPv6 address
+// (::ffff:1.2.3.4) is IPv6 address, as in netip.
+//
+//	rt := trees.NewRoutingTable[string]()
+//	_ = rt.Insert(netip.MustParsePrefix("0.0.0.0/0"), "default")
+//	_ = rt.Insert(netip.MustParsePrefix("10.0.0.0/8"), "internal")
+//	_ = rt.Insert(netip.MustParsePrefix("10.1.0.0/16"), "office")
+//
+//	prefix, val, err := rt.Lookup(netip.MustParseAddr("10.1.2.3")) // 10.1.0.0/16, office
+type RoutingTable[V any] interface {
+	// Insert adds `prefix` with `val`. Host bits of prefix are ignored: 10.1.2.3/8 is 10.0.0.0/8.
+	// If prefix already exists -> replaces its val
+	//
+	// if prefix is not valid -> returns err
+	Insert(prefix netip.Prefix, val V) error
+
+	// Delete deletes `prefix`
+	//
+	// if there is no prefix -> returns err
+	Delete(prefix netip.Prefix) error
+
+	// Get returns val of exactly `prefix`
+	//
+	// if there is no prefix -> val = zero value, err != nil
+	Get(prefix netip.Prefix) (V, error)
+
+	// Lookup returns the longest prefix which contains `addr` and its val
+	//
+	// if there is no such prefix -> err != nil
+	Lookup(addr netip.Addr) (netip.Prefix, V, error)
+
+	// Covered returns prefixes which are inside `prefix` (also prefix itself),
+	// ordered by address, then by length
+	Covered(prefix netip.Prefix) []Route[V]
+
+	// Routes returns all prefixes: IPv4 first, then IPv6, each ordered as in Covered
+	Routes() []Route[V]
+
+	Size() int
+	IsEmpty() bool
+}
+
+// ipKey is the address as 128 bits: IPv4 uses the highest 32 bits of hi
+type ipKey struct {
+	hi, lo uint64
+}
+
+// routeNode - node of path-compressed binary trie.
+//
+// # key, bits 	-> node is the prefix of `bits` first bits of `key`, other bits of key are 0
+//
+// # children 	-> children[b] continues prefix with bit b. Child may be
+// longer than bits+1: bits between them are the same for the whole subtree
+//
+// # hasVal 	-> node is the inserted prefix, otherwise it only joins 2 children
+type routeNode[V any] struct {
+	key      ipKey
+	bits     int
+	children [2]*routeNode[V]
+
+	prefix netip.Prefix
+	val    V
+	hasVal bool
+}
+
+// routingTable - two path-compressed binary tries (Patricia tries over bits of address):
+// for IPv4 and for IPv6. Every node without value has 2 children (except roots),
+// so trie has at most 2n nodes and lookup visits at most 33 (129) nodes.
+type routingTable[V any] struct {
+	v4   *routeNode[V]
+	v6   *routeNode[V]
+	size int
+
+	mu sync.Mutex
+}
+
+// NewRoutingTable creates empty RoutingTable
+func NewRoutingTable[V any]() *routingTable[V] {
+	return &routingTable[V]{
+		v4: &routeNode[V]{},
+		v6: &routeNode[V]{},
+	}
+}
+
+// Insert adds `prefix` with `val`. If prefix already exists -> replaces its val
+func (rt *routingTable[V]) Insert(prefix netip.Prefix, val V) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if !prefix.IsValid() {
+		return fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	prefix = prefix.Masked()
+	node := rt.insert(rt.root(prefix.Addr()), keyOf(prefix.Addr()), prefix.Bits())
+	if !node.hasVal {
+		rt.size++
+	}
+	node.prefix = prefix
+	node.val = val
+	node.hasVal = true
+	return nil
+}
+
+// Delete deletes `prefix`
+func (rt *routingTable[V]) Delete(prefix netip.Prefix) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if !prefix.IsValid() {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	prefix = prefix.Masked()
+	key, bits := keyOf(prefix.Addr()), prefix.Bits()
+
+	// path[i] is parent of path[i+1]
+	path := []*routeNode[V]{rt.root(prefix.Addr())}
+	node := path[0]
+	for node.bits < bits {
+		node = node.children[key.bit(node.bits)]
+		if node == nil || node.bits > bits || commonBits(node.key, key) < node.bits {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+		path = append(path, node)
+	}
+	if !node.hasVal {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	var zero V
+	node.val = zero
+	node.hasVal = false
+	rt.size--
+	rt.compress(path)
+	return nil
+}
+
+// Get returns val of exactly `prefix`
+func (rt *routingTable[V]) Get(prefix netip.Prefix) (V, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	var zero V
+	if !prefix.IsValid() {
+		return zero, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	prefix = prefix.Masked()
+	node := rt.find(rt.root(prefix.Addr()), keyOf(prefix.Addr()), prefix.Bits())
+	if node == nil || node.bits != prefix.Bits() || !node.hasVal {
+		return zero, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return node.val, nil
+}
+
+// Lookup returns the longest prefix which contains `addr` and its val
+func (rt *routingTable[V]) Lookup(addr netip.Addr) (netip.Prefix, V, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	var best *routeNode[V]
+	if addr.IsValid() {
+		key := keyOf(addr)
+		maxBits := addr.BitLen()
+		for node := rt.root(addr); node != nil && commonBits(node.key, key) >= node.bits; {
+			if node.hasVal {
+				best = node
+			}
+			if node.bits == maxBits {
+				break
+			}
+			node = node.children[key.bit(node.bits)]
+		}
+	}
+
+	if best == nil {
+		var zero V
+		return netip.Prefix{}, zero, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return best.prefix, best.val, nil
+}
+
+// Covered returns prefixes which are inside `prefix` (also prefix itself)
+func (rt *routingTable[V]) Covered(prefix netip.Prefix) []Route[V] {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	var res []Route[V]
+	if !prefix.IsValid() {
+		return res
+	}
+	prefix = prefix.Masked()
+	node := rt.find(rt.root(prefix.Addr()), keyOf(prefix.Addr()), prefix.Bits())
+	if node != nil {
+		collectRoutes(node, &res)
+	}
+	return res
+}
+
+// Routes returns all prefixes: IPv4 first, then IPv6
+func (rt *routingTable[V]) Routes() []Route[V] {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	var res []Route[V]
+	collectRoutes(rt.v4, &res)
+	collectRoutes(rt.v6, &res)
+	return res
+}
+
+func (rt *routingTable[V]) Size() int {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.size
+}
+
+func (rt *routingTable[V]) IsEmpty() bool {
+	return rt.Size() == 0
+}
@@ -0,0 +1,151 @@
+package trees
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"net/netip"
+)
+
+// keyOf returns bits of address
+func keyOf(addr netip.Addr) ipKey {
+	if addr.Is4() {
+		b := addr.As4()
+		return ipKey{hi: uint64(binary.BigEndian.Uint32(b[:])) << 32}
+	}
+	b := addr.As16()
+	return ipKey{hi: binary.BigEndian.Uint64(b[:8]), lo: binary.BigEndian.Uint64(b[8:])}
+}
+
+// bit returns bit `i` of key, bit 0 is the highest
+func (k ipKey) bit(i int) int {
+	if i < 64 {
+		return int(k.hi >> (63 - i) & 1)
+	}
+	return int(k.lo >> (127 - i) & 1)
+}
+
+// mask returns key with only `n` first bits
+func (k ipKey) mask(n int) ipKey {
+	switch {
+	case n == 0:
+		return ipKey{}
+	case n <= 64:
+		return ipKey{hi: k.hi &^ (1<<(64-n) - 1)}
+	case n < 128:
+		return ipKey{hi: k.hi, lo: k.lo &^ (1<<(128-n) - 1)}
+	}
+	return k
+}
+
+// commonBits returns number of equal first bits of keys
+func commonBits(a, b ipKey) int {
+	if x := a.hi ^ b.hi; x != 0 {
+		return bits.LeadingZeros64(x)
+	}
+	return 64 + bits.LeadingZeros64(a.lo^b.lo)
+}
+
+// root returns root of trie for family of address
+func (rt *routingTable[V]) root(addr netip.Addr) *routeNode[V] {
+	if addr.Is4() {
+		return rt.v4
+	}
+	return rt.v6
+}
+
+// insert returns node of prefix (`key`, `bits`), node is created if it doesn't exist
+func (rt *routingTable[V]) insert(root *routeNode[V], key ipKey, bits int) *routeNode[V] {
+	node := root
+	for node.bits != bits {
+		b := key.bit(node.bits)
+		child := node.children[b]
+		if child == nil {
+			child = &routeNode[V]{key: key, bits: bits}
+			node.children[b] = child
+			return child
+		}
+
+		common := min(commonBits(child.key, key), child.bits, bits)
+		if common == child.bits {
+			node = child
+			continue
+		}
+
+		// child goes out of prefix after `common` bits: new node is inserted between them
+		n := &routeNode[V]{key: key, bits: bits}
+		if common == bits {
+			// new prefix contains child
+			n.children[child.key.bit(bits)] = child
+			node.children[b] = n
+			return n
+		}
+		// they differ in bit `common`: join them by node without value
+		join := &routeNode[V]{key: key.mask(common), bits: common}
+		join.children[child.key.bit(common)] = child
+		join.children[key.bit(common)] = n
+		node.children[b] = join
+		return n
+	}
+	return node
+}
+
+// find returns the highest node which is inside prefix (`key`, `bits`) or nil
+func (rt *routingTable[V]) find(root *routeNode[V], key ipKey, bits int) *routeNode[V] {
+	node := root
+	for node.bits < bits {
+		node = node.children[key.bit(node.bits)]
+		if node == nil || commonBits(node.key, key) < min(node.bits, bits) {
+			return nil
+		}
+	}
+	return node
+}
+
+// compress removes the last node of path if it has no value and it is not needed
+// to join 2 children. path[0] is root, path[i] is parent of path[i+1]
+func (rt *routingTable[V]) compress(path []*routeNode[V]) {
+	if len(path) < 2 {
+		return
+	}
+	node, parent := path[len(path)-1], path[len(path)-2]
+	switch {
+	case node.children[0] != nil && node.children[1] != nil:
+		return
+	case node.children[0] != nil || node.children[1] != nil:
+		replaceChild(parent, node, onlyChild(node))
+	default:
+		replaceChild(parent, node, nil)
+		// parent may join only one child now
+		if len(path) > 2 && !parent.hasVal {
+			replaceChild(path[len(path)-3], parent, onlyChild(parent))
+		}
+	}
+}
+
+// onlyChild returns child of node which has one child
+func onlyChild[V any](node *routeNode[V]) *routeNode[V] {
+	if node.children[0] != nil {
+		return node.children[0]
+	}
+	return node.children[1]
+}
+
+func replaceChild[V any](parent, old, child *routeNode[V]) {
+	if parent.children[0] == old {
+		parent.children[0] = child
+	} else {
+		parent.children[1] = child
+	}
+}
+
+// collectRoutes adds prefixes of subtree to `res`: node, then children 0 and 1
+func collectRoutes[V any](node *routeNode[V], res *[]Route[V]) {
+	if node.hasVal {
+		*res = append(*res, Route[V]{Prefix: node.prefix, Val: node.val})
+	}
+	for _, child := range node.children {
+		if child != nil {
+			collectRoutes(child, res)
+		}
+	}
+}
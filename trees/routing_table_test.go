@@ -0,0 +1,295 @@
+package trees
+
+import (
+	"math/rand"
+	"net/netip"
+	"slices"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingTable_Lookup(t *testing.T) {
+	rt := NewRoutingTable[string]()
+	for prefix, val := range map[string]string{
+		"0.0.0.0/0":       "default",
+		"10.0.0.0/8":      "internal",
+		"10.1.0.0/16":     "office",
+		"10.1.2.0/24":     "lab",
+		"192.168.1.1/32":  "router",
+		"2001:db8::/32":   "docs",
+		"2001:db8:1::/48": "docs-1",
+	} {
+		require.NoError(t, rt.Insert(netip.MustParsePrefix(prefix), val))
+	}
+	assert.Equal(t, 7, rt.Size())
+
+	for addr, want := range map[string]string{
+		"10.1.2.3":      "lab",
+		"10.1.3.3":      "office",
+		"10.2.0.1":      "internal",
+		"8.8.8.8":       "default",
+		"192.168.1.1":   "router",
+		"192.168.1.2":   "default",
+		"2001:db8:1::1": "docs-1",
+		"2001:db8:2::1": "docs",
+	} {
+		_, val, err := rt.Lookup(netip.MustParseAddr(addr))
+		require.NoError(t, err, addr)
+		assert.Equal(t, want, val, addr)
+	}
+
+	prefix, _, err := rt.Lookup(netip.MustParseAddr("10.1.2.3"))
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("10.1.2.0/24"), prefix)
+
+	// IPv4 routes are not used for IPv6 and IPv4-mapped addresses
+	_, _, err = rt.Lookup(netip.MustParseAddr("2002::1"))
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+	_, _, err = rt.Lookup(netip.MustParseAddr("::ffff:10.1.2.3"))
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+	_, _, err = rt.Lookup(netip.Addr{})
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+}
+
+func TestRoutingTable_InsertDelete(t *testing.T) {
+	rt := NewRoutingTable[int]()
+	assert.EqualError(t, rt.Insert(netip.Prefix{}, 1), gocollections.ErrInvalidData)
+
+	// host bits are ignored
+	require.NoError(t, rt.Insert(netip.MustParsePrefix("10.1.2.3/8"), 1))
+	val, err := rt.Get(netip.MustParsePrefix("10.0.0.0/8"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	require.NoError(t, rt.Insert(netip.MustParsePrefix("10.0.0.0/8"), 2))
+	assert.Equal(t, 1, rt.Size())
+	val, _ = rt.Get(netip.MustParsePrefix("10.0.0.0/8"))
+	assert.Equal(t, 2, val)
+
+	require.NoError(t, rt.Insert(netip.MustParsePrefix("10.128.0.0/9"), 3))
+	require.NoError(t, rt.Insert(netip.MustParsePrefix("10.0.0.0/9"), 4))
+	_, err = rt.Get(netip.MustParsePrefix("10.0.0.0/7"))
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+
+	assert.EqualError(t, rt.Delete(netip.MustParsePrefix("10.0.0.0/16")), gocollections.ErrNotFound)
+	require.NoError(t, rt.Delete(netip.MustParsePrefix("10.0.0.0/8")))
+	assert.EqualError(t, rt.Delete(netip.MustParsePrefix("10.0.0.0/8")), gocollections.ErrNotFound)
+	_, val, err = rt.Lookup(netip.MustParseAddr("10.200.0.1"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, val)
+	_, _, err = rt.Lookup(netip.MustParseAddr("11.0.0.1"))
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+	checkRoutingTable(t, rt)
+
+	require.NoError(t, rt.Delete(netip.MustParsePrefix("10.0.0.0/9")))
+	require.NoError(t, rt.Delete(netip.MustParsePrefix("10.128.0.0/9")))
+	assert.True(t, rt.IsEmpty())
+	assert.Equal(t, [2]*routeNode[int]{}, rt.v4.children)
+}
+
+func TestRoutingTable_Covered(t *testing.T) {
+	rt := NewRoutingTable[int]()
+	for i, prefix := range []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "10.2.0.0/16", "11.0.0.0/8", "::/0", "fe80::/10"} {
+		require.NoError(t, rt.Insert(netip.MustParsePrefix(prefix), i))
+	}
+
+	prefixes := func(routes []Route[int]) []string {
+		var res []string
+		for _, r := range routes {
+			res = append(res, r.Prefix.String())
+		}
+		return res
+	}
+	assert.Equal(t, []string{"10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "10.2.0.0/16"}, prefixes(rt.Covered(netip.MustParsePrefix("10.0.0.0/8"))))
+	assert.Equal(t, []string{"10.1.0.0/16", "10.1.2.0/24"}, prefixes(rt.Covered(netip.MustParsePrefix("10.1.0.0/15"))))
+	assert.Equal(t, []string{"10.1.2.0/24"}, prefixes(rt.Covered(netip.MustParsePrefix("10.1.2.0/23"))))
+	assert.Empty(t, rt.Covered(netip.MustParsePrefix("10.1.3.0/24")))
+	assert.Empty(t, rt.Covered(netip.MustParsePrefix("12.0.0.0/8")))
+	assert.Len(t, rt.Covered(netip.MustParsePrefix("0.0.0.0/0")), 5)
+	assert.Equal(t, []string{"::/0", "fe80::/10"}, prefixes(rt.Covered(netip.MustParsePrefix("::/0"))))
+
+	assert.Equal(t, []string{
+		"10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "10.2.0.0/16", "11.0.0.0/8", "::/0", "fe80::/10",
+	}, prefixes(rt.Routes()))
+}
+
+func TestRoutingTable_Random(t *testing.T) {
+	rt := NewRoutingTable[int]()
+	expected := make(map[netip.Prefix]int)
+	rnd := rand.New(rand.NewSource(1))
+
+	// few distinct high bits, so prefixes are nested and share paths
+	randAddr := func(v6 bool) netip.Addr {
+		if v6 {
+			var b [16]byte
+			b[0] = byte(rnd.Intn(4))
+			b[1] = byte(rnd.Intn(4)) << 6
+			b[15] = byte(rnd.Intn(256))
+			return netip.AddrFrom16(b)
+		}
+		return netip.AddrFrom4([4]byte{byte(rnd.Intn(4)), byte(rnd.Intn(4) << 6), 0, byte(rnd.Intn(256))})
+	}
+	randPrefix := func() netip.Prefix {
+		v6 := rnd.Intn(3) == 0
+		addr := randAddr(v6)
+		prefix, _ := addr.Prefix(rnd.Intn(addr.BitLen() + 1))
+		return prefix
+	}
+
+	for i := 0; i < 5000; i++ {
+		prefix := randPrefix()
+		if rnd.Intn(3) == 0 {
+			_, ok := expected[prefix]
+			err := rt.Delete(prefix)
+			require.Equal(t, ok, err == nil)
+			delete(expected, prefix)
+		} else {
+			require.NoError(t, rt.Insert(prefix, i))
+			expected[prefix] = i
+		}
+
+		if i%50 != 0 {
+			continue
+		}
+		checkRoutingTable(t, rt)
+		require.Equal(t, len(expected), rt.Size())
+
+		addr := randAddr(rnd.Intn(2) == 0)
+		var best netip.Prefix
+		for p := range expected {
+			if p.Contains(addr) && (!best.IsValid() || p.Bits() > best.Bits()) {
+				best = p
+			}
+		}
+		prefix, val, err := rt.Lookup(addr)
+		if !best.IsValid() {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, best, prefix, addr)
+			require.Equal(t, expected[best], val)
+		}
+
+		query := randPrefix()
+		var want []netip.Prefix
+		for p := range expected {
+			if p.Addr().Is4() == query.Addr().Is4() && p.Bits() >= query.Bits() && query.Contains(p.Addr()) {
+				want = append(want, p)
+			}
+		}
+		slices.SortFunc(want, func(a, b netip.Prefix) int {
+			if c := a.Addr().Compare(b.Addr()); c != 0 {
+				return c
+			}
+			return a.Bits() - b.Bits()
+		})
+		var got []netip.Prefix
+		for _, r := range rt.Covered(query) {
+			got = append(got, r.Prefix)
+		}
+		require.Equal(t, want, got, query)
+	}
+}
+
+// checkRoutingTable checks that child is inside its parent and longer than it,
+// continues parent with its bit, and node without value joins 2 children
+func checkRoutingTable(t *testing.T, rt *routingTable[int]) {
+	t.Helper()
+	var check func(node *routeNode[int], isRoot bool)
+	check = func(node *routeNode[int], isRoot bool) {
+		require.Equal(t, node.key, node.key.mask(node.bits))
+		if node.hasVal {
+			require.Equal(t, node.bits, node.prefix.Bits())
+			require.Equal(t, node.key, keyOf(node.prefix.Addr()))
+		} else if !isRoot {
+			require.NotNil(t, node.children[0], "node without value must join 2 children")
+			require.NotNil(t, node.children[1], "node without value must join 2 children")
+		}
+		for b, child := range node.children {
+			if child == nil {
+				continue
+			}
+			require.Greater(t, child.bits, node.bits)
+			require.GreaterOrEqual(t, commonBits(child.key, node.key), node.bits)
+			require.Equal(t, b, child.key.bit(node.bits))
+			check(child, false)
+		}
+	}
+	check(rt.v4, true)
+	check(rt.v6, true)
+}
+
+// fullTable returns synthetic routing table of Internet size: ~1M IPv4 and ~200k IPv6 prefixes.
+// Most IPv4 prefixes are /24, most IPv6 prefixes are /48
+func fullTable() []netip.Prefix {
+	rnd := rand.New(rand.NewSource(1))
+	prefixes := make([]netip.Prefix, 0, 1200000)
+	for i := 0; i < 1000000; i++ {
+		bits := 24
+		switch r := rnd.Intn(100); {
+		case r < 10:
+			bits = 8 + rnd.Intn(12)
+		case r < 40:
+			bits = 20 + rnd.Intn(4)
+		}
+		addr := netip.AddrFrom4([4]byte{byte(1 + rnd.Intn(223)), byte(rnd.Intn(256)), byte(rnd.Intn(256)), 0})
+		prefix, _ := addr.Prefix(bits)
+		prefixes = append(prefixes, prefix)
+	}
+	for i := 0; i < 200000; i++ {
+		bits := 48
+		if rnd.Intn(4) == 0 {
+			bits = 29 + rnd.Intn(19)
+		}
+		var b [16]byte
+		b[0], b[1] = 0x20, byte(rnd.Intn(16))
+		rnd.Read(b[2:6])
+		prefix, _ := netip.AddrFrom16(b).Prefix(bits)
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes
+}
+
+func BenchmarkRoutingTable_Insert(b *testing.B) {
+	prefixes := fullTable()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rt := NewRoutingTable[int]()
+		for j, prefix := range prefixes {
+			_ = rt.Insert(prefix, j)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(prefixes)), "ns/prefix")
+}
+
+func BenchmarkRoutingTable_Lookup(b *testing.B) {
+	prefixes := fullTable()
+	rt := NewRoutingTable[int]()
+	for j, prefix := range prefixes {
+		_ = rt.Insert(prefix, j)
+	}
+
+	rnd := rand.New(rand.NewSource(2))
+	addrs := make([]netip.Addr, 1<<16)
+	for i := range addrs {
+		if i%5 == 0 {
+			var b [16]byte
+			b[0], b[1] = 0x20, byte(rnd.Intn(16))
+			rnd.Read(b[2:])
+			addrs[i] = netip.AddrFrom16(b)
+		} else {
+			addrs[i] = netip.AddrFrom4([4]byte{byte(1 + rnd.Intn(223)), byte(rnd.Intn(256)), byte(rnd.Intn(256)), byte(rnd.Intn(256))})
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = rt.Lookup(addrs[i&(len(addrs)-1)])
+	}
+}
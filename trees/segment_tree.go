@@ -0,0 +1,158 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// Monoid describes how Segment Tree aggregates values:
+//
+// # Combine 	-> associative operation: Combine(a, Combine(b, c)) == Combine(Combine(a, b), c)
+//
+// # Identity 	-> neutral element: Combine(Identity, a) == Combine(a, Identity) == a
+//
+// Combine doesn't have to be commutative, order of elements is kept.
+//
+//	sum := trees.Monoid[int]{
+//		Combine:  func(a, b int) int { return a + b },
+//		Identity: 0,
+//	}
+type Monoid[T any] struct {
+	Combine  func(a, b T) T
+	Identity T
+}
+
+// SegmentTree is the interface of Segment Tree over slice of values.
+//
+// All ranges are half-open: [l, r)
+type SegmentTree[T any] interface {
+	// Query returns Combine of all values in [l, r).
+	//
+	// If l == r -> returns Identity
+	Query(l, r int) (T, error)
+
+	// Update sets value at index `i`
+	Update(i int, val T) error
+
+	// Get returns value at index `i`
+	Get(i int) (*T, error)
+
+	// Size returns number of values
+	Size() int
+}
+
+// segmentTree - Segment Tree with point update and range query.
+//
+// It's a bottom-up tree in array of size 2n:
+// leaves are tree[n..2n), node i has children 2i and 2i+1.
+// Build is O(n), Query and Update are O(log n)
+type segmentTree[T any] struct {
+	tree []T
+	n    int
+
+	mu sync.Mutex
+
+	m Monoid[T]
+}
+
+// NewSegmentTree builds Segment Tree from `items` in O(n).
+// `items` is copied
+func NewSegmentTree[T any](items []T, m Monoid[T]) *segmentTree[T] {
+	n := len(items)
+	st := &segmentTree[T]{tree: make([]T, 2*n), n: n, m: m}
+	copy(st.tree[n:], items)
+	for i := n - 1; i >= 1; i-- {
+		st.tree[i] = m.Combine(st.tree[2*i], st.tree[2*i+1])
+	}
+	return st
+}
+
+// Query returns Combine of all values in [l, r)
+func (st *segmentTree[T]) Query(l, r int) (T, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if l < 0 || r > st.n || l > r {
+		return st.m.Identity, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+
+	// left and right parts are combined separately to keep the order
+	resL, resR := st.m.Identity, st.m.Identity
+	for l, r = l+st.n, r+st.n; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			resL = st.m.Combine(resL, st.tree[l])
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			resR = st.m.Combine(st.tree[r], resR)
+		}
+	}
+	return st.m.Combine(resL, resR), nil
+}
+
+// Update sets value at index `i`
+func (st *segmentTree[T]) Update(i int, val T) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if i < 0 || i >= st.n {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+
+	i += st.n
+	st.tree[i] = val
+	for i /= 2; i >= 1; i /= 2 {
+		st.tree[i] = st.m.Combine(st.tree[2*i], st.tree[2*i+1])
+	}
+	return nil
+}
+
+// Get returns value at index `i`
+func (st *segmentTree[T]) Get(i int) (*T, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if i < 0 || i >= st.n {
+		return nil, fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	val := st.tree[i+st.n]
+	return &val, nil
+}
+
+// Size returns number of values
+func (st *segmentTree[T]) Size() int {
+	return st.n
+}
+
+// ==========================================================================================
+
+// SumMonoid returns monoid of sums
+func SumMonoid[N Number]() Monoid[N] {
+	return Monoid[N]{
+		Combine:  func(a, b N) N { return a + b },
+		Identity: 0,
+	}
+}
+
+// MinMonoid returns monoid of minimums.
+//
+// `inf` must be >= any value, for example math.MaxInt or math.Inf(1)
+func MinMonoid[N Number](inf N) Monoid[N] {
+	return Monoid[N]{
+		Combine:  func(a, b N) N { return min(a, b) },
+		Identity: inf,
+	}
+}
+
+// MaxMonoid returns monoid of maximums.
+//
+// `negInf` must be <= any value, for example math.MinInt or math.Inf(-1)
+func MaxMonoid[N Number](negInf N) Monoid[N] {
+	return Monoid[N]{
+		Combine:  func(a, b N) N { return max(a, b) },
+		Identity: negInf,
+	}
+}
@@ -0,0 +1,194 @@
+package trees
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concatenation is not commutative, so it checks that order is kept
+var concatMonoid = Monoid[string]{
+	Combine:  func(a, b string) string { return a + b },
+	Identity: "",
+}
+
+func TestSegmentTree_Sum(t *testing.T) {
+	var st SegmentTree[int] = NewSegmentTree([]int{5, 3, 8, 1, 4, 7}, SumMonoid[int]())
+
+	sum, err := st.Query(0, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 28, sum)
+
+	sum, err = st.Query(1, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 12, sum)
+
+	sum, err = st.Query(3, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sum)
+
+	require.NoError(t, st.Update(2, 0))
+	sum, _ = st.Query(0, 6)
+	assert.Equal(t, 20, sum)
+
+	val, err := st.Get(2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, *val)
+
+	_, err = st.Query(2, 7)
+	assert.Equal(t, errors.New(gocollections.ErrOutOfBounds), err)
+	_, err = st.Query(4, 2)
+	assert.Error(t, err)
+	assert.Error(t, st.Update(6, 1))
+	_, err = st.Get(-1)
+	assert.Error(t, err)
+}
+
+func TestSegmentTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 7, 16, 33, 100} {
+		items := make([]string, n)
+		for i := range items {
+			items[i] = string(rune('a' + rnd.Intn(26)))
+		}
+		st := NewSegmentTree(items, concatMonoid)
+
+		for op := 0; op < 500; op++ {
+			if rnd.Intn(3) == 0 {
+				i := rnd.Intn(n)
+				items[i] = string(rune('a' + rnd.Intn(26)))
+				require.NoError(t, st.Update(i, items[i]))
+				continue
+			}
+			l := rnd.Intn(n + 1)
+			r := l + rnd.Intn(n-l+1)
+			expected := ""
+			for i := l; i < r; i++ {
+				expected += items[i]
+			}
+			res, err := st.Query(l, r)
+			require.NoError(t, err)
+			require.Equal(t, expected, res, "n=%d [%d, %d)", n, l, r)
+		}
+	}
+}
+
+func TestSegmentTree_Empty(t *testing.T) {
+	st := NewSegmentTree([]int{}, MinMonoid(math.MaxInt))
+
+	assert.Equal(t, 0, st.Size())
+	res, err := st.Query(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, math.MaxInt, res)
+	assert.Error(t, st.Update(0, 1))
+}
+
+func TestLazySegmentTree_RangeAddSum(t *testing.T) {
+	var st LazySegmentTree[int, int] = NewLazySegmentTree([]int{1, 2, 3, 4, 5}, SumMonoid[int](), RangeAddSum[int]())
+
+	require.NoError(t, st.RangeUpdate(1, 4, 10))
+	sum, err := st.Query(0, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 45, sum)
+
+	sum, _ = st.Query(3, 5)
+	assert.Equal(t, 19, sum)
+
+	val, err := st.Get(2)
+	require.NoError(t, err)
+	assert.Equal(t, 13, *val)
+
+	require.NoError(t, st.Update(2, 0))
+	sum, _ = st.Query(0, 5)
+	assert.Equal(t, 32, sum)
+
+	assert.Equal(t, errors.New(gocollections.ErrOutOfBounds), st.RangeUpdate(0, 6, 1))
+	require.NoError(t, st.RangeUpdate(2, 2, 100))
+	sum, _ = st.Query(0, 5)
+	assert.Equal(t, 32, sum)
+}
+
+func TestLazySegmentTree_BruteForce(t *testing.T) {
+	type testCase struct {
+		name  string
+		m     Monoid[int]
+		op    LazyOp[int, int]
+		apply func(old, u int) int
+	}
+	cases := []testCase{
+		{"add sum", SumMonoid[int](), RangeAddSum[int](), func(old, u int) int { return old + u }},
+		{"assign sum", SumMonoid[int](), RangeAssignSum[int](), func(_, u int) int { return u }},
+		{"add min", MinMonoid(math.MaxInt), RangeAddMinMax[int](), func(old, u int) int { return old + u }},
+		{"assign max", MaxMonoid(math.MinInt), RangeAssign[int](), func(_, u int) int { return u }},
+	}
+
+	rnd := rand.New(rand.NewSource(2))
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, n := range []int{1, 2, 5, 17, 64, 100} {
+				items := make([]int, n)
+				for i := range items {
+					items[i] = rnd.Intn(100) - 50
+				}
+				st := NewLazySegmentTree(items, tc.m, tc.op)
+
+				for op := 0; op < 500; op++ {
+					l := rnd.Intn(n + 1)
+					r := l + rnd.Intn(n-l+1)
+					switch rnd.Intn(3) {
+					case 0:
+						u := rnd.Intn(20) - 10
+						require.NoError(t, st.RangeUpdate(l, r, u))
+						for i := l; i < r; i++ {
+							items[i] = tc.apply(items[i], u)
+						}
+					case 1:
+						if n > 0 {
+							i, v := rnd.Intn(n), rnd.Intn(100)
+							require.NoError(t, st.Update(i, v))
+							items[i] = v
+						}
+					default:
+						expected := tc.m.Identity
+						for i := l; i < r; i++ {
+							expected = tc.m.Combine(expected, items[i])
+						}
+						res, err := st.Query(l, r)
+						require.NoError(t, err)
+						require.Equal(t, expected, res, "n=%d [%d, %d)", n, l, r)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLazySegmentTree_Float(t *testing.T) {
+	st := NewLazySegmentTree([]float64{0.5, 1.5, 2.5}, SumMonoid[float64](), RangeAssignSum[float64]())
+	require.NoError(t, st.RangeUpdate(0, 2, 0.25))
+	sum, err := st.Query(0, 3)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, sum, 1e-9)
+}
+
+func BenchmarkLazySegmentTree_RangeAdd(b *testing.B) {
+	n := 1 << 16
+	st := NewLazySegmentTree(make([]int, n), SumMonoid[int](), RangeAddSum[int]())
+	rnd := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l := rnd.Intn(n)
+		r := l + rnd.Intn(n-l)
+		if i%2 == 0 {
+			_ = st.RangeUpdate(l, r, 1)
+		} else {
+			_, _ = st.Query(l, r)
+		}
+	}
+}
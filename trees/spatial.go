@@ -0,0 +1,88 @@
+package trees
+
+import "math"
+
+// Point is the point on 2D plane
+type Point struct {
+	X, Y float64
+}
+
+// Rect is the axis-aligned rectangle, bounds are included
+type Rect struct {
+	Min, Max Point
+}
+
+// NewRect creates Rect from any two opposite corners
+func NewRect(x1, y1, x2, y2 float64) Rect {
+	return Rect{
+		Min: Point{math.Min(x1, x2), math.Min(y1, y2)},
+		Max: Point{math.Max(x1, x2), math.Max(y1, y2)},
+	}
+}
+
+// Contains returns true if `p` is inside r or on its border
+func (r Rect) Contains(p Point) bool {
+	return r.Min.X <= p.X && p.X <= r.Max.X && r.Min.Y <= p.Y && p.Y <= r.Max.Y
+}
+
+// ContainsRect returns true if `o` is inside r
+func (r Rect) ContainsRect(o Rect) bool {
+	return r.Contains(o.Min) && r.Contains(o.Max)
+}
+
+// Intersects returns true if r and `o` have at least one common point
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X && r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+// Union returns the smallest Rect which contains r and `o`
+func (r Rect) Union(o Rect) Rect {
+	return Rect{
+		Min: Point{math.Min(r.Min.X, o.Min.X), math.Min(r.Min.Y, o.Min.Y)},
+		Max: Point{math.Max(r.Max.X, o.Max.X), math.Max(r.Max.Y, o.Max.Y)},
+	}
+}
+
+// Area returns area of r
+func (r Rect) Area() float64 {
+	return (r.Max.X - r.Min.X) * (r.Max.Y - r.Min.Y)
+}
+
+// Distance returns distance from `p` to the nearest point of r (0 if p is inside)
+func (r Rect) Distance(p Point) float64 {
+	dx := math.Max(math.Max(r.Min.X-p.X, 0), p.X-r.Max.X)
+	dy := math.Max(math.Max(r.Min.Y-p.Y, 0), p.Y-r.Max.Y)
+	return math.Hypot(dx, dy)
+}
+
+// PointItem is the point with payload
+type PointItem[T any] struct {
+	P   Point
+	Val T
+}
+
+// RectItem is the rectangle with payload
+type RectItem[T any] struct {
+	R   Rect
+	Val T
+}
+
+// spatialCandidate is the item of priority queue in nearest neighbour search:
+// node which is not opened yet or item. Item is ready for result
+// when it is the nearest of all candidates
+type spatialCandidate[N any, I comparable] struct {
+	node   *N
+	item   I
+	isItem bool
+	dist   float64
+}
+
+// nearestFirst makes Min-Heap by distance
+func nearestFirst[N any, I comparable](a, b spatialCandidate[N, I]) int {
+	if a.dist > b.dist {
+		return -1
+	} else if a.dist < b.dist {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,213 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// SplayStats shows how splay tree adapts to workload.
+//
+// # Accesses 	-> number of Insert, Delete and Search calls
+//
+// # TotalDepth 	-> sum of depths of accessed nodes before they were splayed
+//
+// TotalDepth / Accesses is the average access cost. If workload has hot items,
+// they stay near the root and average cost is much less than log2(n)
+type SplayStats struct {
+	Accesses   uint64
+	TotalDepth uint64
+}
+
+// AverageDepth returns average depth of accessed nodes
+func (s SplayStats) AverageDepth() float64 {
+	if s.Accesses == 0 {
+		return 0
+	}
+	return float64(s.TotalDepth) / float64(s.Accesses)
+}
+
+// splayTree - Splay Tree.
+//
+// Every access (even Search) moves accessed node to the root by rotations (splay),
+// so recently used items are cheap to access again.
+// Any sequence of m operations is O(m log n), but single operation may be O(n).
+//
+// Splay is top-down: tree is divided into left, middle and right parts
+// while going down, so it needs no parent ptrs and no recursion
+type splayTree[T comparable] struct {
+	root *node[T]
+	size int
+
+	mu sync.Mutex
+
+	compare Comparator[T]
+	stats   SplayStats
+}
+
+func NewSplayTree[T comparable](compare Comparator[T]) *splayTree[T] {
+	return &splayTree[T]{compare: compare}
+}
+
+func (st *splayTree[T]) Insert(item T) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	n := &node[T]{val: item}
+	st.size++
+	if st.root == nil {
+		st.root = n
+		st.stats.Accesses++
+		return
+	}
+
+	// after splay root is the closest item, new item becomes new root
+	st.access(item)
+	root := st.root
+	if st.compare(item, root.val) < 0 {
+		n.left, n.right = root.left, root
+		root.left = nil
+	} else {
+		n.left, n.right = root, root.right
+		root.right = nil
+	}
+	st.root = n
+}
+
+func (st *splayTree[T]) Delete(item T) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.root == nil {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	st.access(item)
+	if st.compare(item, st.root.val) != 0 {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	if st.root.left == nil {
+		st.root = st.root.right
+	} else {
+		// max of left subtree has no right child after splay
+		right := st.root.right
+		st.root, _ = st.splay(st.root.left, func(T) int { return 1 })
+		st.root.right = right
+	}
+	st.size--
+	return nil
+}
+
+// Search returns item and moves it to the root
+func (st *splayTree[T]) Search(item T) (*T, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.root == nil {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	st.access(item)
+	if st.compare(item, st.root.val) != 0 {
+		return nil, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return &st.root.val, nil
+}
+
+// Root returns item in the root: the last accessed item
+// or the closest to it if it was not found
+//
+// if tree is empty -> val = nil, err != nil
+func (st *splayTree[T]) Root() (*T, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.root == nil {
+		return nil, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return &st.root.val, nil
+}
+
+// Depth returns depth of `item` (root has 0). Unlike Search it doesn't change tree
+//
+// if there is no item in tree -> returns err
+func (st *splayTree[T]) Depth(item T) (int, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	curr := st.root
+	for depth := 0; curr != nil; depth++ {
+		c := st.compare(item, curr.val)
+		if c == 0 {
+			return depth, nil
+		} else if c < 0 {
+			curr = curr.left
+		} else {
+			curr = curr.right
+		}
+	}
+	return 0, fmt.Errorf(gocollections.ErrNotFound)
+}
+
+// Stats returns access statistics
+func (st *splayTree[T]) Stats() SplayStats {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.stats
+}
+
+// ResetStats sets access statistics to zero
+func (st *splayTree[T]) ResetStats() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.stats = SplayStats{}
+}
+
+func (st *splayTree[T]) InOrder() []T {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	items := make([]T, 0, st.size)
+	st.inOrderHelper(st.root, &items)
+	return items
+}
+
+func (st *splayTree[T]) PreOrder() []T {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	items := make([]T, 0, st.size)
+	st.preOrderHelper(st.root, &items)
+	return items
+}
+
+func (st *splayTree[T]) PostOrder() []T {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	items := make([]T, 0, st.size)
+	st.postOrderHelper(st.root, &items)
+	return items
+}
+
+func (st *splayTree[T]) LevelOrder() []T {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.levelOrderHelper()
+}
+
+// Size returns number of items
+func (st *splayTree[T]) Size() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.size
+}
+
+// IsEmpty returns true if there are no items
+func (st *splayTree[T]) IsEmpty() bool {
+	return st.Size() == 0
+}
@@ -0,0 +1,137 @@
+package trees
+
+import "github.com/0x0FACED/go-collections/queue"
+
+// searchFunc returns func which says where `item` is relative to node's val
+func (st *splayTree[T]) searchFunc(item T) func(val T) int {
+	return func(val T) int {
+		return st.compare(item, val)
+	}
+}
+
+// access splays `item` to the root and records stats
+func (st *splayTree[T]) access(item T) {
+	var depth uint64
+	st.root, depth = st.splay(st.root, st.searchFunc(item))
+	st.stats.Accesses++
+	st.stats.TotalDepth += depth
+}
+
+// splay moves the node found by `where` to the root of subtree `t` and returns new root.
+// If there is no such node, the last node on the search path becomes root.
+//
+// `where` returns < 0 to go left, > 0 to go right, 0 to stop.
+//
+// Nodes which are less than target are linked to the max of left tree,
+// greater - to the min of right tree. Two steps in the same direction
+// are done with rotation (zig-zig), it halves depth of nodes on the path.
+//
+// Returns new root and its depth before splay
+func (st *splayTree[T]) splay(t *node[T], where func(val T) int) (*node[T], uint64) {
+	var header node[T]
+	l, r := &header, &header
+	depth := uint64(0)
+
+	for {
+		c := where(t.val)
+		if c < 0 {
+			if t.left == nil {
+				break
+			}
+			if where(t.left.val) < 0 {
+				// rotate right
+				y := t.left
+				t.left = y.right
+				y.right = t
+				t = y
+				depth++
+				if t.left == nil {
+					break
+				}
+			}
+			// link right
+			r.left = t
+			r = t
+			t = t.left
+		} else if c > 0 {
+			if t.right == nil {
+				break
+			}
+			if where(t.right.val) > 0 {
+				// rotate left
+				y := t.right
+				t.right = y.left
+				y.left = t
+				t = y
+				depth++
+				if t.right == nil {
+					break
+				}
+			}
+			// link left
+			l.right = t
+			l = t
+			t = t.right
+		} else {
+			break
+		}
+		depth++
+	}
+
+	// assemble
+	l.right = t.left
+	r.left = t.right
+	t.left = header.right
+	t.right = header.left
+	return t, depth
+}
+
+func (st *splayTree[T]) inOrderHelper(curr *node[T], items *[]T) {
+	if curr == nil {
+		return
+	}
+	st.inOrderHelper(curr.left, items)
+	*items = append(*items, curr.val)
+	st.inOrderHelper(curr.right, items)
+}
+
+func (st *splayTree[T]) preOrderHelper(curr *node[T], items *[]T) {
+	if curr == nil {
+		return
+	}
+	*items = append(*items, curr.val)
+	st.preOrderHelper(curr.left, items)
+	st.preOrderHelper(curr.right, items)
+}
+
+func (st *splayTree[T]) postOrderHelper(curr *node[T], items *[]T) {
+	if curr == nil {
+		return
+	}
+	st.postOrderHelper(curr.left, items)
+	st.postOrderHelper(curr.right, items)
+	*items = append(*items, curr.val)
+}
+
+func (st *splayTree[T]) levelOrderHelper() []T {
+	items := make([]T, 0, st.size)
+	if st.root == nil {
+		return items
+	}
+	q := queue.NewDynamicListQueue[*node[T]]()
+	q.Enqueue(st.root)
+	for !q.IsEmpty() {
+		curr, err := q.Dequeue()
+		if err != nil {
+			return nil
+		}
+		items = append(items, (*curr).val)
+		if (*curr).left != nil {
+			q.Enqueue((*curr).left)
+		}
+		if (*curr).right != nil {
+			q.Enqueue((*curr).right)
+		}
+	}
+	return items
+}
@@ -0,0 +1,149 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplayTree_Basic(t *testing.T) {
+	var tr TraversalTree[int] = NewSplayTree(intComparator)
+
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80} {
+		tr.Insert(v)
+	}
+	assert.Equal(t, []int{20, 30, 40, 50, 60, 70, 80}, tr.InOrder())
+	assert.Len(t, tr.PreOrder(), 7)
+	assert.Len(t, tr.PostOrder(), 7)
+	assert.Len(t, tr.LevelOrder(), 7)
+
+	val, err := tr.Search(40)
+	require.NoError(t, err)
+	assert.Equal(t, 40, *val)
+
+	require.NoError(t, tr.Delete(40))
+	_, err = tr.Search(40)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+	assert.Error(t, tr.Delete(40))
+	assert.Equal(t, []int{20, 30, 50, 60, 70, 80}, tr.InOrder())
+}
+
+func TestSplayTree_AccessMovesToRoot(t *testing.T) {
+	tr := NewSplayTree(intComparator)
+	for i := 0; i < 100; i++ {
+		tr.Insert(i)
+	}
+
+	// sorted inserts make a path, 0 is the deepest item
+	depth, err := tr.Depth(0)
+	require.NoError(t, err)
+	assert.Equal(t, 99, depth)
+
+	_, err = tr.Search(0)
+	require.NoError(t, err)
+	root, err := tr.Root()
+	require.NoError(t, err)
+	assert.Equal(t, 0, *root)
+	depth, _ = tr.Depth(0)
+	assert.Equal(t, 0, depth)
+
+	// splay of deep node roughly halves depth of nodes on its path
+	maxDepth := 0
+	for i := 0; i < 100; i++ {
+		depth, err = tr.Depth(i)
+		require.NoError(t, err)
+		maxDepth = max(maxDepth, depth)
+	}
+	assert.LessOrEqual(t, maxDepth, 51)
+
+	_, err = tr.Depth(1000)
+	assert.Error(t, err)
+}
+
+func TestSplayTree_Adaptivity(t *testing.T) {
+	const n = 1 << 12
+	rnd := rand.New(rand.NewSource(1))
+
+	run := func(next func() int) float64 {
+		tr := NewSplayTree(intComparator)
+		for _, v := range rnd.Perm(n) {
+			tr.Insert(v)
+		}
+		tr.ResetStats()
+		for i := 0; i < 10000; i++ {
+			_, _ = tr.Search(next())
+		}
+		stats := tr.Stats()
+		require.Equal(t, uint64(10000), stats.Accesses)
+		return stats.AverageDepth()
+	}
+
+	uniform := run(func() int { return rnd.Intn(n) })
+	// 90% of accesses go to 8 hot items
+	skewed := run(func() int {
+		if rnd.Intn(10) > 0 {
+			return rnd.Intn(8) * 500
+		}
+		return rnd.Intn(n)
+	})
+	assert.Less(t, skewed*2, uniform, "uniform=%.2f skewed=%.2f", uniform, skewed)
+}
+
+func TestSplayTree_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	tr := NewSplayTree(intComparator)
+	var expected []int
+
+	for op := 0; op < 3000; op++ {
+		v := rnd.Intn(200)
+		switch rnd.Intn(4) {
+		case 0, 1:
+			tr.Insert(v)
+			i, _ := slices.BinarySearch(expected, v)
+			expected = slices.Insert(expected, i, v)
+		case 2:
+			if i, found := slices.BinarySearch(expected, v); found {
+				require.NoError(t, tr.Delete(v))
+				expected = slices.Delete(expected, i, i+1)
+			} else {
+				require.Error(t, tr.Delete(v))
+			}
+		default:
+			_, found := slices.BinarySearch(expected, v)
+			_, err := tr.Search(v)
+			require.Equal(t, found, err == nil)
+		}
+		require.Equal(t, len(expected), tr.Size())
+	}
+	require.Equal(t, expected, tr.InOrder())
+
+	for len(expected) > 0 {
+		require.NoError(t, tr.Delete(expected[0]))
+		expected = expected[1:]
+	}
+	assert.True(t, tr.IsEmpty())
+	_, err := tr.Root()
+	assert.Equal(t, errors.New(gocollections.ErrEmpty), err)
+}
+
+func BenchmarkSplayTree_SkewedSearch(b *testing.B) {
+	tr := NewSplayTree(intComparator)
+	rnd := rand.New(rand.NewSource(1))
+	for _, v := range rnd.Perm(1 << 16) {
+		tr.Insert(v)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if i%10 > 0 {
+			_, _ = tr.Search(rnd.Intn(16))
+		} else {
+			_, _ = tr.Search(rnd.Intn(1 << 16))
+		}
+	}
+}
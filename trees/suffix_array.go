@@ -0,0 +1,163 @@
+package trees
+
+import (
+	"slices"
+	"sort"
+)
+
+// SubstringIndex is the interface of index over text which answers substring queries
+// without scanning the text. Positions are byte offsets in text.
+//
+// It is implemented by Suffix Array and Suffix Tree.
+//
+//	sa := trees.NewSuffixArray("banana")
+//	sa.Search("ana")                    // [1 3]
+//	sa.LongestRepeatedSubstring()       // "ana"
+//	sa.LongestCommonSubstring("ananas") // "anana"
+type SubstringIndex interface {
+	// Contains returns true if `pattern` is a substring of text
+	Contains(pattern string) bool
+
+	// Search returns sorted start positions of all occurrences of `pattern`
+	Search(pattern string) []int
+
+	// Count returns number of occurrences of `pattern`
+	Count(pattern string) int
+
+	// LongestRepeatedSubstring returns the longest substring which occurs
+	// at least twice (occurrences may overlap). If there are several -> any of them
+	LongestRepeatedSubstring() string
+
+	// LongestCommonSubstring returns the longest substring of both text and `other`.
+	// If there are several -> any of them
+	LongestCommonSubstring(other string) string
+
+	// Len returns length of text in bytes
+	Len() int
+}
+
+// SuffixArray is SubstringIndex which also gives its arrays
+type SuffixArray interface {
+	SubstringIndex
+
+	// Suffixes returns start positions of suffixes of text in lexicographic order
+	Suffixes() []int
+
+	// LCP returns lengths of the longest common prefix of neighbour suffixes:
+	// LCP()[i] is for Suffixes()[i-1] and Suffixes()[i], LCP()[0] = 0
+	LCP() []int
+}
+
+// suffixArray - Suffix Array of bytes of text with LCP array.
+//
+// Suffix array is built by prefix doubling with counting sort in O(n log n),
+// LCP by Kasai algorithm in O(n). Search of pattern is binary search in O(m log n).
+// Suffix array is not changed after creation, so it is safe for concurrent use
+type suffixArray struct {
+	text string
+	sa   []int
+	lcp  []int
+}
+
+// NewSuffixArray creates Suffix Array of `text`
+func NewSuffixArray(text string) *suffixArray {
+	s := make([]int, len(text))
+	for i := 0; i < len(text); i++ {
+		s[i] = int(text[i])
+	}
+	sa := buildSuffixArray(s, 256)
+	return &suffixArray{text: text, sa: sa, lcp: buildLCP(s, sa)}
+}
+
+// Contains returns true if `pattern` is a substring of text
+func (sa *suffixArray) Contains(pattern string) bool {
+	return sa.Count(pattern) > 0
+}
+
+// Search returns sorted start positions of all occurrences of `pattern`
+func (sa *suffixArray) Search(pattern string) []int {
+	lo, hi := sa.bounds(pattern)
+	res := slices.Clone(sa.sa[lo:hi])
+	slices.Sort(res)
+	return res
+}
+
+// Count returns number of occurrences of `pattern`
+func (sa *suffixArray) Count(pattern string) int {
+	lo, hi := sa.bounds(pattern)
+	return hi - lo
+}
+
+// LongestRepeatedSubstring returns the longest substring which occurs at least twice
+func (sa *suffixArray) LongestRepeatedSubstring() string {
+	best := 0
+	for i := range sa.lcp {
+		if sa.lcp[i] > sa.lcp[best] {
+			best = i
+		}
+	}
+	if len(sa.lcp) == 0 || sa.lcp[best] == 0 {
+		return ""
+	}
+	return sa.text[sa.sa[best] : sa.sa[best]+sa.lcp[best]]
+}
+
+// LongestCommonSubstring returns the longest substring of both text and `other`.
+//
+// Suffix array of text + separator + other is built: the answer is the longest LCP
+// of neighbour suffixes where one starts in text and other starts in `other`
+func (sa *suffixArray) LongestCommonSubstring(other string) string {
+	s := make([]int, 0, len(sa.text)+len(other)+1)
+	for i := 0; i < len(sa.text); i++ {
+		s = append(s, int(sa.text[i]))
+	}
+	// separator is not a byte, so common prefix never goes over it
+	s = append(s, 256)
+	for i := 0; i < len(other); i++ {
+		s = append(s, int(other[i]))
+	}
+
+	suffixes := buildSuffixArray(s, 257)
+	lcp := buildLCP(s, suffixes)
+	n := len(sa.text)
+	bestLen, bestPos := 0, 0
+	for i := 1; i < len(suffixes); i++ {
+		if (suffixes[i] < n) != (suffixes[i-1] < n) && lcp[i] > bestLen {
+			bestLen, bestPos = lcp[i], suffixes[i]
+		}
+	}
+	if bestPos > n {
+		return other[bestPos-n-1 : bestPos-n-1+bestLen]
+	}
+	return sa.text[bestPos : bestPos+bestLen]
+}
+
+// Len returns length of text in bytes
+func (sa *suffixArray) Len() int {
+	return len(sa.text)
+}
+
+// Suffixes returns start positions of suffixes of text in lexicographic order
+func (sa *suffixArray) Suffixes() []int {
+	return slices.Clone(sa.sa)
+}
+
+// LCP returns lengths of the longest common prefix of neighbour suffixes
+func (sa *suffixArray) LCP() []int {
+	return slices.Clone(sa.lcp)
+}
+
+// bounds returns range [lo, hi) of suffixes which start with `pattern`
+func (sa *suffixArray) bounds(pattern string) (int, int) {
+	suffix := func(i int) string {
+		return sa.text[sa.sa[i]:]
+	}
+	lo := sort.Search(len(sa.sa), func(i int) bool {
+		return suffix(i) >= pattern
+	})
+	hi := lo + sort.Search(len(sa.sa)-lo, func(i int) bool {
+		s := suffix(lo + i)
+		return len(s) < len(pattern) || s[:len(pattern)] != pattern
+	})
+	return lo, hi
+}
@@ -0,0 +1,104 @@
+package trees
+
+// buildSuffixArray sorts suffixes of `s` (values in [0, alphabet)) by prefix doubling:
+// after step k suffixes are sorted by the first 2k elements. Suffix is sorted by pair
+// (rank of first k, rank of next k), pairs are sorted by two counting sorts
+func buildSuffixArray(s []int, alphabet int) []int {
+	n := len(s)
+	sa := make([]int, n)
+	if n == 0 {
+		return sa
+	}
+	rank := make([]int, n)
+	tmp := make([]int, n)
+	count := make([]int, max(alphabet, n)+1)
+
+	// sort by the first element
+	for _, v := range s {
+		count[v+1]++
+	}
+	for i := 1; i < len(count); i++ {
+		count[i] += count[i-1]
+	}
+	for i, v := range s {
+		sa[count[v]] = i
+		count[v]++
+	}
+	for i := 1; i < n; i++ {
+		rank[sa[i]] = rank[sa[i-1]]
+		if s[sa[i]] != s[sa[i-1]] {
+			rank[sa[i]]++
+		}
+	}
+
+	for k := 1; k < n && rank[sa[n-1]] < n-1; k <<= 1 {
+		// sorted by second half: suffixes without it go first
+		idx := 0
+		for i := n - k; i < n; i++ {
+			tmp[idx] = i
+			idx++
+		}
+		for _, i := range sa {
+			if i >= k {
+				tmp[idx] = i - k
+				idx++
+			}
+		}
+
+		// stable sort by first half
+		clear(count)
+		for _, i := range tmp {
+			count[rank[i]+1]++
+		}
+		for i := 1; i < len(count); i++ {
+			count[i] += count[i-1]
+		}
+		for _, i := range tmp {
+			sa[count[rank[i]]] = i
+			count[rank[i]]++
+		}
+
+		// new ranks: equal pairs have equal rank
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return -1
+		}
+		tmp[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			tmp[sa[i]] = tmp[sa[i-1]]
+			if rank[sa[i]] != rank[sa[i-1]] || second(sa[i]) != second(sa[i-1]) {
+				tmp[sa[i]]++
+			}
+		}
+		rank, tmp = tmp, rank
+	}
+	return sa
+}
+
+// buildLCP builds LCP array by Kasai algorithm: LCP of suffix i+1 with its
+// previous suffix is at least LCP of suffix i minus 1
+func buildLCP(s []int, sa []int) []int {
+	n := len(s)
+	lcp := make([]int, n)
+	rank := make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		h = max(h-1, 0)
+	}
+	return lcp
+}
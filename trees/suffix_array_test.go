@@ -0,0 +1,152 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuffixArray_Banana(t *testing.T) {
+	sa := NewSuffixArray("banana")
+	assert.Equal(t, 6, sa.Len())
+	// a, ana, anana, banana, na, nana
+	assert.Equal(t, []int{5, 3, 1, 0, 4, 2}, sa.Suffixes())
+	assert.Equal(t, []int{0, 1, 3, 0, 0, 2}, sa.LCP())
+
+	assert.Equal(t, []int{1, 3}, sa.Search("ana"))
+	assert.Equal(t, 3, sa.Count("a"))
+	assert.True(t, sa.Contains("nan"))
+	assert.False(t, sa.Contains("nab"))
+	assert.Empty(t, sa.Search("bananas"))
+	assert.Equal(t, "ana", sa.LongestRepeatedSubstring())
+	assert.Equal(t, "anana", sa.LongestCommonSubstring("ananas"))
+	assert.Equal(t, "", sa.LongestCommonSubstring("xyz"))
+}
+
+func TestSuffixArray_Empty(t *testing.T) {
+	sa := NewSuffixArray("")
+	assert.Empty(t, sa.Suffixes())
+	assert.Equal(t, 0, sa.Count("a"))
+	assert.Equal(t, 0, sa.Count(""))
+	assert.Equal(t, "", sa.LongestRepeatedSubstring())
+	assert.Equal(t, "", sa.LongestCommonSubstring("abc"))
+}
+
+func TestSuffixArray_Random(t *testing.T) {
+	testSubstringIndexRandom(t, func(text string) SubstringIndex { return NewSuffixArray(text) })
+
+	rnd := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 200; iter++ {
+		text := randomText(rnd, "ab\x00\xff", rnd.Intn(50))
+		sa := NewSuffixArray(text)
+
+		expected := make([]int, len(text))
+		for i := range expected {
+			expected[i] = i
+		}
+		sort.Slice(expected, func(i, j int) bool { return text[expected[i]:] < text[expected[j]:] })
+		require.Equal(t, expected, sa.Suffixes(), "text %q", text)
+
+		suffixes, lcp := sa.Suffixes(), sa.LCP()
+		for i := 1; i < len(suffixes); i++ {
+			require.Equal(t, commonPrefixLen(text[suffixes[i-1]:], text[suffixes[i]:]), lcp[i])
+		}
+	}
+}
+
+// testSubstringIndexRandom compares queries of index with brute force on random texts
+func testSubstringIndexRandom(t *testing.T, build func(text string) SubstringIndex) {
+	rnd := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 300; iter++ {
+		alphabet := []string{"a", "ab", "abc", "ab\x00\xff"}[rnd.Intn(4)]
+		text := randomText(rnd, alphabet, rnd.Intn(60))
+		index := build(text)
+		msg := fmt.Sprintf("text %q", text)
+		require.Equal(t, len(text), index.Len(), msg)
+
+		for i := 0; i < 20; i++ {
+			pattern := randomText(rnd, alphabet, rnd.Intn(5))
+			expected := bruteSearch(text, pattern)
+			require.Equal(t, expected, index.Search(pattern), "%s, pattern %q", msg, pattern)
+			require.Equal(t, len(expected), index.Count(pattern), "%s, pattern %q", msg, pattern)
+			require.Equal(t, len(expected) > 0, index.Contains(pattern), "%s, pattern %q", msg, pattern)
+		}
+
+		repeated := index.LongestRepeatedSubstring()
+		require.Equal(t, bruteLongestRepeated(text), len(repeated), msg)
+		if repeated != "" {
+			require.GreaterOrEqual(t, len(bruteSearch(text, repeated)), 2, msg)
+		}
+
+		other := randomText(rnd, alphabet, rnd.Intn(60))
+		common := index.LongestCommonSubstring(other)
+		require.Equal(t, bruteLongestCommon(text, other), len(common), "%s, other %q", msg, other)
+		require.True(t, strings.Contains(text, common) && strings.Contains(other, common), "%s, other %q", msg, other)
+	}
+}
+
+func randomText(rnd *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[rnd.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// bruteSearch returns start positions of all occurrences of pattern
+func bruteSearch(text, pattern string) []int {
+	res := []int{}
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		if text[i:i+len(pattern)] == pattern && (pattern != "" || i < len(text)) {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// bruteLongestRepeated returns length of the longest substring which occurs twice
+func bruteLongestRepeated(text string) int {
+	best := 0
+	for i := 0; i < len(text); i++ {
+		for j := i + 1; j < len(text); j++ {
+			best = max(best, commonPrefixLen(text[i:], text[j:]))
+		}
+	}
+	return best
+}
+
+// bruteLongestCommon returns length of the longest common substring
+func bruteLongestCommon(a, b string) int {
+	best := 0
+	for i := 0; i < len(a); i++ {
+		for j := 0; j < len(b); j++ {
+			best = max(best, commonPrefixLen(a[i:], b[j:]))
+		}
+	}
+	return best
+}
+
+func BenchmarkSuffixArray_Build(b *testing.B) {
+	text := randomText(rand.New(rand.NewSource(1)), "acgt", 1<<20)
+	b.SetBytes(int64(len(text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewSuffixArray(text)
+	}
+}
+
+func BenchmarkSuffixArray_Search(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	text := randomText(rnd, "acgt", 1<<20)
+	sa := NewSuffixArray(text)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pos := rnd.Intn(len(text) - 16)
+		sa.Search(text[pos : pos+16])
+	}
+}
@@ -0,0 +1,144 @@
+package trees
+
+import "slices"
+
+// stNode - node of Suffix Tree. Edge to node is labeled by s[start:end+1],
+// for leaves end is leafEnd of tree, it grows while tree is built.
+//
+// # link 	-> suffix link: node of the same string without the first element
+//
+// # suffix 	-> for leaf: start of its suffix, for internal node: -1
+//
+// # depth 	-> length of string from root to the end of edge
+//
+// # leaves 	-> number of leaves in subtree = number of occurrences of node string
+type stNode struct {
+	children map[int]*stNode
+	start    int
+	end      int
+	link     *stNode
+
+	suffix int
+	depth  int
+	leaves int
+}
+
+// suffixTree - Suffix Tree of bytes of text, built by Ukkonen algorithm in O(n).
+//
+// Text is ended by terminator which is not a byte, so every suffix ends in leaf.
+// Search of pattern is walk from root in O(m), occurrences are leaves below.
+// Suffix tree is not changed after creation, so it is safe for concurrent use
+type suffixTree struct {
+	text string
+	s    []int
+	root *stNode
+
+	// state of Ukkonen algorithm
+	activeNode   *stNode
+	activeEdge   int
+	activeLength int
+	remaining    int
+	leafEnd      int
+}
+
+// NewSuffixTree creates Suffix Tree of `text`
+func NewSuffixTree(text string) *suffixTree {
+	s := make([]int, 0, len(text)+1)
+	for i := 0; i < len(text); i++ {
+		s = append(s, int(text[i]))
+	}
+	s = append(s, 256)
+	return buildSuffixTree(text, s)
+}
+
+// Contains returns true if `pattern` is a substring of text
+func (st *suffixTree) Contains(pattern string) bool {
+	return st.Count(pattern) > 0
+}
+
+// Search returns sorted start positions of all occurrences of `pattern`
+func (st *suffixTree) Search(pattern string) []int {
+	node := st.find(pattern)
+	if node == nil {
+		return []int{}
+	}
+	res := make([]int, 0, node.leaves)
+	collectSuffixes(node, &res)
+	slices.Sort(res)
+	if node == st.root {
+		// empty pattern: leaf of terminator is not a position in text
+		res = res[:len(res)-1]
+	}
+	return res
+}
+
+// Count returns number of occurrences of `pattern`
+func (st *suffixTree) Count(pattern string) int {
+	node := st.find(pattern)
+	if node == nil {
+		return 0
+	}
+	if node == st.root {
+		return node.leaves - 1
+	}
+	return node.leaves
+}
+
+// LongestRepeatedSubstring returns the longest substring which occurs at least twice:
+// it is string of the deepest internal node
+func (st *suffixTree) LongestRepeatedSubstring() string {
+	deepest := st.root
+	st.walkInternal(func(node *stNode) {
+		if node.depth > deepest.depth {
+			deepest = node
+		}
+	})
+	return st.label(deepest)
+}
+
+// LongestCommonSubstring returns the longest substring of both text and `other`.
+//
+// Generalized suffix tree of text + separator + other + terminator is built:
+// the answer is the deepest internal node which has leaves of both texts
+func (st *suffixTree) LongestCommonSubstring(other string) string {
+	s := make([]int, 0, len(st.text)+len(other)+2)
+	s = append(s, st.s[:len(st.text)]...)
+	s = append(s, 256)
+	for i := 0; i < len(other); i++ {
+		s = append(s, int(other[i]))
+	}
+	s = append(s, 257)
+	gst := buildSuffixTree(st.text+"\x00"+other, s)
+
+	var best *stNode
+	n := len(st.text)
+	// mask of texts which have leaves in subtree: 1 - text, 2 - other
+	var dfs func(node *stNode) int
+	dfs = func(node *stNode) int {
+		if node.children == nil {
+			if node.suffix <= n {
+				return 1
+			}
+			return 2
+		}
+		mask := 0
+		for _, child := range node.children {
+			mask |= dfs(child)
+		}
+		if mask == 3 && node != gst.root && (best == nil || node.depth > best.depth) {
+			best = node
+		}
+		return mask
+	}
+	dfs(gst.root)
+
+	if best == nil {
+		return ""
+	}
+	return gst.label(best)
+}
+
+// Len returns length of text in bytes
+func (st *suffixTree) Len() int {
+	return len(st.text)
+}
@@ -0,0 +1,164 @@
+package trees
+
+// buildSuffixTree builds Suffix Tree of `s` which ends with unique terminator,
+// `text` is bytes of `s` without terminator (separators are bytes too)
+func buildSuffixTree(text string, s []int) *suffixTree {
+	st := &suffixTree{text: text, s: s, leafEnd: -1}
+	st.root = st.newNode(-1, -1)
+	st.activeNode = st.root
+	for i := range s {
+		st.extend(i)
+	}
+	st.finish(st.root, 0)
+	return st
+}
+
+func (st *suffixTree) newNode(start, end int) *stNode {
+	return &stNode{children: make(map[int]*stNode), start: start, end: end, link: st.root, suffix: -1}
+}
+
+func (st *suffixTree) newLeaf(start int) *stNode {
+	return &stNode{start: start, link: st.root, suffix: -1}
+}
+
+// edgeEnd returns the last index of edge label of `node`
+func (st *suffixTree) edgeEnd(node *stNode) int {
+	if node.children == nil {
+		return st.leafEnd
+	}
+	return node.end
+}
+
+func (st *suffixTree) edgeLength(node *stNode) int {
+	return st.edgeEnd(node) - node.start + 1
+}
+
+// extend adds s[pos] to all suffixes of s[:pos] (phase of Ukkonen algorithm).
+// Leaves are extended at once by leafEnd, then `remaining` suffixes which are
+// not added yet are added from active point, moving by suffix links
+func (st *suffixTree) extend(pos int) {
+	st.leafEnd = pos
+	st.remaining++
+	var lastNew *stNode
+
+	for st.remaining > 0 {
+		if st.activeLength == 0 {
+			st.activeEdge = pos
+		}
+
+		next, ok := st.activeNode.children[st.s[st.activeEdge]]
+		if !ok {
+			st.activeNode.children[st.s[st.activeEdge]] = st.newLeaf(pos)
+			if lastNew != nil {
+				lastNew.link = st.activeNode
+				lastNew = nil
+			}
+		} else {
+			// active point is after the end of edge -> go down
+			if length := st.edgeLength(next); st.activeLength >= length {
+				st.activeEdge += length
+				st.activeLength -= length
+				st.activeNode = next
+				continue
+			}
+
+			// s[pos] is already there: this and all next suffixes are implicit
+			if st.s[next.start+st.activeLength] == st.s[pos] {
+				if lastNew != nil && st.activeNode != st.root {
+					lastNew.link = st.activeNode
+				}
+				st.activeLength++
+				break
+			}
+
+			// split edge in the active point
+			split := st.newNode(next.start, next.start+st.activeLength-1)
+			st.activeNode.children[st.s[st.activeEdge]] = split
+			split.children[st.s[pos]] = st.newLeaf(pos)
+			next.start += st.activeLength
+			split.children[st.s[next.start]] = next
+			if lastNew != nil {
+				lastNew.link = split
+			}
+			lastNew = split
+		}
+
+		st.remaining--
+		if st.activeNode == st.root && st.activeLength > 0 {
+			st.activeLength--
+			st.activeEdge = pos - st.remaining + 1
+		} else if st.activeNode != st.root {
+			st.activeNode = st.activeNode.link
+		}
+	}
+}
+
+// finish sets depth, suffix and number of leaves for nodes of subtree
+func (st *suffixTree) finish(node *stNode, depth int) {
+	node.depth = depth
+	if node.children == nil {
+		node.end = st.leafEnd
+		node.suffix = len(st.s) - depth
+		node.leaves = 1
+		return
+	}
+	for _, child := range node.children {
+		st.finish(child, depth+st.edgeLength(child))
+		node.leaves += child.leaves
+	}
+}
+
+// find returns the highest node whose string starts with `pattern`
+// or nil if `pattern` is not in text
+func (st *suffixTree) find(pattern string) *stNode {
+	node := st.root
+	for i := 0; i < len(pattern); {
+		child, ok := node.children[int(pattern[i])]
+		if !ok {
+			return nil
+		}
+		for j := child.start; j <= child.end && i < len(pattern); j++ {
+			if st.s[j] != int(pattern[i]) {
+				return nil
+			}
+			i++
+		}
+		node = child
+	}
+	return node
+}
+
+// label returns string from root to the end of edge of `node`
+func (st *suffixTree) label(node *stNode) string {
+	if node == st.root {
+		return ""
+	}
+	return st.text[node.end+1-node.depth : node.end+1]
+}
+
+// walkInternal calls `fn` for internal nodes except root
+func (st *suffixTree) walkInternal(fn func(node *stNode)) {
+	var dfs func(node *stNode)
+	dfs = func(node *stNode) {
+		if node != st.root {
+			fn(node)
+		}
+		for _, child := range node.children {
+			if child.children != nil {
+				dfs(child)
+			}
+		}
+	}
+	dfs(st.root)
+}
+
+// collectSuffixes appends starts of suffixes of leaves of subtree to `res`
+func collectSuffixes(node *stNode, res *[]int) {
+	if node.children == nil {
+		*res = append(*res, node.suffix)
+		return
+	}
+	for _, child := range node.children {
+		collectSuffixes(child, res)
+	}
+}
@@ -0,0 +1,98 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuffixTree_Banana(t *testing.T) {
+	st := NewSuffixTree("banana")
+	checkSuffixTree(t, st)
+	assert.Equal(t, 6, st.Len())
+
+	assert.Equal(t, []int{1, 3}, st.Search("ana"))
+	assert.Equal(t, 3, st.Count("a"))
+	assert.Equal(t, 6, st.Count(""))
+	assert.True(t, st.Contains("nan"))
+	assert.False(t, st.Contains("nab"))
+	assert.Empty(t, st.Search("bananas"))
+	assert.Equal(t, "ana", st.LongestRepeatedSubstring())
+	assert.Equal(t, "anana", st.LongestCommonSubstring("ananas"))
+	assert.Equal(t, "", st.LongestCommonSubstring("xyz"))
+}
+
+func TestSuffixTree_Empty(t *testing.T) {
+	st := NewSuffixTree("")
+	checkSuffixTree(t, st)
+	assert.Equal(t, 0, st.Count("a"))
+	assert.Equal(t, []int{}, st.Search(""))
+	assert.Equal(t, "", st.LongestRepeatedSubstring())
+	assert.Equal(t, "", st.LongestCommonSubstring("abc"))
+}
+
+func TestSuffixTree_Random(t *testing.T) {
+	testSubstringIndexRandom(t, func(text string) SubstringIndex {
+		st := NewSuffixTree(text)
+		checkSuffixTree(t, st)
+		return st
+	})
+}
+
+func TestSuffixTree_SameAsSuffixArray(t *testing.T) {
+	text := randomText(rand.New(rand.NewSource(1)), "acgt", 20000)
+	st, sa := NewSuffixTree(text), NewSuffixArray(text)
+	checkSuffixTree(t, st)
+	assert.Equal(t, len(sa.LongestRepeatedSubstring()), len(st.LongestRepeatedSubstring()))
+	for i := 0; i+8 <= len(text); i += 997 {
+		assert.Equal(t, sa.Search(text[i:i+8]), st.Search(text[i:i+8]))
+	}
+}
+
+// checkSuffixTree checks that every suffix ends in its own leaf and
+// internal nodes (except root) are branching
+func checkSuffixTree(t *testing.T, st *suffixTree) {
+	t.Helper()
+	var leaves []int
+	var dfs func(node *stNode)
+	dfs = func(node *stNode) {
+		if node.children == nil {
+			require.Equal(t, len(st.s)-node.depth, node.suffix)
+			require.Equal(t, st.s[node.suffix:], st.s[node.end+1-node.depth:node.end+1])
+			leaves = append(leaves, node.suffix)
+			return
+		}
+		if node != st.root {
+			require.GreaterOrEqual(t, len(node.children), 2)
+		}
+		for first, child := range node.children {
+			require.Equal(t, first, st.s[child.start])
+			dfs(child)
+		}
+	}
+	dfs(st.root)
+	require.Len(t, leaves, len(st.s))
+	require.Equal(t, len(st.s), st.root.leaves)
+}
+
+func BenchmarkSuffixTree_Build(b *testing.B) {
+	text := randomText(rand.New(rand.NewSource(1)), "acgt", 1<<20)
+	b.SetBytes(int64(len(text)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewSuffixTree(text)
+	}
+}
+
+func BenchmarkSuffixTree_Search(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	text := randomText(rnd, "acgt", 1<<20)
+	st := NewSuffixTree(text)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pos := rnd.Intn(len(text) - 16)
+		st.Search(text[pos : pos+16])
+	}
+}
@@ -0,0 +1,165 @@
+package trees
+
+import (
+	"fmt"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// TernarySearchTree is the Trie which also finds near neighbours of items
+// and iterates over items by prefix in order
+type TernarySearchTree[T any] interface {
+	Trie[T]
+
+	// KeysWithPrefix returns at most `limit` items which start with prefix
+	// in lexicographic order. If limit <= 0 -> returns all such items
+	KeysWithPrefix(prefix T, limit int) []T
+
+	// WalkPrefix calls `fn` for items which start with prefix
+	// in lexicographic order until `fn` returns false
+	WalkPrefix(prefix T, fn func(item T) bool)
+
+	// NearNeighbors returns items of the same length (in chars) as `item` which differ
+	// from it in at most `distance` chars (Hamming distance), in lexicographic order
+	NearNeighbors(item T, distance int) []T
+
+	// Size returns number of items
+	Size() int
+}
+
+// tstNode - node of Ternary Search Tree. Node has char `ch`:
+// `left` and `right` are nodes with smaller and greater chars at the same position (BST),
+// `mid` is the next char after `ch`. `val` is the item which ends in this node (if isEnd)
+//
+// # size 	-> number of items in subtree (with left and right)
+type tstNode[T any] struct {
+	ch    rune
+	left  *tstNode[T]
+	mid   *tstNode[T]
+	right *tstNode[T]
+
+	val   T
+	isEnd bool
+
+	size int
+}
+
+// ternarySearchTree - Ternary Search Tree: Trie where children of node are BST
+// instead of map, so node is small and memory is much less than in trie.
+//
+// Search of item of m chars is O(m + log of alphabet) for balanced tree.
+// `root` is not a char: it keeps empty item and its `mid` is the first char
+type ternarySearchTree[T comparable] struct {
+	root     tstNode[T]
+	compare  Comparator[T]
+	toString func(T) string
+}
+
+// NewTernarySearchTree creates empty Ternary Search Tree, params are the same as for NewTrie
+func NewTernarySearchTree[T comparable](cmp Comparator[T], toString func(T) string) *ternarySearchTree[T] {
+	t := &ternarySearchTree[T]{compare: cmp, toString: toString}
+	updateTST(&t.root)
+	return t
+}
+
+// Insert inserts the item to Ternary Search Tree
+func (t *ternarySearchTree[T]) Insert(item T) {
+	key := []rune(t.toString(item))
+	if len(key) == 0 {
+		t.root.setItem(item)
+	} else {
+		t.root.mid = t.insert(t.root.mid, key, 0, item)
+	}
+	updateTST(&t.root)
+}
+
+// Search finds if the element exists in the Ternary Search Tree
+func (t *ternarySearchTree[T]) Search(item T) bool {
+	node := t.findNode(t.toString(item))
+	return node != nil && node.isEnd
+}
+
+// StartsWith returns true, if there are elements in the Ternary Search Tree starts with prefix.
+// Like in trie, it checks only the path of prefix, so empty prefix is always true
+func (t *ternarySearchTree[T]) StartsWith(prefix T) bool {
+	return t.findNode(t.toString(prefix)) != nil
+}
+
+// CountByPrefix returns int number of elements which have prefix arg.
+// Subtrees keep their sizes, so it is O(len of prefix)
+func (t *ternarySearchTree[T]) CountByPrefix(prefix T) int {
+	node := t.findNode(t.toString(prefix))
+	if node == nil {
+		return 0
+	}
+	count := sizeTST(node.mid)
+	if node.isEnd {
+		count++
+	}
+	return count
+}
+
+// Delete deletes the item and removes nodes which are not needed anymore
+//
+// if there is no item in Ternary Search Tree -> returns err
+func (t *ternarySearchTree[T]) Delete(item T) error {
+	key := []rune(t.toString(item))
+	if len(key) == 0 {
+		if !t.root.isEnd {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+		t.root.clearItem()
+	} else {
+		mid, found := t.delete(t.root.mid, key, 0)
+		if !found {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+		t.root.mid = mid
+	}
+	updateTST(&t.root)
+	return nil
+}
+
+// KeysWithPrefix returns at most `limit` items which start with prefix
+// in lexicographic order. If limit <= 0 -> returns all such items
+func (t *ternarySearchTree[T]) KeysWithPrefix(prefix T, limit int) []T {
+	var items []T
+	t.WalkPrefix(prefix, func(item T) bool {
+		items = append(items, item)
+		return limit <= 0 || len(items) < limit
+	})
+	return items
+}
+
+// WalkPrefix calls `fn` for items which start with prefix
+// in lexicographic order until `fn` returns false
+func (t *ternarySearchTree[T]) WalkPrefix(prefix T, fn func(item T) bool) {
+	node := t.findNode(t.toString(prefix))
+	if node == nil || (node.isEnd && !fn(node.val)) {
+		return
+	}
+	walkTST(node.mid, fn)
+}
+
+// NearNeighbors returns items of the same length as `item` which differ
+// from it in at most `distance` chars, in lexicographic order
+func (t *ternarySearchTree[T]) NearNeighbors(item T, distance int) []T {
+	var res []T
+	key := []rune(t.toString(item))
+	if distance < 0 {
+		return res
+	}
+	if len(key) == 0 {
+		if t.root.isEnd {
+			res = append(res, t.root.val)
+		}
+		return res
+	}
+	nearNeighbors(t.root.mid, key, 0, distance, &res)
+	return res
+}
+
+// Size returns number of items
+func (t *ternarySearchTree[T]) Size() int {
+	return t.root.size
+}
@@ -0,0 +1,172 @@
+package trees
+
+import "unicode/utf8"
+
+func (n *tstNode[T]) setItem(item T) {
+	n.val = item
+	n.isEnd = true
+}
+
+func (n *tstNode[T]) clearItem() {
+	var zero T
+	n.val = zero
+	n.isEnd = false
+}
+
+func sizeTST[T any](node *tstNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// updateTST recalculates size of `node` from its children
+func updateTST[T any](node *tstNode[T]) {
+	node.size = sizeTST(node.left) + sizeTST(node.mid) + sizeTST(node.right)
+	if node.isEnd {
+		node.size++
+	}
+}
+
+// insert inserts `item` with key[i:] to subtree and returns new subtree
+func (t *ternarySearchTree[T]) insert(node *tstNode[T], key []rune, i int, item T) *tstNode[T] {
+	if node == nil {
+		node = &tstNode[T]{ch: key[i]}
+	}
+	switch {
+	case key[i] < node.ch:
+		node.left = t.insert(node.left, key, i, item)
+	case key[i] > node.ch:
+		node.right = t.insert(node.right, key, i, item)
+	case i < len(key)-1:
+		node.mid = t.insert(node.mid, key, i+1, item)
+	default:
+		node.setItem(item)
+	}
+	updateTST(node)
+	return node
+}
+
+// delete deletes item with key[i:] from subtree and returns new subtree
+// and false if there is no such item
+func (t *ternarySearchTree[T]) delete(node *tstNode[T], key []rune, i int) (*tstNode[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+	var found bool
+	switch {
+	case key[i] < node.ch:
+		node.left, found = t.delete(node.left, key, i)
+	case key[i] > node.ch:
+		node.right, found = t.delete(node.right, key, i)
+	case i < len(key)-1:
+		node.mid, found = t.delete(node.mid, key, i+1)
+	default:
+		found = node.isEnd
+		if found {
+			node.clearItem()
+		}
+	}
+	if !found {
+		return node, false
+	}
+	return pruneTST(node), true
+}
+
+// pruneTST removes `node` if it has no item and no next chars,
+// like delete from BST: node is replaced by its child or by the min node of right subtree
+func pruneTST[T any](node *tstNode[T]) *tstNode[T] {
+	if node.isEnd || node.mid != nil {
+		updateTST(node)
+		return node
+	}
+	if node.left == nil {
+		return node.right
+	}
+	if node.right == nil {
+		return node.left
+	}
+	right, successor := removeMinTST(node.right)
+	successor.left, successor.right = node.left, right
+	updateTST(successor)
+	return successor
+}
+
+// removeMinTST removes node with min char from BST of `node` and returns new BST and that node
+func removeMinTST[T any](node *tstNode[T]) (*tstNode[T], *tstNode[T]) {
+	if node.left == nil {
+		return node.right, node
+	}
+	var minNode *tstNode[T]
+	node.left, minNode = removeMinTST(node.left)
+	updateTST(node)
+	return node, minNode
+}
+
+// findNode returns node of `str` (root for empty) or nil
+func (t *ternarySearchTree[T]) findNode(str string) *tstNode[T] {
+	if str == "" {
+		return &t.root
+	}
+	node := t.root.mid
+	ch, size := utf8.DecodeRuneInString(str)
+	for node != nil {
+		switch {
+		case ch < node.ch:
+			node = node.left
+		case ch > node.ch:
+			node = node.right
+		case size == len(str):
+			return node
+		default:
+			str = str[size:]
+			ch, size = utf8.DecodeRuneInString(str)
+			node = node.mid
+		}
+	}
+	return nil
+}
+
+// walkTST calls `fn` for items of subtree in lexicographic order.
+// Returns false if `fn` returned false
+func walkTST[T any](node *tstNode[T], fn func(item T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !walkTST(node.left, fn) {
+		return false
+	}
+	if node.isEnd && !fn(node.val) {
+		return false
+	}
+	return walkTST(node.mid, fn) && walkTST(node.right, fn)
+}
+
+// nearNeighbors adds items which differ from key in at most `d` chars of key[i:]
+// (and have the same length). Other chars are visited only while `d` > 0
+func nearNeighbors[T any](node *tstNode[T], key []rune, i, d int, res *[]T) {
+	if node == nil {
+		return
+	}
+	if d > 0 || key[i] < node.ch {
+		nearNeighbors(node.left, key, i, d, res)
+	}
+
+	rest := d
+	if key[i] != node.ch {
+		rest--
+	}
+	if rest >= 0 {
+		if i == len(key)-1 {
+			if node.isEnd {
+				*res = append(*res, node.val)
+			}
+		} else {
+			nearNeighbors(node.mid, key, i+1, rest, res)
+		}
+	}
+
+	if d > 0 || key[i] > node.ch {
+		nearNeighbors(node.right, key, i, d, res)
+	}
+}
@@ -0,0 +1,216 @@
+package trees
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTernarySearchTree_InsertAndSearch(t *testing.T) {
+	tst := NewTernarySearchTree(stringComparator, stringToString)
+	for _, word := range []string{"cat", "cats", "cap", "up", "bug", "ápp"} {
+		tst.Insert(word)
+	}
+	tst.Insert("cat")
+	checkTST(t, tst)
+
+	assert.Equal(t, 6, tst.Size())
+	assert.True(t, tst.Search("cat"))
+	assert.True(t, tst.Search("ápp"))
+	assert.False(t, tst.Search("ca"))
+	assert.False(t, tst.Search(""))
+	assert.True(t, tst.StartsWith("ca"))
+	assert.False(t, tst.StartsWith("dog"))
+	assert.Equal(t, 3, tst.CountByPrefix("ca"))
+	assert.Equal(t, 6, tst.CountByPrefix(""))
+	assert.Equal(t, []string{"bug", "cap", "cat", "cats", "up", "ápp"}, tst.KeysWithPrefix("", 0))
+	assert.Equal(t, []string{"cat", "cats"}, tst.KeysWithPrefix("cat", 5))
+
+	// empty item is kept in root
+	tst.Insert("")
+	assert.True(t, tst.Search(""))
+	assert.Equal(t, []string{"", "bug"}, tst.KeysWithPrefix("", 2))
+
+	tstInts := NewTernarySearchTree(intComparator, intToString)
+	for _, v := range []int{123, 1, 162, 18, 199, 12, 456} {
+		tstInts.Insert(v)
+	}
+	assert.Equal(t, []int{1, 12, 123, 162, 18, 199}, tstInts.KeysWithPrefix(1, 0))
+	assert.Equal(t, 2, tstInts.CountByPrefix(12))
+}
+
+func TestTernarySearchTree_Empty(t *testing.T) {
+	tst := NewTernarySearchTree(stringComparator, stringToString)
+	tr := NewTrie(stringComparator, stringToString)
+	for _, prefix := range []string{"", "a"} {
+		assert.Equal(t, tr.StartsWith(prefix), tst.StartsWith(prefix), "prefix %q", prefix)
+		assert.Equal(t, tr.Search(prefix), tst.Search(prefix), "prefix %q", prefix)
+		assert.Equal(t, tr.CountByPrefix(prefix), tst.CountByPrefix(prefix), "prefix %q", prefix)
+	}
+	assert.True(t, tst.StartsWith(""))
+	assert.False(t, tst.StartsWith("a"))
+}
+
+func TestTernarySearchTree_Delete(t *testing.T) {
+	tst := NewTernarySearchTree(stringComparator, stringToString)
+	for _, word := range []string{"m", "d", "t", "b", "f", "e", "g", "me", "men"} {
+		tst.Insert(word)
+	}
+
+	assert.EqualError(t, tst.Delete("x"), gocollections.ErrNotFound)
+	assert.EqualError(t, tst.Delete("mex"), gocollections.ErrNotFound)
+	assert.EqualError(t, tst.Delete(""), gocollections.ErrNotFound)
+
+	// "d" has both children: it is replaced by "e", the min of right subtree
+	require.NoError(t, tst.Delete("d"))
+	checkTST(t, tst)
+	assert.Equal(t, 'e', tst.root.mid.left.ch)
+	assert.Equal(t, []string{"b", "e", "f", "g", "m", "me", "men", "t"}, tst.KeysWithPrefix("", 0))
+
+	// "m" has next chars, so node stays
+	require.NoError(t, tst.Delete("m"))
+	checkTST(t, tst)
+	assert.Equal(t, 'm', tst.root.mid.ch)
+
+	require.NoError(t, tst.Delete("men"))
+	require.NoError(t, tst.Delete("me"))
+	checkTST(t, tst)
+	assert.Equal(t, []string{"b", "e", "f", "g", "t"}, tst.KeysWithPrefix("", 0))
+
+	for _, word := range []string{"b", "e", "f", "g", "t"} {
+		require.NoError(t, tst.Delete(word))
+		checkTST(t, tst)
+	}
+	assert.Nil(t, tst.root.mid)
+	assert.Equal(t, 0, tst.Size())
+}
+
+func TestTernarySearchTree_NearNeighbors(t *testing.T) {
+	tst := NewTernarySearchTree(stringComparator, stringToString)
+	for _, word := range []string{"cat", "cot", "cut", "cart", "bat", "bot", "dog", "ca"} {
+		tst.Insert(word)
+	}
+
+	assert.Equal(t, []string{"cat"}, tst.NearNeighbors("cat", 0))
+	assert.Equal(t, []string{"bat", "cat", "cot", "cut"}, tst.NearNeighbors("cat", 1))
+	assert.Equal(t, []string{"bot", "cat", "cot", "cut", "dog"}, tst.NearNeighbors("cog", 2))
+	assert.Empty(t, tst.NearNeighbors("cats", 1))
+	assert.Empty(t, tst.NearNeighbors("cat", -1))
+}
+
+func TestTernarySearchTree_SameAsTrie(t *testing.T) {
+	tst := NewTernarySearchTree(stringComparator, stringToString)
+	tr := NewTrie(stringComparator, stringToString)
+	words := make(map[string]bool)
+	rnd := rand.New(rand.NewSource(5))
+	randWord := func(alphabet string, maxLen int) string {
+		b := make([]rune, rnd.Intn(maxLen+1))
+		for i := range b {
+			b[i] = []rune(alphabet)[rnd.Intn(len([]rune(alphabet)))]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 3000; i++ {
+		word := randWord("abcé", 5)
+		switch rnd.Intn(3) {
+		case 0:
+			require.Equal(t, tr.Delete(word), tst.Delete(word))
+			delete(words, word)
+		default:
+			tr.Insert(word)
+			tst.Insert(word)
+			words[word] = true
+		}
+		require.Equal(t, len(words), tst.Size())
+
+		if i%50 != 0 {
+			continue
+		}
+		checkTST(t, tst)
+		prefix := randWord("abcé", 2)
+		limit := rnd.Intn(4)
+		require.Equal(t, tr.KeysWithPrefix(prefix, limit), tst.KeysWithPrefix(prefix, limit))
+		require.Equal(t, tr.CountByPrefix(prefix), tst.CountByPrefix(prefix))
+		require.Equal(t, tr.Search(prefix), tst.Search(prefix))
+		require.Equal(t, tr.StartsWith(prefix), tst.StartsWith(prefix), "prefix %q", prefix)
+
+		query := randWord("abcé", 5)
+		distance := rnd.Intn(3)
+		var want []string
+		for _, word := range tr.KeysWithPrefix("", 0) {
+			if hammingDistance(word, query) <= distance {
+				want = append(want, word)
+			}
+		}
+		require.Equal(t, want, tst.NearNeighbors(query, distance), "query %q, distance %d", query, distance)
+	}
+}
+
+// hammingDistance returns number of different chars or MaxInt if lengths are different
+func hammingDistance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	if len(x) != len(y) {
+		return math.MaxInt
+	}
+	d := 0
+	for i := range x {
+		if x[i] != y[i] {
+			d++
+		}
+	}
+	return d
+}
+
+// checkTST checks order of BST, sizes of subtrees
+// and that every node has item or next chars
+func checkTST[T comparable](t *testing.T, tst *ternarySearchTree[T]) {
+	t.Helper()
+	var check func(node *tstNode[T], lo, hi rune) int
+	check = func(node *tstNode[T], lo, hi rune) int {
+		if node == nil {
+			return 0
+		}
+		require.True(t, lo < node.ch && node.ch < hi, "BST order is broken")
+		require.True(t, node.isEnd || node.mid != nil, "empty node is not pruned")
+
+		size := check(node.left, lo, node.ch) + check(node.mid, -1, math.MaxInt32) + check(node.right, node.ch, hi)
+		if node.isEnd {
+			size++
+		}
+		require.Equal(t, size, node.size)
+		return size
+	}
+
+	size := check(tst.root.mid, -1, math.MaxInt32)
+	if tst.root.isEnd {
+		size++
+	}
+	require.Equal(t, size, tst.root.size)
+}
+
+func BenchmarkTernarySearchTree_Memory(b *testing.B) {
+	benchmarkMemory(b, func(keys []string) any {
+		tst := NewTernarySearchTree(stringComparator, stringToString)
+		for _, key := range keys {
+			tst.Insert(key)
+		}
+		return tst
+	})
+}
+
+func BenchmarkTernarySearchTree_Search(b *testing.B) {
+	tst := NewTernarySearchTree(stringComparator, stringToString)
+	keys := memoryKeys()
+	for _, key := range keys {
+		tst.Insert(key)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tst.Search(keys[i%len(keys)])
+	}
+}
@@ -0,0 +1,183 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// treap - Treap (tree + heap).
+//
+// Every node has random priority. Tree is BST by values and
+// Max-Heap by priorities, so its shape is the same as of BST
+// with items inserted in random order: expected depth is O(log n).
+//
+// All operations are built on 2 primitives:
+//
+// # split 	-> divides treap into items < key and items >= key
+//
+// # merge 	-> joins 2 treaps when all items of first <= all items of second
+type treap[T comparable] struct {
+	root *treap_node[T]
+
+	mu sync.Mutex
+
+	compare Comparator[T]
+	rnd     *rand.Rand
+}
+
+// treap_node is the node of Treap
+//
+// # size 	-> number of nodes in subtree
+type treap_node[T comparable] struct {
+	val      T
+	priority uint64
+	size     int
+
+	left  *treap_node[T]
+	right *treap_node[T]
+}
+
+// NewTreap creates Treap with random priorities seeded by time
+func NewTreap[T comparable](compare Comparator[T]) *treap[T] {
+	return NewTreapWithSource(compare, rand.NewSource(time.Now().UnixNano()))
+}
+
+// NewTreapWithSource creates Treap with priorities from `src`.
+// Pass rand.NewSource(seed) to get the same shape of tree in tests
+func NewTreapWithSource[T comparable](compare Comparator[T], src rand.Source) *treap[T] {
+	return &treap[T]{compare: compare, rnd: rand.New(src)}
+}
+
+func (t *treap[T]) Insert(item T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := &treap_node[T]{val: item, priority: t.rnd.Uint64(), size: 1}
+	l, r := t.split(t.root, item)
+	t.root = t.merge(t.merge(l, n), r)
+}
+
+func (t *treap[T]) Delete(item T) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var found bool
+	t.root, found = t.deleteHelper(t.root, item)
+	if !found {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return nil
+}
+
+func (t *treap[T]) Search(item T) (*T, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	curr := t.root
+	for curr != nil {
+		c := t.compare(item, curr.val)
+		if c == 0 {
+			return &curr.val, nil
+		} else if c < 0 {
+			curr = curr.left
+		} else {
+			curr = curr.right
+		}
+	}
+	return nil, fmt.Errorf(gocollections.ErrNotFound)
+}
+
+// Split moves all items >= key to new Treap and returns it.
+// Items < key stay in t. Expected O(log n)
+func (t *treap[T]) Split(key T) *treap[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	other := &treap[T]{compare: t.compare, rnd: rand.New(rand.NewSource(t.rnd.Int63()))}
+	t.root, other.root = t.split(t.root, key)
+	return other
+}
+
+// Merge moves all items of `other` to t, `other` becomes empty.
+//
+// If all items of one treap are <= all items of another (after Split for example),
+// Merge is expected O(log n), otherwise it is union in expected O(m log(n/m)),
+// where m is size of the smaller treap
+func (t *treap[T]) Merge(other *treap[T]) {
+	if other == t {
+		return
+	}
+
+	// items are moved out of `other` before t is locked: both locks are never held
+	// at once, so a.Merge(b) and b.Merge(a) at the same time don't deadlock
+	other.mu.Lock()
+	b := other.root
+	other.root = nil
+	other.mu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a := t.root
+	switch {
+	case a == nil || b == nil:
+		t.root = t.merge(a, b)
+	case t.compare(t.max(a), t.min(b)) <= 0:
+		t.root = t.merge(a, b)
+	case t.compare(t.max(b), t.min(a)) <= 0:
+		t.root = t.merge(b, a)
+	default:
+		t.root = t.union(a, b)
+	}
+}
+
+func (t *treap[T]) InOrder() []T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	items := make([]T, 0, t.root.getSize())
+	t.inOrderHelper(t.root, &items)
+	return items
+}
+
+func (t *treap[T]) PreOrder() []T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	items := make([]T, 0, t.root.getSize())
+	t.preOrderHelper(t.root, &items)
+	return items
+}
+
+func (t *treap[T]) PostOrder() []T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	items := make([]T, 0, t.root.getSize())
+	t.postOrderHelper(t.root, &items)
+	return items
+}
+
+func (t *treap[T]) LevelOrder() []T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.levelOrderHelper()
+}
+
+// Size returns number of items
+func (t *treap[T]) Size() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.root.getSize()
+}
+
+// IsEmpty returns true if there are no items
+func (t *treap[T]) IsEmpty() bool {
+	return t.Size() == 0
+}
@@ -0,0 +1,155 @@
+package trees
+
+import "github.com/0x0FACED/go-collections/queue"
+
+// getSize returns size of subtree, 0 for nil
+func (n *treap_node[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treap_node[T]) update() {
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+// split divides subtree `curr` into items < key and items >= key
+func (t *treap[T]) split(curr *treap_node[T], key T) (*treap_node[T], *treap_node[T]) {
+	if curr == nil {
+		return nil, nil
+	}
+	if t.compare(curr.val, key) < 0 {
+		// curr and its left subtree are < key
+		l, r := t.split(curr.right, key)
+		curr.right = l
+		curr.update()
+		return curr, r
+	}
+	l, r := t.split(curr.left, key)
+	curr.left = r
+	curr.update()
+	return l, curr
+}
+
+// merge joins `a` and `b`, all items of `a` must be <= all items of `b`.
+// Root with bigger priority becomes root
+func (t *treap[T]) merge(a, b *treap_node[T]) *treap_node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = t.merge(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = t.merge(a, b.left)
+	b.update()
+	return b
+}
+
+// union joins any 2 treaps: root with bigger priority stays root,
+// other treap is split by its value and parts are joined with its children
+func (t *treap[T]) union(a, b *treap_node[T]) *treap_node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority < b.priority {
+		a, b = b, a
+	}
+	l, r := t.split(b, a.val)
+	a.left = t.union(a.left, l)
+	a.right = t.union(a.right, r)
+	a.update()
+	return a
+}
+
+// deleteHelper deletes one node with `item` and returns new subtree
+func (t *treap[T]) deleteHelper(curr *treap_node[T], item T) (*treap_node[T], bool) {
+	if curr == nil {
+		return nil, false
+	}
+	var found bool
+	c := t.compare(item, curr.val)
+	if c == 0 {
+		return t.merge(curr.left, curr.right), true
+	} else if c < 0 {
+		curr.left, found = t.deleteHelper(curr.left, item)
+	} else {
+		curr.right, found = t.deleteHelper(curr.right, item)
+	}
+	if found {
+		curr.update()
+	}
+	return curr, found
+}
+
+func (t *treap[T]) min(curr *treap_node[T]) T {
+	for curr.left != nil {
+		curr = curr.left
+	}
+	return curr.val
+}
+
+func (t *treap[T]) max(curr *treap_node[T]) T {
+	for curr.right != nil {
+		curr = curr.right
+	}
+	return curr.val
+}
+
+func (t *treap[T]) inOrderHelper(curr *treap_node[T], items *[]T) {
+	if curr == nil {
+		return
+	}
+	t.inOrderHelper(curr.left, items)
+	*items = append(*items, curr.val)
+	t.inOrderHelper(curr.right, items)
+}
+
+func (t *treap[T]) preOrderHelper(curr *treap_node[T], items *[]T) {
+	if curr == nil {
+		return
+	}
+	*items = append(*items, curr.val)
+	t.preOrderHelper(curr.left, items)
+	t.preOrderHelper(curr.right, items)
+}
+
+func (t *treap[T]) postOrderHelper(curr *treap_node[T], items *[]T) {
+	if curr == nil {
+		return
+	}
+	t.postOrderHelper(curr.left, items)
+	t.postOrderHelper(curr.right, items)
+	*items = append(*items, curr.val)
+}
+
+func (t *treap[T]) levelOrderHelper() []T {
+	items := make([]T, 0, t.root.getSize())
+	if t.root == nil {
+		return items
+	}
+	q := queue.NewDynamicListQueue[*treap_node[T]]()
+	q.Enqueue(t.root)
+	for !q.IsEmpty() {
+		curr, err := q.Dequeue()
+		if err != nil {
+			return nil
+		}
+		items = append(items, (*curr).val)
+		if (*curr).left != nil {
+			q.Enqueue((*curr).left)
+		}
+		if (*curr).right != nil {
+			q.Enqueue((*curr).right)
+		}
+	}
+	return items
+}
@@ -0,0 +1,201 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreap_Basic(t *testing.T) {
+	var tr TraversalTree[int] = NewTreapWithSource(intComparator, rand.NewSource(1))
+
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80} {
+		tr.Insert(v)
+	}
+	assert.Equal(t, []int{20, 30, 40, 50, 60, 70, 80}, tr.InOrder())
+	assert.Len(t, tr.PreOrder(), 7)
+	assert.Len(t, tr.PostOrder(), 7)
+	assert.Len(t, tr.LevelOrder(), 7)
+
+	val, err := tr.Search(40)
+	require.NoError(t, err)
+	assert.Equal(t, 40, *val)
+
+	require.NoError(t, tr.Delete(40))
+	_, err = tr.Search(40)
+	assert.Equal(t, errors.New(gocollections.ErrNotFound), err)
+	assert.Error(t, tr.Delete(40))
+	assert.Equal(t, []int{20, 30, 50, 60, 70, 80}, tr.InOrder())
+}
+
+func TestTreap_Empty(t *testing.T) {
+	tr := NewTreap(intComparator)
+	assert.True(t, tr.IsEmpty())
+	assert.Empty(t, tr.InOrder())
+	assert.Empty(t, tr.LevelOrder())
+	_, err := tr.Search(1)
+	assert.Error(t, err)
+}
+
+func TestTreap_SplitMerge(t *testing.T) {
+	tr := NewTreapWithSource(intComparator, rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		tr.Insert(i)
+	}
+
+	right := tr.Split(60)
+	assert.Equal(t, 60, tr.Size())
+	assert.Equal(t, 40, right.Size())
+	assert.Equal(t, 59, tr.InOrder()[59])
+	assert.Equal(t, 60, right.InOrder()[0])
+	checkTreap(t, tr)
+	checkTreap(t, right)
+
+	// split by missing key and by key out of range
+	empty := right.Split(1000)
+	assert.True(t, empty.IsEmpty())
+	all := tr.Split(-1)
+	assert.True(t, tr.IsEmpty())
+	assert.Equal(t, 60, all.Size())
+
+	// merge in any order
+	right.Merge(all)
+	assert.True(t, all.IsEmpty())
+	assert.Equal(t, 100, right.Size())
+	expected := make([]int, 100)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.Equal(t, expected, right.InOrder())
+	checkTreap(t, right)
+
+	right.Merge(right)
+	assert.Equal(t, 100, right.Size())
+}
+
+func TestTreap_MergeOverlapping(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	a := NewTreapWithSource(intComparator, rand.NewSource(4))
+	b := NewTreapWithSource(intComparator, rand.NewSource(5))
+
+	var expected []int
+	for i := 0; i < 500; i++ {
+		v := rnd.Intn(300)
+		if i%2 == 0 {
+			a.Insert(v)
+		} else {
+			b.Insert(v)
+		}
+		expected = append(expected, v)
+	}
+	a.Merge(b)
+	slices.Sort(expected)
+	assert.Equal(t, expected, a.InOrder())
+	assert.True(t, b.IsEmpty())
+	checkTreap(t, a)
+}
+
+func TestTreap_MergeConcurrent(t *testing.T) {
+	a := NewTreapWithSource(intComparator, rand.NewSource(1))
+	b := NewTreapWithSource(intComparator, rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		a.Insert(i)
+		b.Insert(i + 100)
+	}
+
+	// a.Merge(b) and b.Merge(a) at the same time must not deadlock
+	var wg sync.WaitGroup
+	for _, pair := range [][2]*treap[int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func(dst, src *treap[int]) {
+			defer wg.Done()
+			for i := 0; i < 20000; i++ {
+				dst.Merge(src)
+			}
+		}(pair[0], pair[1])
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("concurrent Merge deadlocked")
+	}
+
+	// items are moved, not lost or copied
+	items := append(a.InOrder(), b.InOrder()...)
+	slices.Sort(items)
+	expected := make([]int, 200)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.Equal(t, expected, items)
+	checkTreap(t, a)
+	checkTreap(t, b)
+}
+
+func TestTreap_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(6))
+	tr := NewTreapWithSource(intComparator, rand.NewSource(7))
+	var expected []int
+
+	for op := 0; op < 2000; op++ {
+		v := rnd.Intn(200)
+		if rnd.Intn(3) > 0 {
+			tr.Insert(v)
+			i, _ := slices.BinarySearch(expected, v)
+			expected = slices.Insert(expected, i, v)
+		} else if i, found := slices.BinarySearch(expected, v); found {
+			require.NoError(t, tr.Delete(v))
+			expected = slices.Delete(expected, i, i+1)
+		} else {
+			require.Error(t, tr.Delete(v))
+		}
+	}
+	require.Equal(t, expected, tr.InOrder())
+	checkTreap(t, tr)
+}
+
+// checkTreap checks heap order of priorities and sizes of subtrees
+func checkTreap(t *testing.T, tr *treap[int]) {
+	t.Helper()
+
+	var check func(n *treap_node[int]) int
+	check = func(n *treap_node[int]) int {
+		if n == nil {
+			return 0
+		}
+		if n.left != nil {
+			require.GreaterOrEqual(t, n.priority, n.left.priority)
+		}
+		if n.right != nil {
+			require.GreaterOrEqual(t, n.priority, n.right.priority)
+		}
+		size := 1 + check(n.left) + check(n.right)
+		require.Equal(t, size, n.size)
+		return size
+	}
+	check(tr.root)
+	require.True(t, slices.IsSorted(tr.InOrder()))
+}
+
+func BenchmarkTreap_Insert(b *testing.B) {
+	tr := NewTreap(intComparator)
+	rnd := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.Insert(rnd.Int())
+	}
+}
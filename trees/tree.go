@@ -25,6 +25,17 @@ type Comparator[T comparable] func(a, b T) int
 
 // ==========================================================================================
 
+// Number is any integer or float type.
+//
+// Used by trees which sum their values: Segment Tree, Fenwick Tree
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ==========================================================================================
+
 // rbt_node is the node of Red-Black Tree.
 //
 // # val
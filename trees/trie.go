@@ -1,5 +1,11 @@
 package trees
 
+import (
+	"fmt"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
 type Trie[T any] interface {
 	// Insert inserts the item to Trie with custom comparator
 	Insert(item T)
@@ -15,11 +21,87 @@ type Trie[T any] interface {
 	CountByPrefix(prefix T) int
 }
 
+// PrefixTrie is Trie with deleting and listing of items by prefix
+type PrefixTrie[T any] interface {
+	Trie[T]
+
+	// Delete deletes the item and removes nodes which are not needed anymore
+	//
+	// if there is no item in Trie -> returns err
+	Delete(item T) error
+
+	// KeysWithPrefix returns at most `limit` items which start with prefix
+	// in lexicographic order. If limit <= 0 -> returns all such items.
+	//
+	// Only the needed part of Trie is visited, so small limit is cheap
+	// even if there are many items with prefix (e.g. autocomplete)
+	KeysWithPrefix(prefix T, limit int) []T
+
+	// WalkPrefix calls `fn` for items which start with prefix
+	// in lexicographic order until `fn` returns false
+	WalkPrefix(prefix T, fn func(item T) bool)
+}
+
+// ScoredTrie is Trie with scores of items for ranked autocomplete
+type ScoredTrie[T any] interface {
+	Trie[T]
+
+	// IncrementScore adds `delta` to score of the item (frequency, recency, etc).
+	// New item has score 0
+	//
+	// if there is no item in Trie -> returns err
+	IncrementScore(item T, delta float64) error
+
+	// Score returns score of the item
+	//
+	// if there is no item in Trie -> returns err
+	Score(item T) (float64, error)
+
+	// TopK returns at most `k` items with the highest score which start with prefix.
+	// Items with equal score are in lexicographic order.
+	//
+	// Subtrees which can't have better items are not visited
+	TopK(prefix T, k int) []T
+}
+
+// FuzzyTrie is Trie with approximate search of items
+type FuzzyTrie[T any] interface {
+	Trie[T]
+
+	// FuzzySearch returns items which Levenshtein distance to query is at most maxDistance
+	// (insert, delete or replace of one char costs 1), sorted by distance,
+	// then in lexicographic order
+	FuzzySearch(query T, maxDistance int) []FuzzyMatch[T]
+
+	// DamerauFuzzySearch is FuzzySearch which also counts swap of two adjacent chars
+	// as one edit (optimal string alignment distance): "form" -> "from" is 1
+	DamerauFuzzySearch(query T, maxDistance int) []FuzzyMatch[T]
+
+	// Match returns items which match `pattern` in lexicographic order:
+	// '?' matches any one char, '*' matches any sequence of chars (also empty).
+	// Pattern is a string, not T, because it has wildcards
+	Match(pattern string) []T
+}
+
+// FuzzyMatch is the item found by FuzzySearch and its edit distance to query
+type FuzzyMatch[T any] struct {
+	Item     T
+	Distance int
+}
+
+// trieNode - node of Trie, `val` is the item which ends in this node (if isEnd)
+//
+// # score 	-> score of `val`
+//
+// # maxScore 	-> max score of items in subtree, used by TopK
 type trieNode[T any] struct {
 	val T
 
 	children map[rune]*trieNode[T]
 	isEnd    bool
+
+	score    float64
+	maxScore float64
 }
 
 type trie[T comparable] struct {
@@ -46,8 +128,13 @@ func (t *trie[T]) Insert(item T) {
 		}
 		dummy = dummy.children[ch]
 	}
+	isNew := !dummy.isEnd
 	dummy.isEnd = true
 	dummy.val = item
+	if isNew {
+		dummy.score = 0
+		updateMaxScores(t.path(itemStr))
+	}
 }
 
 // Search finds if the element exists in the Trie.
@@ -105,3 +192,113 @@ func countEndNodes[T any](node *trieNode[T]) int {
 	}
 	return count
 }
+
+// Delete deletes the item and removes nodes which are not needed anymore
+func (t *trie[T]) Delete(item T) error {
+	itemStr := t.toString(item)
+
+	// path[i] is parent of path[i+1], runes[i] is the char of path[i+1]
+	path := []*trieNode[T]{t.root}
+	var runes []rune
+	dummy := t.root
+	for _, ch := range itemStr {
+		next, exists := dummy.children[ch]
+		if !exists {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+		dummy = next
+		path = append(path, dummy)
+		runes = append(runes, ch)
+	}
+	if !dummy.isEnd {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	var zero T
+	dummy.val = zero
+	dummy.isEnd = false
+	dummy.score = 0
+
+	// go up while node is not the end of other item and has no children
+	last := len(path) - 1
+	for ; last > 0; last-- {
+		if path[last].isEnd || len(path[last].children) > 0 {
+			break
+		}
+		delete(path[last-1].children, runes[last-1])
+	}
+	updateMaxScores(path[:last+1])
+	return nil
+}
+
+// KeysWithPrefix returns at most `limit` items which start with prefix
+// in lexicographic order. If limit <= 0 -> returns all such items
+func (t *trie[T]) KeysWithPrefix(prefix T, limit int) []T {
+	var items []T
+	t.WalkPrefix(prefix, func(item T) bool {
+		items = append(items, item)
+		return limit <= 0 || len(items) < limit
+	})
+	return items
+}
+
+// WalkPrefix calls `fn` for items which start with prefix
+// in lexicographic order until `fn` returns false
+func (t *trie[T]) WalkPrefix(prefix T, fn func(item T) bool) {
+	node := t.findNode(t.toString(prefix))
+	if node == nil {
+		return
+	}
+	walkTrie(node, fn)
+}
+
+// IncrementScore adds `delta` to score of the item
+func (t *trie[T]) IncrementScore(item T, delta float64) error {
+	path := t.path(t.toString(item))
+	if path == nil || !path[len(path)-1].isEnd {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	path[len(path)-1].score += delta
+	updateMaxScores(path)
+	return nil
+}
+
+// Score returns score of the item
+func (t *trie[T]) Score(item T) (float64, error) {
+	node := t.findNode(t.toString(item))
+	if node == nil || !node.isEnd {
+		return 0, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return node.score, nil
+}
+
+// TopK returns at most `k` items with the highest score which start with prefix
+func (t *trie[T]) TopK(prefix T, k int) []T {
+	prefixStr := t.toString(prefix)
+	node := t.findNode(prefixStr)
+	if node == nil || k <= 0 {
+		return nil
+	}
+	return topKHelper(node, prefixStr, k)
+}
+
+// FuzzySearch returns items which Levenshtein distance to query is at most maxDistance
+func (t *trie[T]) FuzzySearch(query T, maxDistance int) []FuzzyMatch[T] {
+	return t.fuzzySearch(query, maxDistance, false)
+}
+
+// DamerauFuzzySearch is FuzzySearch which also counts swap of two adjacent chars as one edit
+func (t *trie[T]) DamerauFuzzySearch(query T, maxDistance int) []FuzzyMatch[T] {
+	return t.fuzzySearch(query, maxDistance, true)
+}
+
+// Match returns items which match `pattern` in lexicographic order
+func (t *trie[T]) Match(pattern string) []T {
+	var res []T
+	p := []rune(pattern)
+	states := make([]bool, len(p)+1)
+	states[0] = true
+	closeStates(p, states)
+	matchHelper(t.root, p, states, &res)
+	return res
+}
@@ -0,0 +1,237 @@
+package trees
+
+import (
+	"math"
+	"slices"
+
+	"github.com/0x0FACED/go-collections/heaps"
+)
+
+// findNode returns node of `str` or nil
+func (t *trie[T]) findNode(str string) *trieNode[T] {
+	dummy := t.root
+	for _, ch := range str {
+		next, exists := dummy.children[ch]
+		if !exists {
+			return nil
+		}
+		dummy = next
+	}
+	return dummy
+}
+
+// walkTrie calls `fn` for items of subtree in lexicographic order:
+// item of node goes before items of its children, children are visited in order of chars.
+// Returns false if `fn` returned false
+func walkTrie[T any](node *trieNode[T], fn func(item T) bool) bool {
+	if node.isEnd && !fn(node.val) {
+		return false
+	}
+	for _, ch := range sortedChars(node.children) {
+		if !walkTrie(node.children[ch], fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedChars returns chars of children in ascending order
+func sortedChars[T any](children map[rune]*trieNode[T]) []rune {
+	chars := make([]rune, 0, len(children))
+	for ch := range children {
+		chars = append(chars, ch)
+	}
+	slices.Sort(chars)
+	return chars
+}
+
+// path returns nodes from root to node of `str` or nil if there is no such node
+func (t *trie[T]) path(str string) []*trieNode[T] {
+	path := []*trieNode[T]{t.root}
+	dummy := t.root
+	for _, ch := range str {
+		next, exists := dummy.children[ch]
+		if !exists {
+			return nil
+		}
+		dummy = next
+		path = append(path, dummy)
+	}
+	return path
+}
+
+// updateMaxScores recalculates maxScore from the last node of path to root
+func updateMaxScores[T any](path []*trieNode[T]) {
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i]
+		node.maxScore = math.Inf(-1)
+		if node.isEnd {
+			node.maxScore = node.score
+		}
+		for _, child := range node.children {
+			node.maxScore = max(node.maxScore, child.maxScore)
+		}
+	}
+}
+
+// topKCandidate is node (its best item has `score`) or item of node in TopK queue
+type topKCandidate[T any] struct {
+	node   *trieNode[T]
+	key    string
+	score  float64
+	isItem bool
+}
+
+// bestFirst makes Max-Heap by score, then Min-Heap by key.
+// Key of node is not greater than keys of items in its subtree,
+// so node with equal score is taken before its items
+func bestFirst[T any](a, b topKCandidate[T]) int {
+	if a.score != b.score {
+		if a.score > b.score {
+			return 1
+		}
+		return -1
+	}
+	if a.key != b.key {
+		if a.key < b.key {
+			return 1
+		}
+		return -1
+	}
+	// item goes before its own node
+	if a.isItem != b.isItem {
+		if a.isItem {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// topKHelper is best-first search: nodes are taken from queue by max score in subtree.
+// When item is taken, all the rest items are not better
+func topKHelper[T any](root *trieNode[T], key string, k int) []T {
+	var res []T
+	pq := heaps.NewHeap(bestFirst[T])
+	pq.Insert(topKCandidate[T]{node: root, key: key, score: root.maxScore})
+
+	for len(res) < k && !pq.IsEmpty() {
+		c, _ := pq.Extract()
+		if c.isItem {
+			res = append(res, c.node.val)
+			continue
+		}
+		if c.node.isEnd {
+			pq.Insert(topKCandidate[T]{node: c.node, key: c.key, score: c.node.score, isItem: true})
+		}
+		for ch, child := range c.node.children {
+			pq.Insert(topKCandidate[T]{node: child, key: c.key + string(ch), score: child.maxScore})
+		}
+	}
+	return res
+}
+
+// fuzzySearch walks Trie in lexicographic order with one row of edit distance DP per node:
+// row[j] is distance between key of node and the first j chars of query.
+// Subtree is skipped when all values of row > maxDistance: they never decrease deeper
+func (t *trie[T]) fuzzySearch(query T, maxDistance int, transpositions bool) []FuzzyMatch[T] {
+	var res []FuzzyMatch[T]
+	if maxDistance < 0 {
+		return res
+	}
+	q := []rune(t.toString(query))
+	row := make([]int, len(q)+1)
+	for j := range row {
+		row[j] = j
+	}
+	search := &fuzzySearcher[T]{query: q, maxDistance: maxDistance, transpositions: transpositions, res: &res}
+	if t.root.isEnd && row[len(q)] <= maxDistance {
+		res = append(res, FuzzyMatch[T]{Item: t.root.val, Distance: row[len(q)]})
+	}
+	for _, ch := range sortedChars(t.root.children) {
+		search.visit(t.root.children[ch], ch, 0, row, nil)
+	}
+
+	// stable: items with equal distance stay in lexicographic order
+	slices.SortStableFunc(res, func(a, b FuzzyMatch[T]) int {
+		return a.Distance - b.Distance
+	})
+	return res
+}
+
+// fuzzySearcher keeps params of fuzzySearch for recursive visit
+type fuzzySearcher[T any] struct {
+	query          []rune
+	maxDistance    int
+	transpositions bool
+	res            *[]FuzzyMatch[T]
+}
+
+// visit calculates row of `node` (its char is `ch`) from row of parent `prev`,
+// `prevPrev` and `prevCh` are row and char of grandparent for transpositions
+func (s *fuzzySearcher[T]) visit(node *trieNode[T], ch, prevCh rune, prev, prevPrev []int) {
+	q := s.query
+	row := make([]int, len(q)+1)
+	row[0] = prev[0] + 1
+	best := row[0]
+	for j := 1; j <= len(q); j++ {
+		cost := 1
+		if q[j-1] == ch {
+			cost = 0
+		}
+		row[j] = min(prev[j]+1, row[j-1]+1, prev[j-1]+cost)
+		if s.transpositions && prevPrev != nil && j > 1 && q[j-1] == prevCh && q[j-2] == ch {
+			row[j] = min(row[j], prevPrev[j-2]+1)
+		}
+		best = min(best, row[j])
+	}
+
+	if node.isEnd && row[len(q)] <= s.maxDistance {
+		*s.res = append(*s.res, FuzzyMatch[T]{Item: node.val, Distance: row[len(q)]})
+	}
+	if best > s.maxDistance {
+		return
+	}
+	for _, next := range sortedChars(node.children) {
+		s.visit(node.children[next], next, ch, row, prev)
+	}
+}
+
+// closeStates adds states reachable without chars: '*' may match empty sequence
+func closeStates(pattern []rune, states []bool) {
+	for i, p := range pattern {
+		if states[i] && p == '*' {
+			states[i+1] = true
+		}
+	}
+}
+
+// matchHelper walks Trie in lexicographic order with set of pattern positions
+// which can be reached by key of `node` (like NFA). states[len(pattern)] means full match
+func matchHelper[T any](node *trieNode[T], pattern []rune, states []bool, res *[]T) {
+	if node.isEnd && states[len(pattern)] {
+		*res = append(*res, node.val)
+	}
+	for _, ch := range sortedChars(node.children) {
+		next := make([]bool, len(states))
+		alive := false
+		for i, p := range pattern {
+			if !states[i] {
+				continue
+			}
+			switch {
+			case p == '*':
+				next[i] = true
+			case p == '?' || p == ch:
+				next[i+1] = true
+			}
+		}
+		closeStates(pattern, next)
+		for _, ok := range next {
+			alive = alive || ok
+		}
+		if alive {
+			matchHelper(node.children[ch], pattern, next, res)
+		}
+	}
+}
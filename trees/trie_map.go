@@ -0,0 +1,211 @@
+package trees
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// TrieMap is the interface of Trie which maps sequences of K to values.
+//
+// Unlike Trie, key is not converted to string, so it can be []byte,
+// path segments ([]string), bits of IP, etc.
+//
+//	tm := trees.NewTrieMap[string, string]()
+//	tm.Put([]string{"api", "users"}, "users handler")
+//	tm.Put([]string{"api"}, "api handler")
+//
+//	key, val, err := tm.LongestPrefixOf([]string{"api", "users", "42"}) // [api users], users handler
+type TrieMap[K comparable, V any] interface {
+	// Put adds `key` with `val`. If key already exists -> replaces its val
+	Put(key []K, val V)
+
+	// Get returns val by `key`
+	//
+	// if there is no key -> val = zero value, err != nil
+	Get(key []K) (V, error)
+
+	// Delete deletes `key` and removes nodes which are not needed anymore
+	//
+	// if there is no key -> returns err
+	Delete(key []K) error
+
+	// LongestPrefixOf returns the longest key which is a prefix of `key` and its val
+	//
+	// if there is no such key -> err != nil
+	LongestPrefixOf(key []K) ([]K, V, error)
+
+	// WalkPrefix calls `fn` for keys which start with `prefix` until `fn` returns false.
+	// Shorter key goes before keys which continue it. Children are visited in order
+	// of elements for sorted storage and in random order for map storage.
+	// `fn` gets a copy of key
+	WalkPrefix(prefix []K, fn func(key []K, val V) bool)
+
+	Size() int
+	IsEmpty() bool
+}
+
+// trieMapNode - node of TrieMap. Only one of `children` and `sorted` is used,
+// it depends on storage of TrieMap
+//
+// # children 	-> map storage: element -> child
+//
+// # sorted 	-> sorted storage: children sorted by element
+type trieMapNode[K comparable, V any] struct {
+	children map[K]*trieMapNode[K, V]
+	sorted   []trieMapEntry[K, V]
+
+	val   V
+	isEnd bool
+}
+
+// trieMapEntry - child of node in sorted storage
+type trieMapEntry[K comparable, V any] struct {
+	key  K
+	node *trieMapNode[K, V]
+}
+
+// trieMap - Trie with keys of type []K.
+//
+// Children of node are stored in map (fast for nodes with many children)
+// or in sorted slice (less memory, ordered walk; good when nodes have few children).
+//
+// # compare 	-> nil for map storage, order of elements for sorted storage
+type trieMap[K comparable, V any] struct {
+	root *trieMapNode[K, V]
+	size int
+
+	mu sync.Mutex
+
+	compare Comparator[K]
+}
+
+// NewTrieMap creates TrieMap which stores children of node in map
+func NewTrieMap[K comparable, V any]() *trieMap[K, V] {
+	return &trieMap[K, V]{root: &trieMapNode[K, V]{}}
+}
+
+// NewSortedTrieMap creates TrieMap which stores children of node in slice sorted by `compare`.
+// It takes less memory than map and WalkPrefix returns keys in ascending order.
+//
+// If compare == nil -> children are stored in map like in NewTrieMap,
+// so WalkPrefix returns keys in no particular order
+func NewSortedTrieMap[K comparable, V any](compare Comparator[K]) *trieMap[K, V] {
+	return &trieMap[K, V]{root: &trieMapNode[K, V]{}, compare: compare}
+}
+
+// Put adds `key` with `val`. If key already exists -> replaces its val
+func (tm *trieMap[K, V]) Put(key []K, val V) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	node := tm.root
+	for _, k := range key {
+		next := tm.child(node, k)
+		if next == nil {
+			next = &trieMapNode[K, V]{}
+			tm.setChild(node, k, next)
+		}
+		node = next
+	}
+	if !node.isEnd {
+		tm.size++
+	}
+	node.val = val
+	node.isEnd = true
+}
+
+// Get returns val by `key`
+func (tm *trieMap[K, V]) Get(key []K) (V, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	node := tm.find(key)
+	if node == nil || !node.isEnd {
+		var zero V
+		return zero, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return node.val, nil
+}
+
+// Delete deletes `key` and removes nodes which are not needed anymore
+func (tm *trieMap[K, V]) Delete(key []K) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	// path[i] is parent of path[i+1], key[i] is the element of path[i+1]
+	path := []*trieMapNode[K, V]{tm.root}
+	node := tm.root
+	for _, k := range key {
+		if node = tm.child(node, k); node == nil {
+			return fmt.Errorf(gocollections.ErrNotFound)
+		}
+		path = append(path, node)
+	}
+	if !node.isEnd {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+
+	var zero V
+	node.val = zero
+	node.isEnd = false
+	tm.size--
+
+	// go up while node is not the end of other key and has no children
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i].isEnd || tm.numChildren(path[i]) > 0 {
+			break
+		}
+		tm.removeChild(path[i-1], key[i-1])
+	}
+	return nil
+}
+
+// LongestPrefixOf returns the longest key which is a prefix of `key` and its val
+func (tm *trieMap[K, V]) LongestPrefixOf(key []K) ([]K, V, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	best := -1
+	var val V
+	node := tm.root
+	for i := 0; node != nil; i++ {
+		if node.isEnd {
+			best, val = i, node.val
+		}
+		if i == len(key) {
+			break
+		}
+		node = tm.child(node, key[i])
+	}
+
+	if best == -1 {
+		return nil, val, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return slices.Clone(key[:best]), val, nil
+}
+
+// WalkPrefix calls `fn` for keys which start with `prefix` until `fn` returns false
+func (tm *trieMap[K, V]) WalkPrefix(prefix []K, fn func(key []K, val V) bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	node := tm.find(prefix)
+	if node == nil {
+		return
+	}
+	tm.walk(node, slices.Clone(prefix), fn)
+}
+
+func (tm *trieMap[K, V]) Size() int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	return tm.size
+}
+
+func (tm *trieMap[K, V]) IsEmpty() bool {
+	return tm.Size() == 0
+}
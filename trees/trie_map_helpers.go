@@ -0,0 +1,100 @@
+package trees
+
+import (
+	"slices"
+	"sort"
+)
+
+// child returns child of `node` by element `k` or nil
+func (tm *trieMap[K, V]) child(node *trieMapNode[K, V], k K) *trieMapNode[K, V] {
+	if tm.compare == nil {
+		return node.children[k]
+	}
+	if i, found := tm.childIndex(node, k); found {
+		return node.sorted[i].node
+	}
+	return nil
+}
+
+// setChild adds `child` of `node` by element `k`
+func (tm *trieMap[K, V]) setChild(node *trieMapNode[K, V], k K, child *trieMapNode[K, V]) {
+	if tm.compare == nil {
+		if node.children == nil {
+			node.children = make(map[K]*trieMapNode[K, V])
+		}
+		node.children[k] = child
+		return
+	}
+	i, found := tm.childIndex(node, k)
+	if found {
+		node.sorted[i].node = child
+		return
+	}
+	node.sorted = slices.Insert(node.sorted, i, trieMapEntry[K, V]{key: k, node: child})
+}
+
+// removeChild removes child of `node` by element `k`
+func (tm *trieMap[K, V]) removeChild(node *trieMapNode[K, V], k K) {
+	if tm.compare == nil {
+		delete(node.children, k)
+		if len(node.children) == 0 {
+			node.children = nil
+		}
+		return
+	}
+	if i, found := tm.childIndex(node, k); found {
+		node.sorted = slices.Delete(node.sorted, i, i+1)
+		if len(node.sorted) == 0 {
+			node.sorted = nil
+		}
+	}
+}
+
+func (tm *trieMap[K, V]) numChildren(node *trieMapNode[K, V]) int {
+	if tm.compare == nil {
+		return len(node.children)
+	}
+	return len(node.sorted)
+}
+
+// childIndex returns index of child by element `k` in sorted storage
+// or index where it should be inserted
+func (tm *trieMap[K, V]) childIndex(node *trieMapNode[K, V], k K) (int, bool) {
+	i := sort.Search(len(node.sorted), func(i int) bool {
+		return tm.compare(node.sorted[i].key, k) >= 0
+	})
+	return i, i < len(node.sorted) && tm.compare(node.sorted[i].key, k) == 0
+}
+
+// find returns node of `key` or nil
+func (tm *trieMap[K, V]) find(key []K) *trieMapNode[K, V] {
+	node := tm.root
+	for _, k := range key {
+		if node = tm.child(node, k); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// walk calls `fn` for keys of subtree, `key` is the key of `node`.
+// Returns false if `fn` returned false
+func (tm *trieMap[K, V]) walk(node *trieMapNode[K, V], key []K, fn func(key []K, val V) bool) bool {
+	if node.isEnd && !fn(slices.Clone(key), node.val) {
+		return false
+	}
+	if tm.compare == nil {
+		for k, child := range node.children {
+			if !tm.walk(child, append(key, k), fn) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, e := range node.sorted {
+		if !tm.walk(e.node, append(key, e.key), fn) {
+			return false
+		}
+	}
+	return true
+}
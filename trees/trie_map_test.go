@@ -0,0 +1,216 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrieMap_PathSegments(t *testing.T) {
+	for name, tm := range map[string]*trieMap[string, string]{
+		"map":    NewTrieMap[string, string](),
+		"sorted": NewSortedTrieMap[string, string](strings.Compare),
+	} {
+		t.Run(name, func(t *testing.T) {
+			tm.Put([]string{"api", "users"}, "users")
+			tm.Put([]string{"api", "users", "admin"}, "admin")
+			tm.Put([]string{"api"}, "api")
+			tm.Put([]string{"static"}, "static")
+			tm.Put([]string{"api", "users"}, "users v2")
+			assert.Equal(t, 4, tm.Size())
+
+			val, err := tm.Get([]string{"api", "users"})
+			require.NoError(t, err)
+			assert.Equal(t, "users v2", val)
+			_, err = tm.Get([]string{"api", "orders"})
+			assert.EqualError(t, err, gocollections.ErrNotFound)
+
+			request := []string{"api", "users", "42", "posts"}
+			key, val, err := tm.LongestPrefixOf(request)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"api", "users"}, key)
+			assert.Equal(t, "users v2", val)
+			key[0] = "changed"
+			assert.Equal(t, "api", request[0])
+
+			_, _, err = tm.LongestPrefixOf([]string{"images"})
+			assert.EqualError(t, err, gocollections.ErrNotFound)
+
+			var keys [][]string
+			tm.WalkPrefix([]string{"api"}, func(key []string, _ string) bool {
+				keys = append(keys, key)
+				return true
+			})
+			assert.ElementsMatch(t, [][]string{{"api"}, {"api", "users"}, {"api", "users", "admin"}}, keys)
+
+			require.NoError(t, tm.Delete([]string{"api", "users"}))
+			assert.EqualError(t, tm.Delete([]string{"api", "users"}), gocollections.ErrNotFound)
+			_, val, _ = tm.LongestPrefixOf(request)
+			assert.Equal(t, "api", val)
+
+			require.NoError(t, tm.Delete([]string{"api", "users", "admin"}))
+			require.NoError(t, tm.Delete([]string{"api"}))
+			require.NoError(t, tm.Delete([]string{"static"}))
+			assert.True(t, tm.IsEmpty())
+			assert.Zero(t, tm.numChildren(tm.root))
+		})
+	}
+}
+
+func TestTrieMap_Sorted(t *testing.T) {
+	tm := NewSortedTrieMap[byte, int](func(a, b byte) int { return int(a) - int(b) })
+	for i, key := range []string{"b", "ab", "a", "abc", "ba", ""} {
+		tm.Put([]byte(key), i)
+	}
+
+	var keys []string
+	tm.WalkPrefix(nil, func(key []byte, _ int) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+	assert.Equal(t, []string{"", "a", "ab", "abc", "b", "ba"}, keys)
+
+	keys = nil
+	tm.WalkPrefix([]byte("a"), func(key []byte, _ int) bool {
+		keys = append(keys, string(key))
+		return len(keys) < 2
+	})
+	assert.Equal(t, []string{"a", "ab"}, keys)
+
+	key, val, err := tm.LongestPrefixOf([]byte("xyz"))
+	require.NoError(t, err)
+	assert.Empty(t, key)
+	assert.Equal(t, 5, val)
+}
+
+func TestTrieMap_Random(t *testing.T) {
+	for name, tm := range map[string]*trieMap[byte, int]{
+		"map":    NewTrieMap[byte, int](),
+		"sorted": NewSortedTrieMap[byte, int](func(a, b byte) int { return int(a) - int(b) }),
+	} {
+		t.Run(name, func(t *testing.T) {
+			expected := make(map[string]int)
+			rnd := rand.New(rand.NewSource(1))
+			randKey := func() []byte {
+				b := make([]byte, rnd.Intn(6))
+				for i := range b {
+					b[i] = byte(rnd.Intn(3))
+				}
+				return b
+			}
+
+			for i := 0; i < 3000; i++ {
+				key := randKey()
+				if rnd.Intn(3) == 0 {
+					_, ok := expected[string(key)]
+					err := tm.Delete(key)
+					require.Equal(t, ok, err == nil)
+					delete(expected, string(key))
+				} else {
+					tm.Put(key, i)
+					expected[string(key)] = i
+				}
+
+				if i%100 == 0 {
+					require.Equal(t, len(expected), tm.Size())
+
+					prefix := randKey()
+					var want, got []string
+					for key := range expected {
+						if strings.HasPrefix(key, string(prefix)) {
+							want = append(want, key)
+						}
+					}
+					tm.WalkPrefix(prefix, func(key []byte, val int) bool {
+						got = append(got, string(key))
+						require.Equal(t, expected[string(key)], val)
+						return true
+					})
+					slices.Sort(want)
+					if tm.compare == nil {
+						slices.Sort(got)
+					}
+					require.Equal(t, want, got)
+
+					query := append(randKey(), randKey()...)
+					best := -1
+					for key := range expected {
+						if strings.HasPrefix(string(query), key) {
+							best = max(best, len(key))
+						}
+					}
+					key, val, err := tm.LongestPrefixOf(query)
+					if best == -1 {
+						require.Error(t, err)
+					} else {
+						require.NoError(t, err)
+						require.Equal(t, query[:best], key)
+						require.Equal(t, expected[string(key)], val)
+					}
+					checkTrieMapPruned(t, tm, tm.root, true)
+				}
+			}
+		})
+	}
+}
+
+// checkTrieMapPruned checks that every leaf node is the end of key
+func checkTrieMapPruned(t *testing.T, tm *trieMap[byte, int], node *trieMapNode[byte, int], isRoot bool) {
+	t.Helper()
+	if !isRoot && tm.numChildren(node) == 0 {
+		require.True(t, node.isEnd, "empty branch is not pruned")
+	}
+	for _, child := range node.children {
+		checkTrieMapPruned(t, tm, child, false)
+	}
+	for i, e := range node.sorted {
+		if i > 0 {
+			require.Less(t, node.sorted[i-1].key, e.key)
+		}
+		checkTrieMapPruned(t, tm, e.node, false)
+	}
+}
+
+// pathKeys returns file paths split by segments
+func pathKeys() [][]string {
+	rnd := rand.New(rand.NewSource(1))
+	keys := make([][]string, 10000)
+	for i := range keys {
+		keys[i] = []string{
+			"home",
+			fmt.Sprintf("user%d", rnd.Intn(20)),
+			[]string{"docs", "src", "music", "photos"}[rnd.Intn(4)],
+			fmt.Sprintf("dir%d", rnd.Intn(50)),
+			fmt.Sprintf("file%d", rnd.Intn(1000)),
+		}
+	}
+	return keys
+}
+
+func BenchmarkTrieMap_Memory_Map(b *testing.B) {
+	keys := pathKeys()
+	benchmarkMemory(b, func([]string) any {
+		tm := NewTrieMap[string, int]()
+		for i, key := range keys {
+			tm.Put(key, i)
+		}
+		return tm
+	})
+}
+
+func BenchmarkTrieMap_Memory_Sorted(b *testing.B) {
+	keys := pathKeys()
+	benchmarkMemory(b, func([]string) any {
+		tm := NewSortedTrieMap[string, int](strings.Compare)
+		for i, key := range keys {
+			tm.Put(key, i)
+		}
+		return tm
+	})
+}
@@ -2,9 +2,15 @@ package trees
 
 import (
 	"fmt"
+	"math/rand"
+	"path"
+	"slices"
+	"strings"
 	"testing"
 
+	gocollections "github.com/0x0FACED/go-collections"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stringComparator(a, b string) int {
@@ -132,3 +138,361 @@ func TestTrie_InsertAndSearch_Int(t *testing.T) {
 	assert.True(t, trieInts.Search(1))
 	assert.False(t, trieInts.Search(999))
 }
+
+func TestTrie_Delete(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	for _, word := range []string{"app", "apple", "apply", "bandana"} {
+		tr.Insert(word)
+	}
+
+	assert.EqualError(t, tr.Delete("ap"), gocollections.ErrNotFound)
+	assert.EqualError(t, tr.Delete("cat"), gocollections.ErrNotFound)
+
+	require.NoError(t, tr.Delete("app"))
+	assert.False(t, tr.Search("app"))
+	assert.True(t, tr.Search("apple"))
+	assert.True(t, tr.StartsWith("app"))
+	assert.EqualError(t, tr.Delete("app"), gocollections.ErrNotFound)
+
+	// "apple" shares "appl" with "apply", only "e" is pruned
+	require.NoError(t, tr.Delete("apple"))
+	assert.NotNil(t, tr.findNode("appl"))
+	assert.Nil(t, tr.findNode("apple"))
+
+	// whole branch is pruned
+	require.NoError(t, tr.Delete("bandana"))
+	assert.False(t, tr.StartsWith("b"))
+	_, exists := tr.root.children['b']
+	assert.False(t, exists)
+
+	require.NoError(t, tr.Delete("apply"))
+	assert.Empty(t, tr.root.children)
+	assert.Equal(t, 0, tr.CountByPrefix(""))
+}
+
+func TestTrie_KeysWithPrefix(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	for _, word := range []string{"banana", "apply", "app", "apricot", "apple", "band", "apps", "ápp"} {
+		tr.Insert(word)
+	}
+
+	assert.Equal(t, []string{"app", "apple", "apply", "apps"}, tr.KeysWithPrefix("app", 0))
+	assert.Equal(t, []string{"app", "apple"}, tr.KeysWithPrefix("app", 2))
+	assert.Equal(t, []string{"app", "apple", "apply", "apps", "apricot", "banana", "band", "ápp"}, tr.KeysWithPrefix("", -1))
+	assert.Empty(t, tr.KeysWithPrefix("cat", 10))
+
+	visited := 0
+	tr.WalkPrefix("a", func(item string) bool {
+		visited++
+		return item != "apply"
+	})
+	assert.Equal(t, 3, visited)
+
+	trieInts := NewTrie(intComparator, intToString)
+	for _, v := range []int{123, 1, 162, 18, 199, 12, 456} {
+		trieInts.Insert(v)
+	}
+	assert.Equal(t, []int{1, 12, 123, 162, 18, 199}, trieInts.KeysWithPrefix(1, 0))
+}
+
+func TestTrie_Random(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	expected := make(map[string]bool)
+	rnd := rand.New(rand.NewSource(1))
+	randWord := func() string {
+		b := make([]byte, rnd.Intn(6))
+		for i := range b {
+			b[i] = "abc"[rnd.Intn(3)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 3000; i++ {
+		word := randWord()
+		if rnd.Intn(3) == 0 {
+			err := tr.Delete(word)
+			assert.Equal(t, expected[word], err == nil)
+			delete(expected, word)
+		} else {
+			tr.Insert(word)
+			expected[word] = true
+		}
+
+		if i%100 == 0 {
+			prefix := randWord()
+			var want []string
+			for word := range expected {
+				if strings.HasPrefix(word, prefix) {
+					want = append(want, word)
+				}
+			}
+			slices.Sort(want)
+			limit := rnd.Intn(5)
+			got := tr.KeysWithPrefix(prefix, limit)
+			if limit > 0 && len(want) > limit {
+				want = want[:limit]
+			}
+			require.Equal(t, want, got)
+			checkTriePruned(t, tr.root, true)
+		}
+	}
+}
+
+// checkTriePruned checks that every leaf node is the end of item
+func checkTriePruned[T any](t *testing.T, node *trieNode[T], isRoot bool) {
+	t.Helper()
+	if !isRoot && len(node.children) == 0 {
+		require.True(t, node.isEnd, "empty branch is not pruned")
+	}
+	for _, child := range node.children {
+		checkTriePruned(t, child, false)
+	}
+}
+
+func TestTrie_TopK(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	for _, word := range []string{"car", "card", "care", "careful", "cart", "cat", "dog"} {
+		tr.Insert(word)
+	}
+	require.NoError(t, tr.IncrementScore("care", 5))
+	require.NoError(t, tr.IncrementScore("cart", 3))
+	require.NoError(t, tr.IncrementScore("careful", 3))
+	require.NoError(t, tr.IncrementScore("dog", 10))
+	require.NoError(t, tr.IncrementScore("cat", -1))
+	assert.EqualError(t, tr.IncrementScore("ca", 1), gocollections.ErrNotFound)
+
+	// equal scores are in lexicographic order
+	assert.Equal(t, []string{"care", "careful", "cart", "car", "card"}, tr.TopK("car", 10))
+	assert.Equal(t, []string{"care", "careful"}, tr.TopK("ca", 2))
+	assert.Equal(t, []string{"dog"}, tr.TopK("", 1))
+	assert.Empty(t, tr.TopK("x", 3))
+	assert.Empty(t, tr.TopK("c", 0))
+
+	// insert doesn't reset score
+	tr.Insert("care")
+	score, err := tr.Score("care")
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, score)
+
+	// deleted item is not returned and its score is not used by parents
+	require.NoError(t, tr.Delete("care"))
+	assert.Equal(t, []string{"careful", "cart"}, tr.TopK("car", 2))
+	assert.Equal(t, 3.0, tr.root.children['c'].maxScore)
+	_, err = tr.Score("care")
+	assert.EqualError(t, err, gocollections.ErrNotFound)
+
+	// inserted again -> score starts from 0
+	tr.Insert("care")
+	score, _ = tr.Score("care")
+	assert.Equal(t, 0.0, score)
+}
+
+func TestTrie_TopK_Random(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	scores := make(map[string]float64)
+	rnd := rand.New(rand.NewSource(2))
+	randWord := func() string {
+		b := make([]byte, 1+rnd.Intn(5))
+		for i := range b {
+			b[i] = "abcd"[rnd.Intn(4)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 5000; i++ {
+		word := randWord()
+		switch rnd.Intn(4) {
+		case 0:
+			err := tr.Delete(word)
+			_, ok := scores[word]
+			assert.Equal(t, ok, err == nil)
+			delete(scores, word)
+		case 1:
+			tr.Insert(word)
+			if _, ok := scores[word]; !ok {
+				scores[word] = 0
+			}
+		default:
+			delta := float64(rnd.Intn(21) - 5)
+			err := tr.IncrementScore(word, delta)
+			_, ok := scores[word]
+			require.Equal(t, ok, err == nil)
+			if ok {
+				scores[word] += delta
+			}
+		}
+
+		if i%50 == 0 {
+			prefix := randWord()[:rnd.Intn(2)]
+			k := 1 + rnd.Intn(10)
+			var want []string
+			for word := range scores {
+				if strings.HasPrefix(word, prefix) {
+					want = append(want, word)
+				}
+			}
+			slices.SortFunc(want, func(a, b string) int {
+				if scores[a] != scores[b] {
+					if scores[a] > scores[b] {
+						return -1
+					}
+					return 1
+				}
+				return strings.Compare(a, b)
+			})
+			if len(want) > k {
+				want = want[:k]
+			}
+			require.Equal(t, want, tr.TopK(prefix, k))
+		}
+	}
+}
+
+func BenchmarkTrie_TopK(b *testing.B) {
+	tr := NewTrie(stringComparator, stringToString)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		word := fmt.Sprintf("query %d", rnd.Intn(1000000))
+		tr.Insert(word)
+		_ = tr.IncrementScore(word, float64(rnd.Intn(1000)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tr.TopK("query 1", 10)
+	}
+}
+
+func TestTrie_FuzzySearch(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	for _, word := range []string{"hello", "help", "hell", "yellow", "shell", "from", "form", "world"} {
+		tr.Insert(word)
+	}
+
+	assert.Equal(t, []FuzzyMatch[string]{{"hello", 0}}, tr.FuzzySearch("hello", 0))
+	assert.Equal(t, []FuzzyMatch[string]{
+		{"hell", 1}, {"hello", 1}, {"help", 1},
+	}, tr.FuzzySearch("helo", 1))
+	assert.Equal(t, []FuzzyMatch[string]{
+		{"hello", 0}, {"hell", 1}, {"help", 2}, {"shell", 2}, {"yellow", 2},
+	}, tr.FuzzySearch("hello", 2))
+	assert.Empty(t, tr.FuzzySearch("xyz", 1))
+	assert.Empty(t, tr.FuzzySearch("hello", -1))
+
+	// swap is 2 edits for Levenshtein and 1 for Damerau
+	assert.Equal(t, []FuzzyMatch[string]{{"form", 0}}, tr.FuzzySearch("form", 1))
+	assert.Equal(t, []FuzzyMatch[string]{{"form", 0}, {"from", 1}}, tr.DamerauFuzzySearch("form", 1))
+}
+
+func TestTrie_FuzzySearch_Random(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	rnd := rand.New(rand.NewSource(3))
+	randWord := func() string {
+		b := make([]rune, rnd.Intn(7))
+		for i := range b {
+			b[i] = []rune("abcé")[rnd.Intn(4)]
+		}
+		return string(b)
+	}
+	var words []string
+	for i := 0; i < 500; i++ {
+		word := randWord()
+		tr.Insert(word)
+		words = append(words, word)
+	}
+	slices.Sort(words)
+	words = slices.Compact(words)
+
+	for i := 0; i < 100; i++ {
+		query := randWord()
+		maxDistance := rnd.Intn(3)
+		for _, damerau := range []bool{false, true} {
+			var want []FuzzyMatch[string]
+			for _, word := range words {
+				if d := editDistance(query, word, damerau); d <= maxDistance {
+					want = append(want, FuzzyMatch[string]{word, d})
+				}
+			}
+			slices.SortStableFunc(want, func(a, b FuzzyMatch[string]) int {
+				return a.Distance - b.Distance
+			})
+
+			got := tr.FuzzySearch(query, maxDistance)
+			if damerau {
+				got = tr.DamerauFuzzySearch(query, maxDistance)
+			}
+			require.Equal(t, want, got, "query %q, distance %d, damerau %v", query, maxDistance, damerau)
+		}
+	}
+}
+
+// editDistance is Levenshtein or optimal string alignment distance by full DP table
+func editDistance(a, b string, transpositions bool) int {
+	x, y := []rune(a), []rune(b)
+	d := make([][]int, len(x)+1)
+	for i := range d {
+		d[i] = make([]int, len(y)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if transpositions && i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(x)][len(y)]
+}
+
+func TestTrie_Match(t *testing.T) {
+	tr := NewTrie(stringComparator, stringToString)
+	for _, word := range []string{"cat", "cart", "coat", "cut", "dog", "ct", "c"} {
+		tr.Insert(word)
+	}
+
+	assert.Equal(t, []string{"cat", "cut"}, tr.Match("c?t"))
+	assert.Equal(t, []string{"cart", "cat", "coat", "ct", "cut"}, tr.Match("c*t"))
+	assert.Equal(t, []string{"cart", "coat"}, tr.Match("c??t"))
+	assert.Equal(t, []string{"c", "cart", "cat", "coat", "ct", "cut", "dog"}, tr.Match("*"))
+	assert.Equal(t, []string{"cart", "cat", "coat"}, tr.Match("*a*"))
+	assert.Equal(t, []string{"dog"}, tr.Match("dog"))
+	assert.Empty(t, tr.Match("do"))
+	assert.Empty(t, tr.Match(""))
+
+	rnd := rand.New(rand.NewSource(4))
+	random := NewTrie(stringComparator, stringToString)
+	var words []string
+	for i := 0; i < 300; i++ {
+		b := make([]byte, rnd.Intn(6))
+		for i := range b {
+			b[i] = "ab"[rnd.Intn(2)]
+		}
+		random.Insert(string(b))
+		words = append(words, string(b))
+	}
+	slices.Sort(words)
+	words = slices.Compact(words)
+
+	for i := 0; i < 200; i++ {
+		p := make([]byte, rnd.Intn(5))
+		for i := range p {
+			p[i] = "ab?*"[rnd.Intn(4)]
+		}
+		var want []string
+		for _, word := range words {
+			// words have no '/', so path.Match has the same wildcards
+			if ok, _ := path.Match(string(p), word); ok {
+				want = append(want, word)
+			}
+		}
+		require.Equal(t, want, random.Match(string(p)), "pattern %q", p)
+	}
+}
@@ -0,0 +1,231 @@
+package trees
+
+import (
+	"fmt"
+	"sync"
+
+	gocollections "github.com/0x0FACED/go-collections"
+)
+
+// VEBTree is the interface of set of integers from universe [0, 2^bits)
+// with all operations in O(log log U).
+//
+//	v, err := trees.NewVEBTree(16) // universe [0, 65536)
+//	v.Insert(42)
+//	next, err := v.Successor(10) // 42
+type VEBTree interface {
+	// Insert inserts `x`, inserting of existing item does nothing
+	//
+	// if x is out of universe -> returns err
+	Insert(x uint64) error
+
+	// Delete deletes `x`
+	//
+	// if there is no x in set -> returns err
+	Delete(x uint64) error
+
+	// Member returns true if `x` is in set
+	Member(x uint64) bool
+
+	// Successor returns the smallest item > x
+	//
+	// if there is no such item -> returns err
+	Successor(x uint64) (uint64, error)
+
+	// Predecessor returns the largest item < x
+	//
+	// if there is no such item -> returns err
+	Predecessor(x uint64) (uint64, error)
+
+	// Min returns the smallest item
+	//
+	// if set is empty -> returns err
+	Min() (uint64, error)
+
+	// Max returns the largest item
+	//
+	// if set is empty -> returns err
+	Max() (uint64, error)
+
+	// Size returns number of items
+	Size() int
+
+	// IsEmpty returns true if there are no items
+	IsEmpty() bool
+}
+
+// vebNode - node of Van Emde Boas Tree for universe [0, 2^bits).
+//
+// Item x is divided into high and low halves of bits: high is number of cluster,
+// low is item in cluster of universe 2^(bits/2). Query goes to one cluster
+// or to summary, so bits are halved on each level: O(log log U).
+//
+// # min 	-> the smallest item, it is not stored in clusters
+//
+// # max 	-> the largest item, also stored in its cluster (if it is not min)
+//
+// # summary 	-> set of numbers of not empty clusters, nil if all are empty
+//
+// # clusters 	-> clusters by number, nil is empty cluster
+//
+// # lazy 	-> clusters in map instead of slice: only not empty ones take memory
+type vebNode struct {
+	bits  uint
+	min   uint64
+	max   uint64
+	empty bool
+
+	summary  *vebNode
+	clusters []*vebNode
+	lazy     map[uint64]*vebNode
+}
+
+// vebTree - Van Emde Boas Tree.
+//
+// Slice of clusters of every node takes O(sqrt U) memory, so universe is
+// limited by 32 bits. Lazy variant keeps clusters in map and takes O(n log log U)
+// memory, so it works for sparse sets of 64 bits integers.
+type vebTree struct {
+	root   *vebNode
+	size   int
+	bits   uint
+	isLazy bool
+
+	mu sync.Mutex
+}
+
+// NewVEBTree creates Van Emde Boas Tree for universe [0, 2^bits) with clusters in slices
+//
+// if bits < 1 or bits > 32 -> returns err
+func NewVEBTree(bits int) (*vebTree, error) {
+	if bits < 1 {
+		return nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	if bits > 32 {
+		return nil, fmt.Errorf(gocollections.ErrTooLarge)
+	}
+	return &vebTree{root: newVEBNode(uint(bits), false), bits: uint(bits)}, nil
+}
+
+// NewLazyVEBTree creates Van Emde Boas Tree for universe [0, 2^bits)
+// with clusters in hash maps, which are created only for not empty clusters
+//
+// if bits < 1 or bits > 64 -> returns err
+func NewLazyVEBTree(bits int) (*vebTree, error) {
+	if bits < 1 {
+		return nil, fmt.Errorf(gocollections.ErrInvalidData)
+	}
+	if bits > 64 {
+		return nil, fmt.Errorf(gocollections.ErrTooLarge)
+	}
+	return &vebTree{root: newVEBNode(uint(bits), true), bits: uint(bits), isLazy: true}, nil
+}
+
+// Insert inserts `x`, inserting of existing item does nothing
+func (v *vebTree) Insert(x uint64) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.inUniverse(x) {
+		return fmt.Errorf(gocollections.ErrOutOfBounds)
+	}
+	if v.root.member(x) {
+		return nil
+	}
+	v.root.insert(x, v.isLazy)
+	v.size++
+	return nil
+}
+
+// Delete deletes `x`
+func (v *vebTree) Delete(x uint64) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.inUniverse(x) || !v.root.member(x) {
+		return fmt.Errorf(gocollections.ErrNotFound)
+	}
+	v.root.delete(x)
+	v.size--
+	return nil
+}
+
+// Member returns true if `x` is in set
+func (v *vebTree) Member(x uint64) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.inUniverse(x) && v.root.member(x)
+}
+
+// Successor returns the smallest item > x
+func (v *vebTree) Successor(x uint64) (uint64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.inUniverse(x) {
+		return 0, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	next, ok := v.root.successor(x)
+	if !ok {
+		return 0, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return next, nil
+}
+
+// Predecessor returns the largest item < x
+func (v *vebTree) Predecessor(x uint64) (uint64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.inUniverse(x) {
+		// all items are < x
+		if v.root.empty {
+			return 0, fmt.Errorf(gocollections.ErrNotFound)
+		}
+		return v.root.max, nil
+	}
+	prev, ok := v.root.predecessor(x)
+	if !ok {
+		return 0, fmt.Errorf(gocollections.ErrNotFound)
+	}
+	return prev, nil
+}
+
+// Min returns the smallest item
+func (v *vebTree) Min() (uint64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.root.empty {
+		return 0, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return v.root.min, nil
+}
+
+// Max returns the largest item
+func (v *vebTree) Max() (uint64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.root.empty {
+		return 0, fmt.Errorf(gocollections.ErrEmpty)
+	}
+	return v.root.max, nil
+}
+
+// Size returns number of items
+func (v *vebTree) Size() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.size
+}
+
+// IsEmpty returns true if there are no items
+func (v *vebTree) IsEmpty() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.size == 0
+}
@@ -0,0 +1,214 @@
+package trees
+
+func newVEBNode(bits uint, lazy bool) *vebNode {
+	n := &vebNode{bits: bits, empty: true}
+	if bits > 1 {
+		if lazy {
+			n.lazy = make(map[uint64]*vebNode)
+		} else {
+			n.clusters = make([]*vebNode, 1<<n.highBits())
+		}
+	}
+	return n
+}
+
+// inUniverse returns true if x < 2^bits
+func (v *vebTree) inUniverse(x uint64) bool {
+	return v.bits == 64 || x>>v.bits == 0
+}
+
+func (n *vebNode) lowBits() uint {
+	return n.bits / 2
+}
+
+func (n *vebNode) highBits() uint {
+	return n.bits - n.lowBits()
+}
+
+// high returns number of cluster of `x`
+func (n *vebNode) high(x uint64) uint64 {
+	return x >> n.lowBits()
+}
+
+// low returns item of `x` in its cluster
+func (n *vebNode) low(x uint64) uint64 {
+	return x & (1<<n.lowBits() - 1)
+}
+
+// index returns item from number of cluster and item in cluster
+func (n *vebNode) index(high, low uint64) uint64 {
+	return high<<n.lowBits() | low
+}
+
+func (n *vebNode) cluster(high uint64) *vebNode {
+	if n.lazy != nil {
+		return n.lazy[high]
+	}
+	return n.clusters[high]
+}
+
+func (n *vebNode) setCluster(high uint64, c *vebNode) {
+	if n.lazy != nil {
+		if c == nil {
+			delete(n.lazy, high)
+		} else {
+			n.lazy[high] = c
+		}
+		return
+	}
+	n.clusters[high] = c
+}
+
+// member returns true if `x` is in subtree
+func (n *vebNode) member(x uint64) bool {
+	for {
+		if n.empty {
+			return false
+		}
+		if x == n.min || x == n.max {
+			return true
+		}
+		if n.bits == 1 {
+			return false
+		}
+		c := n.cluster(n.high(x))
+		if c == nil {
+			return false
+		}
+		n, x = c, n.low(x)
+	}
+}
+
+// insert inserts `x` which is not in subtree.
+// If new min is less than min, old min goes down instead of `x`
+func (n *vebNode) insert(x uint64, lazy bool) {
+	if n.empty {
+		n.min, n.max, n.empty = x, x, false
+		return
+	}
+	if x < n.min {
+		x, n.min = n.min, x
+	}
+	if n.bits > 1 {
+		h, l := n.high(x), n.low(x)
+		c := n.cluster(h)
+		if c == nil {
+			// empty cluster: O(1) insert to it, so only summary goes deeper
+			c = newVEBNode(n.lowBits(), lazy)
+			n.setCluster(h, c)
+			if n.summary == nil {
+				n.summary = newVEBNode(n.highBits(), lazy)
+			}
+			n.summary.insert(h, lazy)
+		}
+		c.insert(l, lazy)
+	}
+	if x > n.max {
+		n.max = x
+	}
+}
+
+// delete deletes `x` which is in subtree
+func (n *vebNode) delete(x uint64) {
+	if n.min == n.max {
+		n.empty = true
+		return
+	}
+	if n.bits == 1 {
+		// 2 items: the other stays
+		n.min = 1 - x
+		n.max = n.min
+		return
+	}
+
+	if x == n.min {
+		// the next item becomes min and is deleted from its cluster
+		first := n.summary.min
+		x = n.index(first, n.cluster(first).min)
+		n.min = x
+	}
+
+	h := n.high(x)
+	c := n.cluster(h)
+	c.delete(n.low(x))
+	if c.empty {
+		// the only item of cluster: O(1) delete from it, so only summary goes deeper
+		n.setCluster(h, nil)
+		n.summary.delete(h)
+		if n.summary.empty {
+			n.summary = nil
+		}
+		if x == n.max {
+			if n.summary == nil {
+				n.max = n.min
+			} else {
+				last := n.summary.max
+				n.max = n.index(last, n.cluster(last).max)
+			}
+		}
+	} else if x == n.max {
+		n.max = n.index(h, c.max)
+	}
+}
+
+// successor returns the smallest item > x in subtree
+func (n *vebNode) successor(x uint64) (uint64, bool) {
+	if n.empty {
+		return 0, false
+	}
+	if n.bits == 1 {
+		if x == 0 && n.max == 1 {
+			return 1, true
+		}
+		return 0, false
+	}
+	if x < n.min {
+		return n.min, true
+	}
+
+	h, l := n.high(x), n.low(x)
+	if c := n.cluster(h); c != nil && l < c.max {
+		next, _ := c.successor(l)
+		return n.index(h, next), true
+	}
+	if n.summary == nil {
+		return 0, false
+	}
+	nextCluster, ok := n.summary.successor(h)
+	if !ok {
+		return 0, false
+	}
+	return n.index(nextCluster, n.cluster(nextCluster).min), true
+}
+
+// predecessor returns the largest item < x in subtree
+func (n *vebNode) predecessor(x uint64) (uint64, bool) {
+	if n.empty {
+		return 0, false
+	}
+	if n.bits == 1 {
+		if x == 1 && n.min == 0 {
+			return 0, true
+		}
+		return 0, false
+	}
+	if x > n.max {
+		return n.max, true
+	}
+
+	h, l := n.high(x), n.low(x)
+	if c := n.cluster(h); c != nil && l > c.min {
+		prev, _ := c.predecessor(l)
+		return n.index(h, prev), true
+	}
+	if n.summary != nil {
+		if prevCluster, ok := n.summary.predecessor(h); ok {
+			return n.index(prevCluster, n.cluster(prevCluster).max), true
+		}
+	}
+	// min is not in clusters
+	if x > n.min {
+		return n.min, true
+	}
+	return 0, false
+}
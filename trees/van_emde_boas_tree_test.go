@@ -0,0 +1,226 @@
+package trees
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	gocollections "github.com/0x0FACED/go-collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVEBTree_Basic(t *testing.T) {
+	for _, newTree := range []func(bits int) (*vebTree, error){NewVEBTree, NewLazyVEBTree} {
+		v, err := newTree(8)
+		require.NoError(t, err)
+		assert.True(t, v.IsEmpty())
+		_, err = v.Min()
+		assert.EqualError(t, err, gocollections.ErrEmpty)
+
+		for _, x := range []uint64{42, 7, 255, 0, 100, 42} {
+			require.NoError(t, v.Insert(x))
+		}
+		assert.EqualError(t, v.Insert(256), gocollections.ErrOutOfBounds)
+		assert.Equal(t, 5, v.Size())
+		assert.True(t, v.Member(100))
+		assert.False(t, v.Member(101))
+		assert.False(t, v.Member(1000))
+
+		minItem, _ := v.Min()
+		maxItem, _ := v.Max()
+		assert.Equal(t, uint64(0), minItem)
+		assert.Equal(t, uint64(255), maxItem)
+
+		next, err := v.Successor(42)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), next)
+		prev, err := v.Predecessor(42)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), prev)
+		_, err = v.Successor(255)
+		assert.EqualError(t, err, gocollections.ErrNotFound)
+		_, err = v.Predecessor(0)
+		assert.EqualError(t, err, gocollections.ErrNotFound)
+		prev, _ = v.Predecessor(1000)
+		assert.Equal(t, uint64(255), prev)
+
+		require.NoError(t, v.Delete(0))
+		require.NoError(t, v.Delete(255))
+		assert.EqualError(t, v.Delete(255), gocollections.ErrNotFound)
+		minItem, _ = v.Min()
+		maxItem, _ = v.Max()
+		assert.Equal(t, uint64(7), minItem)
+		assert.Equal(t, uint64(100), maxItem)
+	}
+
+	_, err := NewVEBTree(0)
+	assert.EqualError(t, err, gocollections.ErrInvalidData)
+	_, err = NewVEBTree(33)
+	assert.EqualError(t, err, gocollections.ErrTooLarge)
+	_, err = NewLazyVEBTree(65)
+	assert.EqualError(t, err, gocollections.ErrTooLarge)
+}
+
+func TestVEBTree_Random(t *testing.T) {
+	for _, bits := range []int{1, 2, 3, 5, 8, 11} {
+		for _, lazy := range []bool{false, true} {
+			t.Run(fmt.Sprintf("bits=%d lazy=%v", bits, lazy), func(t *testing.T) {
+				newTree := NewVEBTree
+				if lazy {
+					newTree = NewLazyVEBTree
+				}
+				v, err := newTree(bits)
+				require.NoError(t, err)
+				universe := uint64(1) << bits
+				testVEBTreeRandom(t, v, func(rnd *rand.Rand) uint64 {
+					return uint64(rnd.Int63n(int64(universe)))
+				}, 1)
+			})
+		}
+	}
+}
+
+func TestVEBTree_Lazy64(t *testing.T) {
+	v, err := NewLazyVEBTree(64)
+	require.NoError(t, err)
+	testVEBTreeRandom(t, v, func(rnd *rand.Rand) uint64 {
+		// items near each other and near the ends of universe
+		switch rnd.Intn(3) {
+		case 0:
+			return rnd.Uint64()
+		case 1:
+			return uint64(rnd.Intn(100))
+		}
+		return math.MaxUint64 - uint64(rnd.Intn(100))
+	}, 2)
+	require.NoError(t, v.Insert(math.MaxUint64))
+	maxItem, _ := v.Max()
+	assert.Equal(t, uint64(math.MaxUint64), maxItem)
+}
+
+// testVEBTreeRandom compares VEBTree with sorted slice on random operations
+func testVEBTreeRandom(t *testing.T, v *vebTree, randItem func(rnd *rand.Rand) uint64, seed int64) {
+	rnd := rand.New(rand.NewSource(seed))
+	var expected []uint64
+	for i := 0; i < 5000; i++ {
+		x := randItem(rnd)
+		idx, found := slices.BinarySearch(expected, x)
+		switch rnd.Intn(3) {
+		case 0:
+			err := v.Delete(x)
+			require.Equal(t, found, err == nil)
+			if found {
+				expected = slices.Delete(expected, idx, idx+1)
+			}
+		default:
+			require.NoError(t, v.Insert(x))
+			if !found {
+				expected = slices.Insert(expected, idx, x)
+			}
+		}
+		require.Equal(t, len(expected), v.Size())
+
+		q := randItem(rnd)
+		idx, found = slices.BinarySearch(expected, q)
+		require.Equal(t, found, v.Member(q))
+
+		next, err := v.Successor(q)
+		if found {
+			idx++
+		}
+		if idx < len(expected) {
+			require.NoError(t, err)
+			require.Equal(t, expected[idx], next, "successor of %d", q)
+		} else {
+			require.EqualError(t, err, gocollections.ErrNotFound)
+		}
+
+		prev, err := v.Predecessor(q)
+		idx, _ = slices.BinarySearch(expected, q)
+		if idx > 0 {
+			require.NoError(t, err)
+			require.Equal(t, expected[idx-1], prev, "predecessor of %d", q)
+		} else {
+			require.EqualError(t, err, gocollections.ErrNotFound)
+		}
+
+		if len(expected) > 0 {
+			minItem, _ := v.Min()
+			maxItem, _ := v.Max()
+			require.Equal(t, expected[0], minItem)
+			require.Equal(t, expected[len(expected)-1], maxItem)
+		}
+	}
+
+	// empty clusters are removed
+	for _, x := range expected {
+		require.NoError(t, v.Delete(x))
+	}
+	assert.True(t, v.IsEmpty())
+	assert.Nil(t, v.root.summary)
+}
+
+// benchmarkVEB runs `fn` on VEBTree with the same items as benchmarkSearch
+// (permutation of [0, 2^20)), so results are comparable with BenchmarkRBT_Search
+func benchmarkVEB(b *testing.B, newTree func(bits int) (*vebTree, error), fn func(v *vebTree, x uint64)) {
+	n := 1 << 20
+	rnd := rand.New(rand.NewSource(1))
+	v, _ := newTree(20)
+	for _, x := range rnd.Perm(n) {
+		v.Insert(uint64(x))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn(v, uint64(rnd.Intn(n)))
+	}
+}
+
+func BenchmarkVEBTree_Member(b *testing.B) {
+	benchmarkVEB(b, NewVEBTree, func(v *vebTree, x uint64) { v.Member(x) })
+}
+
+func BenchmarkLazyVEBTree_Member(b *testing.B) {
+	benchmarkVEB(b, NewLazyVEBTree, func(v *vebTree, x uint64) { v.Member(x) })
+}
+
+func BenchmarkVEBTree_Successor(b *testing.B) {
+	benchmarkVEB(b, NewVEBTree, func(v *vebTree, x uint64) { v.Successor(x) })
+}
+
+func BenchmarkLazyVEBTree_Successor(b *testing.B) {
+	benchmarkVEB(b, NewLazyVEBTree, func(v *vebTree, x uint64) { v.Successor(x) })
+}
+
+func BenchmarkVEBTree_DeleteInsert(b *testing.B) {
+	benchmarkVEB(b, NewVEBTree, func(v *vebTree, x uint64) {
+		v.Delete(x)
+		v.Insert(x)
+	})
+}
+
+func BenchmarkLazyVEBTree_DeleteInsert(b *testing.B) {
+	benchmarkVEB(b, NewLazyVEBTree, func(v *vebTree, x uint64) {
+		v.Delete(x)
+		v.Insert(x)
+	})
+}
+
+func BenchmarkRBT_DeleteInsert(b *testing.B) {
+	n := 1 << 20
+	rnd := rand.New(rand.NewSource(1))
+	tr := NewRBT(intComparator)
+	for _, x := range rnd.Perm(n) {
+		tr.Insert(x)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x := rnd.Intn(n)
+		tr.Delete(x)
+		tr.Insert(x)
+	}
+}